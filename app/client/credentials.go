@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/waifu-devs/fuwa/client/proto"
+	"google.golang.org/grpc/credentials"
+)
+
+// refreshLeeway is how long before expiry an access token gets rotated.
+const refreshLeeway = 30 * time.Second
+
+// Credentials are the account details used to log in when connecting.
+type Credentials struct {
+	Username string
+	Password string
+	// Register creates the account before logging in
+	Register bool
+}
+
+// tokenCredentials attaches the current access token to every RPC and
+// transparently rotates it with the refresh token shortly before it expires.
+type tokenCredentials struct {
+	mu               sync.Mutex
+	auth             pb.AuthServiceClient
+	accessToken      string
+	refreshToken     string
+	accessExpiresAt  time.Time
+	refreshExpiresAt time.Time
+}
+
+func (t *tokenCredentials) setTokens(tokens *pb.AuthTokens) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.applyTokens(tokens)
+}
+
+func (t *tokenCredentials) applyTokens(tokens *pb.AuthTokens) {
+	if tokens == nil {
+		t.accessToken = ""
+		t.refreshToken = ""
+		t.accessExpiresAt = time.Time{}
+		t.refreshExpiresAt = time.Time{}
+		return
+	}
+	t.accessToken = tokens.AccessToken
+	t.refreshToken = tokens.RefreshToken
+	t.accessExpiresAt = tokens.AccessTokenExpiresAt.AsTime()
+	t.refreshExpiresAt = tokens.RefreshTokenExpiresAt.AsTime()
+}
+
+func (t *tokenCredentials) currentRefreshToken() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.refreshToken
+}
+
+func (t *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	// AuthService calls carry their own credentials in the request body
+	if info, ok := credentials.RequestInfoFromContext(ctx); ok && strings.HasPrefix(info.Method, "/fuwa.AuthService/") {
+		return nil, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.accessToken == "" {
+		return nil, nil
+	}
+
+	if time.Until(t.accessExpiresAt) < refreshLeeway && t.refreshToken != "" && time.Now().Before(t.refreshExpiresAt) {
+		resp, err := t.auth.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: t.refreshToken})
+		if err != nil {
+			log.Printf("Failed to refresh access token: %v", err)
+		} else {
+			t.applyTokens(resp.Tokens)
+		}
+	}
+
+	return map[string]string{
+		"authorization": "Bearer " + t.accessToken,
+	}, nil
+}
+
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
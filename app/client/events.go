@@ -8,18 +8,28 @@ import (
 )
 
 type EventHandler struct {
-	manager     *Manager
-	subscribers map[string]context.CancelFunc
-	MessageChan chan *pb.Message
-	ChannelChan chan *pb.Channel
+	manager      *Manager
+	subscribers  map[string]context.CancelFunc
+	MessageChan  chan *pb.Message
+	ChannelChan  chan *pb.Channel
+	ReactionChan chan *ReactionEvent
+	ThreadChan   chan *pb.Channel
+}
+
+// ReactionEvent is a reaction added to or removed from a message.
+type ReactionEvent struct {
+	*pb.MessageReactionPayload
+	Added bool
 }
 
 func NewEventHandler(manager *Manager) *EventHandler {
 	return &EventHandler{
-		manager:     manager,
-		subscribers: make(map[string]context.CancelFunc),
-		MessageChan: make(chan *pb.Message, 100),
-		ChannelChan: make(chan *pb.Channel, 100),
+		manager:      manager,
+		subscribers:  make(map[string]context.CancelFunc),
+		MessageChan:  make(chan *pb.Message, 100),
+		ChannelChan:  make(chan *pb.Channel, 100),
+		ReactionChan: make(chan *ReactionEvent, 100),
+		ThreadChan:   make(chan *pb.Channel, 100),
 	}
 }
 
@@ -40,7 +50,7 @@ func (e *EventHandler) Subscribe(serverID string) error {
 		}()
 
 		stream, err := clients.Event.Subscribe(ctx, &pb.SubscribeRequest{
-			EventTypes: []string{"message.sent", "channel.created", "channel.updated"},
+			EventTypes: []string{"message.sent", "message.reaction_added", "message.reaction_removed", "channel.created", "channel.updated", "thread.created", "thread.updated"},
 			Scopes:     []string{"server:" + serverID},
 		})
 		if err != nil {
@@ -68,35 +78,75 @@ func (e *EventHandler) Subscribe(serverID string) error {
 }
 
 func (e *EventHandler) handleEvent(event *pb.Event) {
-	switch event.EventType {
-	case "message.sent":
-		// For simplicity, we'll create a mock message from the event
-		// In a real implementation, you'd properly deserialize the payload
-		message := &pb.Message{
-			MessageId: event.EventId,
-			Content:   "Event: " + event.EventType,
-			AuthorId:  event.ActorId,
+	if event.Payload == nil {
+		return
+	}
+
+	payload, err := event.Payload.UnmarshalNew()
+	if err != nil {
+		log.Printf("Failed to decode payload of %s event %s: %v", event.EventType, event.EventId, err)
+		return
+	}
+
+	switch payload := payload.(type) {
+	case *pb.MessageSentPayload:
+		if payload.Message == nil {
+			return
 		}
 
 		select {
-		case e.MessageChan <- message:
+		case e.MessageChan <- payload.Message:
 		default:
 			log.Println("Message channel full, dropping message event")
 		}
 
-	case "channel.created", "channel.updated":
-		// Mock channel event
-		channel := &pb.Channel{
-			ChannelId: event.EventId,
-			Name:      "Event Channel",
-			ServerId:  extractServerIdFromScope(event.Scope),
+	case *pb.MessageReactionPayload:
+		reaction := &ReactionEvent{
+			MessageReactionPayload: payload,
+			Added:                  event.EventType == "message.reaction_added",
 		}
 
 		select {
-		case e.ChannelChan <- channel:
+		case e.ReactionChan <- reaction:
 		default:
-			log.Println("Channel channel full, dropping channel event")
+			log.Println("Reaction channel full, dropping reaction event")
 		}
+
+	case *pb.ChannelCreatedPayload:
+		e.sendChannel(payload.Channel)
+
+	case *pb.ChannelUpdatedPayload:
+		e.sendChannel(payload.Channel)
+
+	case *pb.ThreadCreatedPayload:
+		e.sendThread(payload.Thread)
+
+	case *pb.ThreadUpdatedPayload:
+		e.sendThread(payload.Thread)
+	}
+}
+
+func (e *EventHandler) sendThread(thread *pb.Channel) {
+	if thread == nil || thread.Thread == nil {
+		return
+	}
+
+	select {
+	case e.ThreadChan <- thread:
+	default:
+		log.Println("Thread channel full, dropping thread event")
+	}
+}
+
+func (e *EventHandler) sendChannel(channel *pb.Channel) {
+	if channel == nil {
+		return
+	}
+
+	select {
+	case e.ChannelChan <- channel:
+	default:
+		log.Println("Channel channel full, dropping channel event")
 	}
 }
 
@@ -116,11 +166,6 @@ func (e *EventHandler) Close() {
 	e.subscribers = make(map[string]context.CancelFunc)
 	close(e.MessageChan)
 	close(e.ChannelChan)
-}
-
-func extractServerIdFromScope(scope string) string {
-	if len(scope) > 7 && scope[:7] == "server:" {
-		return scope[7:]
-	}
-	return scope
+	close(e.ReactionChan)
+	close(e.ThreadChan)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log"
 	"sync"
+	"time"
 
 	pb "github.com/waifu-devs/fuwa/client/proto"
 	"google.golang.org/grpc"
@@ -19,10 +20,13 @@ var (
 type Manager struct {
 	connections map[string]*grpc.ClientConn
 	clients     map[string]*Clients
+	tokens      map[string]*tokenCredentials
+	users       map[string]*pb.User
 	mu          sync.RWMutex
 }
 
 type Clients struct {
+	Auth    pb.AuthServiceClient
 	Event   pb.EventServiceClient
 	Channel pb.ChannelServiceClient
 	Message pb.MessageServiceClient
@@ -33,10 +37,14 @@ func NewManager() *Manager {
 	return &Manager{
 		connections: make(map[string]*grpc.ClientConn),
 		clients:     make(map[string]*Clients),
+		tokens:      make(map[string]*tokenCredentials),
+		users:       make(map[string]*pb.User),
 	}
 }
 
-func (m *Manager) Connect(serverID, address string) error {
+// Connect dials the server and, when creds is non-nil, logs in (registering
+// first if requested) so every subsequent RPC carries the access token.
+func (m *Manager) Connect(serverID, address string, creds *Credentials) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -44,33 +52,118 @@ func (m *Manager) Connect(serverID, address string) error {
 		return nil
 	}
 
-	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	tokens := &tokenCredentials{}
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(tokens),
+	)
 	if err != nil {
 		return err
 	}
 
 	clients := &Clients{
+		Auth:    pb.NewAuthServiceClient(conn),
 		Event:   pb.NewEventServiceClient(conn),
 		Channel: pb.NewChannelServiceClient(conn),
 		Message: pb.NewMessageServiceClient(conn),
 		Config:  pb.NewConfigServiceClient(conn),
 	}
 
+	tokens.auth = clients.Auth
+
+	if creds != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		user, err := login(ctx, clients.Auth, tokens, creds)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+		m.users[serverID] = user
+		log.Printf("Logged in to server %s as %s", serverID, user.Username)
+	}
+
 	m.connections[serverID] = conn
 	m.clients[serverID] = clients
+	m.tokens[serverID] = tokens
 
 	log.Printf("Connected to server %s at %s", serverID, address)
 	return nil
 }
 
+func login(ctx context.Context, auth pb.AuthServiceClient, tokens *tokenCredentials, creds *Credentials) (*pb.User, error) {
+	if creds.Register {
+		resp, err := auth.Register(ctx, &pb.RegisterRequest{
+			Username: creds.Username,
+			Password: creds.Password,
+		})
+		if err != nil {
+			return nil, err
+		}
+		tokens.setTokens(resp.Tokens)
+		return resp.User, nil
+	}
+
+	resp, err := auth.Login(ctx, &pb.LoginRequest{
+		Username: creds.Username,
+		Password: creds.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tokens.setTokens(resp.Tokens)
+	return resp.User, nil
+}
+
+// CurrentUser returns the account logged in on the given server, if any.
+func (m *Manager) CurrentUser(serverID string) (*pb.User, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	user, exists := m.users[serverID]
+	return user, exists
+}
+
+// Logout revokes the session on the server and forgets its tokens.
+func (m *Manager) Logout(ctx context.Context, serverID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.logoutLocked(ctx, serverID)
+}
+
+func (m *Manager) logoutLocked(ctx context.Context, serverID string) error {
+	clients, exists := m.clients[serverID]
+	if !exists {
+		return ErrServerNotConnected
+	}
+	tokens := m.tokens[serverID]
+	delete(m.users, serverID)
+
+	refreshToken := tokens.currentRefreshToken()
+	if refreshToken == "" {
+		return nil
+	}
+	tokens.setTokens(nil)
+
+	_, err := clients.Auth.Logout(ctx, &pb.LogoutRequest{RefreshToken: refreshToken})
+	return err
+}
+
 func (m *Manager) Disconnect(serverID string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	if conn, exists := m.connections[serverID]; exists {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := m.logoutLocked(ctx, serverID); err != nil {
+			log.Printf("Failed to log out from server %s: %v", serverID, err)
+		}
+		cancel()
+
 		conn.Close()
 		delete(m.connections, serverID)
 		delete(m.clients, serverID)
+		delete(m.tokens, serverID)
 		log.Printf("Disconnected from server %s", serverID)
 	}
 }
@@ -111,6 +204,19 @@ func (m *Manager) GetMessages(ctx context.Context, channelID string, limit int32
 	return nil, ErrNoServerAvailable
 }
 
+// ListActiveThreads returns the unarchived threads of a channel.
+func (m *Manager) ListActiveThreads(ctx context.Context, channelID string) ([]*pb.Channel, error) {
+	for _, clients := range m.clients {
+		resp, err := clients.Channel.ListActiveThreads(ctx, &pb.ListActiveThreadsRequest{
+			ChannelId: channelID,
+		})
+		if err == nil {
+			return resp.Threads, nil
+		}
+	}
+	return nil, ErrNoServerAvailable
+}
+
 func (m *Manager) SendMessage(ctx context.Context, channelID, content string) (*pb.Message, error) {
 	for _, clients := range m.clients {
 		resp, err := clients.Message.SendMessage(ctx, &pb.SendMessageRequest{
@@ -153,4 +259,6 @@ func (m *Manager) Close() {
 
 	m.connections = make(map[string]*grpc.ClientConn)
 	m.clients = make(map[string]*Clients)
+	m.tokens = make(map[string]*tokenCredentials)
+	m.users = make(map[string]*pb.User)
 }
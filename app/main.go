@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -42,7 +43,7 @@ func main() {
 
 	for !rl.WindowShouldClose() {
 		handleInput(app, manager, eventHandler)
-		handleEvents(app, eventHandler)
+		handleEvents(app, manager, eventHandler)
 
 		rl.BeginDrawing()
 		rl.ClearBackground(rl.Color{54, 57, 63, 255}) // Discord dark background
@@ -67,6 +68,9 @@ func handleInput(app *types.AppState, manager *client.Manager, eventHandler *cli
 	if rl.IsKeyPressed(rl.KeyN) && rl.IsKeyDown(rl.KeyLeftControl) {
 		app.ShowConnectionDialog = true
 		app.ConnectionInput = "localhost:50051"
+		app.UsernameInput = ""
+		app.PasswordInput = ""
+		app.ConnectionField = types.ConnectionFieldAddress
 	}
 
 	if rl.IsKeyPressed(rl.KeyC) && rl.IsKeyDown(rl.KeyLeftControl) && app.CurrentServer != nil {
@@ -90,22 +94,39 @@ func handleInput(app *types.AppState, manager *client.Manager, eventHandler *cli
 }
 
 func handleConnectionDialog(app *types.AppState, manager *client.Manager, eventHandler *client.EventHandler) {
+	var input *string
+	switch app.ConnectionField {
+	case types.ConnectionFieldUsername:
+		input = &app.UsernameInput
+	case types.ConnectionFieldPassword:
+		input = &app.PasswordInput
+	default:
+		input = &app.ConnectionInput
+	}
+
 	key := rl.GetCharPressed()
 	if key > 0 {
-		app.ConnectionInput += string(rune(key))
+		*input += string(rune(key))
+	}
+
+	if rl.IsKeyPressed(rl.KeyBackspace) && len(*input) > 0 {
+		*input = (*input)[:len(*input)-1]
 	}
 
-	if rl.IsKeyPressed(rl.KeyBackspace) && len(app.ConnectionInput) > 0 {
-		app.ConnectionInput = app.ConnectionInput[:len(app.ConnectionInput)-1]
+	if rl.IsKeyPressed(rl.KeyTab) {
+		app.ConnectionField = (app.ConnectionField + 1) % 3
 	}
 
 	if rl.IsKeyPressed(rl.KeyEnter) {
-		connectToServer(app, manager, eventHandler)
+		// Ctrl+Enter registers a new account instead of logging in
+		connectToServer(app, manager, eventHandler, rl.IsKeyDown(rl.KeyLeftControl))
 	}
 
 	if rl.IsKeyPressed(rl.KeyEscape) {
 		app.ShowConnectionDialog = false
 		app.ConnectionInput = ""
+		app.UsernameInput = ""
+		app.PasswordInput = ""
 	}
 }
 
@@ -129,15 +150,25 @@ func handleChannelDialog(app *types.AppState, manager *client.Manager, eventHand
 	}
 }
 
-func connectToServer(app *types.AppState, manager *client.Manager, eventHandler *client.EventHandler) {
+func connectToServer(app *types.AppState, manager *client.Manager, eventHandler *client.EventHandler, register bool) {
 	address := strings.TrimSpace(app.ConnectionInput)
 	if address == "" {
 		return
 	}
 
+	// Without a username we connect anonymously (only allowed outside production)
+	var creds *client.Credentials
+	if username := strings.TrimSpace(app.UsernameInput); username != "" {
+		creds = &client.Credentials{
+			Username: username,
+			Password: app.PasswordInput,
+			Register: register,
+		}
+	}
+
 	serverID := fmt.Sprintf("server-%d", len(app.Servers)+1)
 
-	err := manager.Connect(serverID, address)
+	err := manager.Connect(serverID, address, creds)
 	if err != nil {
 		log.Printf("Failed to connect to server: %v", err)
 		return
@@ -162,6 +193,8 @@ func connectToServer(app *types.AppState, manager *client.Manager, eventHandler
 
 	app.ShowConnectionDialog = false
 	app.ConnectionInput = ""
+	app.UsernameInput = ""
+	app.PasswordInput = ""
 }
 
 func createChannel(app *types.AppState, manager *client.Manager, eventHandler *client.EventHandler) {
@@ -199,6 +232,11 @@ func loadChannels(app *types.AppState, manager *client.Manager, serverID string)
 		return
 	}
 
+	// Threads are shown under the message they were started from
+	channels = slices.DeleteFunc(channels, func(channel *pb.Channel) bool {
+		return channel.Type == pb.ChannelType_CHANNEL_TYPE_THREAD
+	})
+
 	for _, server := range app.Servers {
 		if server.ID == serverID {
 			server.Channels = channels
@@ -225,7 +263,16 @@ func loadMessages(app *types.AppState, manager *client.Manager) {
 		return
 	}
 
+	threads, err := manager.ListActiveThreads(ctx, app.CurrentChannel.ChannelId)
+	if err != nil {
+		log.Printf("Failed to load threads: %v", err)
+	}
+
 	app.Messages = messages
+	app.Threads = make(map[string]*pb.Channel, len(threads))
+	for _, thread := range threads {
+		app.Threads[thread.Thread.GetMessageId()] = thread
+	}
 }
 
 func handleSidebarClick(app *types.AppState, mousePos rl.Vector2, manager *client.Manager, eventHandler *client.EventHandler) {
@@ -295,16 +342,36 @@ func sendMessage(app *types.AppState, manager *client.Manager) {
 	app.MessageInput = ""
 }
 
-func handleEvents(app *types.AppState, eventHandler *client.EventHandler) {
+func handleEvents(app *types.AppState, manager *client.Manager, eventHandler *client.EventHandler) {
 	select {
 	case message := <-eventHandler.MessageChan:
 		if app.CurrentChannel != nil && message.ChannelId == app.CurrentChannel.ChannelId {
 			app.Messages = append(app.Messages, message)
 		}
+		countThreadMessage(app, message)
+	case thread := <-eventHandler.ThreadChan:
+		if app.CurrentChannel != nil && thread.ParentId == app.CurrentChannel.ChannelId {
+			app.Threads[thread.Thread.MessageId] = thread
+		}
+	case reaction := <-eventHandler.ReactionChan:
+		if app.CurrentChannel != nil && reaction.ChannelId == app.CurrentChannel.ChannelId {
+			applyReaction(app, manager, reaction)
+		}
 	case channel := <-eventHandler.ChannelChan:
 		for _, server := range app.Servers {
 			if server.ID == channel.ServerId {
-				server.Channels = append(server.Channels, channel)
+				// channel.updated carries a channel we already know about
+				replaced := false
+				for i, existing := range server.Channels {
+					if existing.ChannelId == channel.ChannelId {
+						server.Channels[i] = channel
+						replaced = true
+						break
+					}
+				}
+				if !replaced {
+					server.Channels = append(server.Channels, channel)
+				}
 				break
 			}
 		}
@@ -312,6 +379,58 @@ func handleEvents(app *types.AppState, eventHandler *client.EventHandler) {
 	}
 }
 
+// countThreadMessage counts a message sent to one of the shown threads.
+func countThreadMessage(app *types.AppState, message *pb.Message) {
+	for _, thread := range app.Threads {
+		if thread.ChannelId == message.ChannelId {
+			thread.Thread.MessageCount++
+			thread.Thread.Archived = false
+			return
+		}
+	}
+}
+
+// applyReaction updates the shown reactions of a message to those after
+// the event.
+func applyReaction(app *types.AppState, manager *client.Manager, event *client.ReactionEvent) {
+	var message *pb.Message
+	for _, existing := range app.Messages {
+		if existing.MessageId == event.MessageId {
+			message = existing
+			break
+		}
+	}
+	if message == nil {
+		return
+	}
+
+	var reaction *pb.Reaction
+	for i, existing := range message.Reactions {
+		if existing.Emoji == event.Emoji {
+			if event.Count == 0 {
+				message.Reactions = append(message.Reactions[:i], message.Reactions[i+1:]...)
+				return
+			}
+			reaction = existing
+			break
+		}
+	}
+	if reaction == nil {
+		if event.Count == 0 {
+			return
+		}
+		reaction = &pb.Reaction{Emoji: event.Emoji}
+		message.Reactions = append(message.Reactions, reaction)
+	}
+
+	reaction.Count = event.Count
+	if app.CurrentServer != nil {
+		if user, ok := manager.CurrentUser(app.CurrentServer.ID); ok && user.UserId == event.UserId {
+			reaction.Me = event.Added
+		}
+	}
+}
+
 func drawUI(app *types.AppState) {
 	drawHeader(app)
 	drawSidebar(app)
@@ -413,12 +532,54 @@ func drawMessageArea(app *types.AppState) {
 		rl.DrawText(author+": "+message.Content, x+10, y, 16, rl.Color{220, 221, 222, 255})
 		y += 25
 
+		if len(message.Reactions) > 0 {
+			drawReactions(message.Reactions, x+10, y)
+			y += 24
+		}
+
+		if thread, ok := app.Threads[message.MessageId]; ok {
+			drawThread(thread, x+30, y)
+			y += 22
+		} else if message.ThreadId != "" {
+			rl.DrawText("Thread (archived)", x+30, y, 14, rl.Color{114, 118, 125, 255})
+			y += 22
+		}
+
 		if y > WINDOW_HEIGHT-120 {
 			break
 		}
 	}
 }
 
+func drawReactions(reactions []*pb.Reaction, x, y int32) {
+	for _, reaction := range reactions {
+		label := fmt.Sprintf("%s %d", reaction.Emoji, reaction.Count)
+		width := rl.MeasureText(label, 14) + 12
+
+		// Reactions of the current user are highlighted
+		background := rl.Color{47, 49, 54, 255}
+		if reaction.Me {
+			background = rl.Color{88, 101, 242, 255}
+		}
+		rl.DrawRectangle(x, y, width, 20, background)
+		rl.DrawText(label, x+6, y+3, 14, rl.Color{220, 221, 222, 255})
+		x += width + 6
+	}
+}
+
+func drawThread(thread *pb.Channel, x, y int32) {
+	label := fmt.Sprintf("Thread: %s - %d messages", thread.Name, thread.Thread.MessageCount)
+	color := rl.Color{0, 168, 252, 255}
+	switch {
+	case thread.Thread.Locked:
+		label += " (locked)"
+	case thread.Thread.Archived:
+		label += " (archived)"
+		color = rl.Color{114, 118, 125, 255}
+	}
+	rl.DrawText(label, x, y, 14, color)
+}
+
 func drawMessageInput(app *types.AppState) {
 	x := int32(SIDEBAR_WIDTH + CHANNEL_WIDTH)
 	y := int32(WINDOW_HEIGHT - 60)
@@ -443,7 +604,7 @@ func drawMessageInput(app *types.AppState) {
 
 func drawConnectionDialog(app *types.AppState) {
 	dialogWidth := int32(400)
-	dialogHeight := int32(200)
+	dialogHeight := int32(320)
 	dialogX := (WINDOW_WIDTH - dialogWidth) / 2
 	dialogY := (WINDOW_HEIGHT - dialogHeight) / 2
 
@@ -452,13 +613,33 @@ func drawConnectionDialog(app *types.AppState) {
 	rl.DrawRectangleLines(dialogX, dialogY, dialogWidth, dialogHeight, rl.Color{114, 118, 125, 255})
 
 	rl.DrawText("Add Server", dialogX+20, dialogY+20, 20, rl.Color{220, 221, 222, 255})
-	rl.DrawText("Server Address:", dialogX+20, dialogY+60, 16, rl.Color{180, 184, 191, 255})
 
-	inputY := dialogY + 90
-	rl.DrawRectangle(dialogX+20, inputY, dialogWidth-40, 30, rl.Color{32, 34, 37, 255})
-	rl.DrawText(app.ConnectionInput, dialogX+25, inputY+7, 16, rl.Color{220, 221, 222, 255})
+	fields := []struct {
+		label string
+		value string
+		field types.ConnectionField
+	}{
+		{"Server Address:", app.ConnectionInput, types.ConnectionFieldAddress},
+		{"Username (optional):", app.UsernameInput, types.ConnectionFieldUsername},
+		{"Password:", strings.Repeat("*", len(app.PasswordInput)), types.ConnectionFieldPassword},
+	}
+
+	y := dialogY + 55
+	for _, f := range fields {
+		rl.DrawText(f.label, dialogX+20, y, 16, rl.Color{180, 184, 191, 255})
+
+		inputY := y + 22
+		rl.DrawRectangle(dialogX+20, inputY, dialogWidth-40, 30, rl.Color{32, 34, 37, 255})
+		if f.field == app.ConnectionField {
+			rl.DrawRectangleLines(dialogX+20, inputY, dialogWidth-40, 30, rl.Color{88, 101, 242, 255})
+		}
+		rl.DrawText(f.value, dialogX+25, inputY+7, 16, rl.Color{220, 221, 222, 255})
+
+		y += 70
+	}
 
-	rl.DrawText("Press Enter to connect, Esc to cancel", dialogX+20, dialogY+140, 14, rl.Color{114, 118, 125, 255})
+	rl.DrawText("Tab to switch fields, Enter to log in", dialogX+20, dialogY+270, 14, rl.Color{114, 118, 125, 255})
+	rl.DrawText("Ctrl+Enter to register, Esc to cancel", dialogX+20, dialogY+290, 14, rl.Color{114, 118, 125, 255})
 }
 
 func drawChannelDialog(app *types.AppState) {
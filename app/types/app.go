@@ -13,14 +13,28 @@ type Server struct {
 	Channels  []*pb.Channel
 }
 
+// ConnectionField identifies the focused input of the connection dialog.
+type ConnectionField int
+
+const (
+	ConnectionFieldAddress ConnectionField = iota
+	ConnectionFieldUsername
+	ConnectionFieldPassword
+)
+
 type AppState struct {
 	Servers        []*Server
 	CurrentServer  *Server
 	CurrentChannel *pb.Channel
 	Messages       []*pb.Message
+	// Threads of the current channel by the message they were started from
+	Threads map[string]*pb.Channel
 
 	ShowConnectionDialog bool
 	ConnectionInput      string
+	UsernameInput        string
+	PasswordInput        string
+	ConnectionField      ConnectionField
 
 	ShowChannelDialog bool
 	ChannelNameInput  string
@@ -44,6 +58,7 @@ type AppState struct {
 func NewAppState() *AppState {
 	return &AppState{
 		Servers: make([]*Server, 0),
+		Threads: make(map[string]*pb.Channel),
 		UI: struct {
 			SidebarWidth  float32
 			ChannelWidth  float32
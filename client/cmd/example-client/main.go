@@ -67,7 +67,15 @@ func main() {
 			log.Printf("Stream ended: %v", err)
 			break
 		}
-		log.Printf("Received event: %+v", event)
+		log.Printf("Received event: %s %s (sequence %d)", event.EventType, event.Scope, event.Sequence)
+		if event.Payload != nil {
+			payload, err := event.Payload.UnmarshalNew()
+			if err != nil {
+				log.Printf("Failed to decode payload: %v", err)
+			} else {
+				log.Printf("Payload: %+v", payload)
+			}
+		}
 
 		// Exit after receiving one event for demo purposes
 		break
@@ -78,7 +86,7 @@ func main() {
 	publishResp, err := eventClient.Publish(ctx, &pb.PublishRequest{
 		Event: &pb.Event{
 			EventId:   "client-event-1",
-			EventType: "user.action",
+			EventType: "example.action",
 			Scope:     "server:123",
 			ActorId:   "user:456",
 			Sequence:  1,
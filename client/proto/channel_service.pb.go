@@ -350,12 +350,17 @@ func (x *ListChannelsResponse) GetNextPageToken() string {
 	return ""
 }
 
+// Updates the fields of a channel named in update_mask: "name", "parent_id",
+// "metadata", which replaces all metadata, or "metadata.<key>", which sets
+// one key or removes it if metadata doesn't hold it. Without a mask, every
+// field that is set is updated.
 type UpdateChannelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	UpdateMask    []string               `protobuf:"bytes,4,rep,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"` // Fields to update
+	ParentId      string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`       // Empty moves the channel to the top level
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -418,6 +423,13 @@ func (x *UpdateChannelRequest) GetUpdateMask() []string {
 	return nil
 }
 
+func (x *UpdateChannelRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
 type UpdateChannelResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Channel       *Channel               `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
@@ -550,6 +562,498 @@ func (x *DeleteChannelResponse) GetSuccess() bool {
 	return false
 }
 
+// Starts a thread from a message; the caller becomes its owner and first
+// member.
+type StartThreadRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	MessageId           string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                             // Defaults to the start of the message
+	AutoArchiveDuration int32                  `protobuf:"varint,3,opt,name=auto_archive_duration,json=autoArchiveDuration,proto3" json:"auto_archive_duration,omitempty"` // Minutes, default 1440
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *StartThreadRequest) Reset() {
+	*x = StartThreadRequest{}
+	mi := &file_channel_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartThreadRequest) ProtoMessage() {}
+
+func (x *StartThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartThreadRequest.ProtoReflect.Descriptor instead.
+func (*StartThreadRequest) Descriptor() ([]byte, []int) {
+	return file_channel_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StartThreadRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *StartThreadRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StartThreadRequest) GetAutoArchiveDuration() int32 {
+	if x != nil {
+		return x.AutoArchiveDuration
+	}
+	return 0
+}
+
+type StartThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Thread        *Channel               `protobuf:"bytes,1,opt,name=thread,proto3" json:"thread,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartThreadResponse) Reset() {
+	*x = StartThreadResponse{}
+	mi := &file_channel_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartThreadResponse) ProtoMessage() {}
+
+func (x *StartThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartThreadResponse.ProtoReflect.Descriptor instead.
+func (*StartThreadResponse) Descriptor() ([]byte, []int) {
+	return file_channel_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StartThreadResponse) GetThread() *Channel {
+	if x != nil {
+		return x.Thread
+	}
+	return nil
+}
+
+type UpdateThreadRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId           string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Archived            bool                   `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"`
+	Locked              bool                   `protobuf:"varint,3,opt,name=locked,proto3" json:"locked,omitempty"`
+	AutoArchiveDuration int32                  `protobuf:"varint,4,opt,name=auto_archive_duration,json=autoArchiveDuration,proto3" json:"auto_archive_duration,omitempty"`
+	UpdateMask          []string               `protobuf:"bytes,5,rep,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"` // archived, locked and auto_archive_duration
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UpdateThreadRequest) Reset() {
+	*x = UpdateThreadRequest{}
+	mi := &file_channel_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateThreadRequest) ProtoMessage() {}
+
+func (x *UpdateThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateThreadRequest.ProtoReflect.Descriptor instead.
+func (*UpdateThreadRequest) Descriptor() ([]byte, []int) {
+	return file_channel_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateThreadRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *UpdateThreadRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *UpdateThreadRequest) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *UpdateThreadRequest) GetAutoArchiveDuration() int32 {
+	if x != nil {
+		return x.AutoArchiveDuration
+	}
+	return 0
+}
+
+func (x *UpdateThreadRequest) GetUpdateMask() []string {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Thread        *Channel               `protobuf:"bytes,1,opt,name=thread,proto3" json:"thread,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateThreadResponse) Reset() {
+	*x = UpdateThreadResponse{}
+	mi := &file_channel_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateThreadResponse) ProtoMessage() {}
+
+func (x *UpdateThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateThreadResponse.ProtoReflect.Descriptor instead.
+func (*UpdateThreadResponse) Descriptor() ([]byte, []int) {
+	return file_channel_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateThreadResponse) GetThread() *Channel {
+	if x != nil {
+		return x.Thread
+	}
+	return nil
+}
+
+type JoinThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinThreadRequest) Reset() {
+	*x = JoinThreadRequest{}
+	mi := &file_channel_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinThreadRequest) ProtoMessage() {}
+
+func (x *JoinThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinThreadRequest.ProtoReflect.Descriptor instead.
+func (*JoinThreadRequest) Descriptor() ([]byte, []int) {
+	return file_channel_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *JoinThreadRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+type JoinThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinThreadResponse) Reset() {
+	*x = JoinThreadResponse{}
+	mi := &file_channel_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinThreadResponse) ProtoMessage() {}
+
+func (x *JoinThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinThreadResponse.ProtoReflect.Descriptor instead.
+func (*JoinThreadResponse) Descriptor() ([]byte, []int) {
+	return file_channel_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *JoinThreadResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type LeaveThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveThreadRequest) Reset() {
+	*x = LeaveThreadRequest{}
+	mi := &file_channel_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveThreadRequest) ProtoMessage() {}
+
+func (x *LeaveThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveThreadRequest.ProtoReflect.Descriptor instead.
+func (*LeaveThreadRequest) Descriptor() ([]byte, []int) {
+	return file_channel_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LeaveThreadRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+type LeaveThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveThreadResponse) Reset() {
+	*x = LeaveThreadResponse{}
+	mi := &file_channel_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveThreadResponse) ProtoMessage() {}
+
+func (x *LeaveThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveThreadResponse.ProtoReflect.Descriptor instead.
+func (*LeaveThreadResponse) Descriptor() ([]byte, []int) {
+	return file_channel_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LeaveThreadResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Lists the threads of a channel that are not archived, most recently
+// active first.
+type ListActiveThreadsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveThreadsRequest) Reset() {
+	*x = ListActiveThreadsRequest{}
+	mi := &file_channel_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveThreadsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveThreadsRequest) ProtoMessage() {}
+
+func (x *ListActiveThreadsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveThreadsRequest.ProtoReflect.Descriptor instead.
+func (*ListActiveThreadsRequest) Descriptor() ([]byte, []int) {
+	return file_channel_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListActiveThreadsRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+type ListActiveThreadsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Threads       []*Channel             `protobuf:"bytes,1,rep,name=threads,proto3" json:"threads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveThreadsResponse) Reset() {
+	*x = ListActiveThreadsResponse{}
+	mi := &file_channel_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveThreadsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveThreadsResponse) ProtoMessage() {}
+
+func (x *ListActiveThreadsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_channel_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveThreadsResponse.ProtoReflect.Descriptor instead.
+func (*ListActiveThreadsResponse) Descriptor() ([]byte, []int) {
+	return file_channel_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListActiveThreadsResponse) GetThreads() []*Channel {
+	if x != nil {
+		return x.Threads
+	}
+	return nil
+}
+
 var File_channel_service_proto protoreflect.FileDescriptor
 
 const file_channel_service_proto_rawDesc = "" +
@@ -579,14 +1083,15 @@ const file_channel_service_proto_rawDesc = "" +
 	"page_token\x18\x04 \x01(\tR\tpageToken\"i\n" +
 	"\x14ListChannelsResponse\x12)\n" +
 	"\bchannels\x18\x01 \x03(\v2\r.fuwa.ChannelR\bchannels\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xed\x01\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x8a\x02\n" +
 	"\x14UpdateChannelRequest\x12\x1d\n" +
 	"\n" +
 	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12D\n" +
 	"\bmetadata\x18\x03 \x03(\v2(.fuwa.UpdateChannelRequest.MetadataEntryR\bmetadata\x12\x1f\n" +
 	"\vupdate_mask\x18\x04 \x03(\tR\n" +
-	"updateMask\x1a;\n" +
+	"updateMask\x12\x1b\n" +
+	"\tparent_id\x18\x05 \x01(\tR\bparentId\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
@@ -596,14 +1101,52 @@ const file_channel_service_proto_rawDesc = "" +
 	"\n" +
 	"channel_id\x18\x01 \x01(\tR\tchannelId\"1\n" +
 	"\x15DeleteChannelResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xf6\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"{\n" +
+	"\x12StartThreadRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x122\n" +
+	"\x15auto_archive_duration\x18\x03 \x01(\x05R\x13autoArchiveDuration\"<\n" +
+	"\x13StartThreadResponse\x12%\n" +
+	"\x06thread\x18\x01 \x01(\v2\r.fuwa.ChannelR\x06thread\"\xbd\x01\n" +
+	"\x13UpdateThreadRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x1a\n" +
+	"\barchived\x18\x02 \x01(\bR\barchived\x12\x16\n" +
+	"\x06locked\x18\x03 \x01(\bR\x06locked\x122\n" +
+	"\x15auto_archive_duration\x18\x04 \x01(\x05R\x13autoArchiveDuration\x12\x1f\n" +
+	"\vupdate_mask\x18\x05 \x03(\tR\n" +
+	"updateMask\"=\n" +
+	"\x14UpdateThreadResponse\x12%\n" +
+	"\x06thread\x18\x01 \x01(\v2\r.fuwa.ChannelR\x06thread\"2\n" +
+	"\x11JoinThreadRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\".\n" +
+	"\x12JoinThreadResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"3\n" +
+	"\x12LeaveThreadRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\"/\n" +
+	"\x13LeaveThreadResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"9\n" +
+	"\x18ListActiveThreadsRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\"D\n" +
+	"\x19ListActiveThreadsResponse\x12'\n" +
+	"\athreads\x18\x01 \x03(\v2\r.fuwa.ChannelR\athreads2\xdc\x05\n" +
 	"\x0eChannelService\x12H\n" +
 	"\rCreateChannel\x12\x1a.fuwa.CreateChannelRequest\x1a\x1b.fuwa.CreateChannelResponse\x12?\n" +
 	"\n" +
 	"GetChannel\x12\x17.fuwa.GetChannelRequest\x1a\x18.fuwa.GetChannelResponse\x12E\n" +
 	"\fListChannels\x12\x19.fuwa.ListChannelsRequest\x1a\x1a.fuwa.ListChannelsResponse\x12H\n" +
 	"\rUpdateChannel\x12\x1a.fuwa.UpdateChannelRequest\x1a\x1b.fuwa.UpdateChannelResponse\x12H\n" +
-	"\rDeleteChannel\x12\x1a.fuwa.DeleteChannelRequest\x1a\x1b.fuwa.DeleteChannelResponseB\"Z github.com/waifu-devs/fuwa/protob\x06proto3"
+	"\rDeleteChannel\x12\x1a.fuwa.DeleteChannelRequest\x1a\x1b.fuwa.DeleteChannelResponse\x12B\n" +
+	"\vStartThread\x12\x18.fuwa.StartThreadRequest\x1a\x19.fuwa.StartThreadResponse\x12E\n" +
+	"\fUpdateThread\x12\x19.fuwa.UpdateThreadRequest\x1a\x1a.fuwa.UpdateThreadResponse\x12?\n" +
+	"\n" +
+	"JoinThread\x12\x17.fuwa.JoinThreadRequest\x1a\x18.fuwa.JoinThreadResponse\x12B\n" +
+	"\vLeaveThread\x12\x18.fuwa.LeaveThreadRequest\x1a\x19.fuwa.LeaveThreadResponse\x12T\n" +
+	"\x11ListActiveThreads\x12\x1e.fuwa.ListActiveThreadsRequest\x1a\x1f.fuwa.ListActiveThreadsResponseB\"Z github.com/waifu-devs/fuwa/protob\x06proto3"
 
 var (
 	file_channel_service_proto_rawDescOnce sync.Once
@@ -617,46 +1160,69 @@ func file_channel_service_proto_rawDescGZIP() []byte {
 	return file_channel_service_proto_rawDescData
 }
 
-var file_channel_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_channel_service_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_channel_service_proto_goTypes = []any{
-	(*CreateChannelRequest)(nil),  // 0: fuwa.CreateChannelRequest
-	(*CreateChannelResponse)(nil), // 1: fuwa.CreateChannelResponse
-	(*GetChannelRequest)(nil),     // 2: fuwa.GetChannelRequest
-	(*GetChannelResponse)(nil),    // 3: fuwa.GetChannelResponse
-	(*ListChannelsRequest)(nil),   // 4: fuwa.ListChannelsRequest
-	(*ListChannelsResponse)(nil),  // 5: fuwa.ListChannelsResponse
-	(*UpdateChannelRequest)(nil),  // 6: fuwa.UpdateChannelRequest
-	(*UpdateChannelResponse)(nil), // 7: fuwa.UpdateChannelResponse
-	(*DeleteChannelRequest)(nil),  // 8: fuwa.DeleteChannelRequest
-	(*DeleteChannelResponse)(nil), // 9: fuwa.DeleteChannelResponse
-	nil,                           // 10: fuwa.CreateChannelRequest.MetadataEntry
-	nil,                           // 11: fuwa.UpdateChannelRequest.MetadataEntry
-	(ChannelType)(0),              // 12: fuwa.ChannelType
-	(*Channel)(nil),               // 13: fuwa.Channel
+	(*CreateChannelRequest)(nil),      // 0: fuwa.CreateChannelRequest
+	(*CreateChannelResponse)(nil),     // 1: fuwa.CreateChannelResponse
+	(*GetChannelRequest)(nil),         // 2: fuwa.GetChannelRequest
+	(*GetChannelResponse)(nil),        // 3: fuwa.GetChannelResponse
+	(*ListChannelsRequest)(nil),       // 4: fuwa.ListChannelsRequest
+	(*ListChannelsResponse)(nil),      // 5: fuwa.ListChannelsResponse
+	(*UpdateChannelRequest)(nil),      // 6: fuwa.UpdateChannelRequest
+	(*UpdateChannelResponse)(nil),     // 7: fuwa.UpdateChannelResponse
+	(*DeleteChannelRequest)(nil),      // 8: fuwa.DeleteChannelRequest
+	(*DeleteChannelResponse)(nil),     // 9: fuwa.DeleteChannelResponse
+	(*StartThreadRequest)(nil),        // 10: fuwa.StartThreadRequest
+	(*StartThreadResponse)(nil),       // 11: fuwa.StartThreadResponse
+	(*UpdateThreadRequest)(nil),       // 12: fuwa.UpdateThreadRequest
+	(*UpdateThreadResponse)(nil),      // 13: fuwa.UpdateThreadResponse
+	(*JoinThreadRequest)(nil),         // 14: fuwa.JoinThreadRequest
+	(*JoinThreadResponse)(nil),        // 15: fuwa.JoinThreadResponse
+	(*LeaveThreadRequest)(nil),        // 16: fuwa.LeaveThreadRequest
+	(*LeaveThreadResponse)(nil),       // 17: fuwa.LeaveThreadResponse
+	(*ListActiveThreadsRequest)(nil),  // 18: fuwa.ListActiveThreadsRequest
+	(*ListActiveThreadsResponse)(nil), // 19: fuwa.ListActiveThreadsResponse
+	nil,                               // 20: fuwa.CreateChannelRequest.MetadataEntry
+	nil,                               // 21: fuwa.UpdateChannelRequest.MetadataEntry
+	(ChannelType)(0),                  // 22: fuwa.ChannelType
+	(*Channel)(nil),                   // 23: fuwa.Channel
 }
 var file_channel_service_proto_depIdxs = []int32{
-	12, // 0: fuwa.CreateChannelRequest.type:type_name -> fuwa.ChannelType
-	10, // 1: fuwa.CreateChannelRequest.metadata:type_name -> fuwa.CreateChannelRequest.MetadataEntry
-	13, // 2: fuwa.CreateChannelResponse.channel:type_name -> fuwa.Channel
-	13, // 3: fuwa.GetChannelResponse.channel:type_name -> fuwa.Channel
-	13, // 4: fuwa.ListChannelsResponse.channels:type_name -> fuwa.Channel
-	11, // 5: fuwa.UpdateChannelRequest.metadata:type_name -> fuwa.UpdateChannelRequest.MetadataEntry
-	13, // 6: fuwa.UpdateChannelResponse.channel:type_name -> fuwa.Channel
-	0,  // 7: fuwa.ChannelService.CreateChannel:input_type -> fuwa.CreateChannelRequest
-	2,  // 8: fuwa.ChannelService.GetChannel:input_type -> fuwa.GetChannelRequest
-	4,  // 9: fuwa.ChannelService.ListChannels:input_type -> fuwa.ListChannelsRequest
-	6,  // 10: fuwa.ChannelService.UpdateChannel:input_type -> fuwa.UpdateChannelRequest
-	8,  // 11: fuwa.ChannelService.DeleteChannel:input_type -> fuwa.DeleteChannelRequest
-	1,  // 12: fuwa.ChannelService.CreateChannel:output_type -> fuwa.CreateChannelResponse
-	3,  // 13: fuwa.ChannelService.GetChannel:output_type -> fuwa.GetChannelResponse
-	5,  // 14: fuwa.ChannelService.ListChannels:output_type -> fuwa.ListChannelsResponse
-	7,  // 15: fuwa.ChannelService.UpdateChannel:output_type -> fuwa.UpdateChannelResponse
-	9,  // 16: fuwa.ChannelService.DeleteChannel:output_type -> fuwa.DeleteChannelResponse
-	12, // [12:17] is the sub-list for method output_type
-	7,  // [7:12] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	22, // 0: fuwa.CreateChannelRequest.type:type_name -> fuwa.ChannelType
+	20, // 1: fuwa.CreateChannelRequest.metadata:type_name -> fuwa.CreateChannelRequest.MetadataEntry
+	23, // 2: fuwa.CreateChannelResponse.channel:type_name -> fuwa.Channel
+	23, // 3: fuwa.GetChannelResponse.channel:type_name -> fuwa.Channel
+	23, // 4: fuwa.ListChannelsResponse.channels:type_name -> fuwa.Channel
+	21, // 5: fuwa.UpdateChannelRequest.metadata:type_name -> fuwa.UpdateChannelRequest.MetadataEntry
+	23, // 6: fuwa.UpdateChannelResponse.channel:type_name -> fuwa.Channel
+	23, // 7: fuwa.StartThreadResponse.thread:type_name -> fuwa.Channel
+	23, // 8: fuwa.UpdateThreadResponse.thread:type_name -> fuwa.Channel
+	23, // 9: fuwa.ListActiveThreadsResponse.threads:type_name -> fuwa.Channel
+	0,  // 10: fuwa.ChannelService.CreateChannel:input_type -> fuwa.CreateChannelRequest
+	2,  // 11: fuwa.ChannelService.GetChannel:input_type -> fuwa.GetChannelRequest
+	4,  // 12: fuwa.ChannelService.ListChannels:input_type -> fuwa.ListChannelsRequest
+	6,  // 13: fuwa.ChannelService.UpdateChannel:input_type -> fuwa.UpdateChannelRequest
+	8,  // 14: fuwa.ChannelService.DeleteChannel:input_type -> fuwa.DeleteChannelRequest
+	10, // 15: fuwa.ChannelService.StartThread:input_type -> fuwa.StartThreadRequest
+	12, // 16: fuwa.ChannelService.UpdateThread:input_type -> fuwa.UpdateThreadRequest
+	14, // 17: fuwa.ChannelService.JoinThread:input_type -> fuwa.JoinThreadRequest
+	16, // 18: fuwa.ChannelService.LeaveThread:input_type -> fuwa.LeaveThreadRequest
+	18, // 19: fuwa.ChannelService.ListActiveThreads:input_type -> fuwa.ListActiveThreadsRequest
+	1,  // 20: fuwa.ChannelService.CreateChannel:output_type -> fuwa.CreateChannelResponse
+	3,  // 21: fuwa.ChannelService.GetChannel:output_type -> fuwa.GetChannelResponse
+	5,  // 22: fuwa.ChannelService.ListChannels:output_type -> fuwa.ListChannelsResponse
+	7,  // 23: fuwa.ChannelService.UpdateChannel:output_type -> fuwa.UpdateChannelResponse
+	9,  // 24: fuwa.ChannelService.DeleteChannel:output_type -> fuwa.DeleteChannelResponse
+	11, // 25: fuwa.ChannelService.StartThread:output_type -> fuwa.StartThreadResponse
+	13, // 26: fuwa.ChannelService.UpdateThread:output_type -> fuwa.UpdateThreadResponse
+	15, // 27: fuwa.ChannelService.JoinThread:output_type -> fuwa.JoinThreadResponse
+	17, // 28: fuwa.ChannelService.LeaveThread:output_type -> fuwa.LeaveThreadResponse
+	19, // 29: fuwa.ChannelService.ListActiveThreads:output_type -> fuwa.ListActiveThreadsResponse
+	20, // [20:30] is the sub-list for method output_type
+	10, // [10:20] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_channel_service_proto_init() }
@@ -671,7 +1237,7 @@ func file_channel_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_channel_service_proto_rawDesc), len(file_channel_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,11 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ChannelService_CreateChannel_FullMethodName = "/fuwa.ChannelService/CreateChannel"
-	ChannelService_GetChannel_FullMethodName    = "/fuwa.ChannelService/GetChannel"
-	ChannelService_ListChannels_FullMethodName  = "/fuwa.ChannelService/ListChannels"
-	ChannelService_UpdateChannel_FullMethodName = "/fuwa.ChannelService/UpdateChannel"
-	ChannelService_DeleteChannel_FullMethodName = "/fuwa.ChannelService/DeleteChannel"
+	ChannelService_CreateChannel_FullMethodName     = "/fuwa.ChannelService/CreateChannel"
+	ChannelService_GetChannel_FullMethodName        = "/fuwa.ChannelService/GetChannel"
+	ChannelService_ListChannels_FullMethodName      = "/fuwa.ChannelService/ListChannels"
+	ChannelService_UpdateChannel_FullMethodName     = "/fuwa.ChannelService/UpdateChannel"
+	ChannelService_DeleteChannel_FullMethodName     = "/fuwa.ChannelService/DeleteChannel"
+	ChannelService_StartThread_FullMethodName       = "/fuwa.ChannelService/StartThread"
+	ChannelService_UpdateThread_FullMethodName      = "/fuwa.ChannelService/UpdateThread"
+	ChannelService_JoinThread_FullMethodName        = "/fuwa.ChannelService/JoinThread"
+	ChannelService_LeaveThread_FullMethodName       = "/fuwa.ChannelService/LeaveThread"
+	ChannelService_ListActiveThreads_FullMethodName = "/fuwa.ChannelService/ListActiveThreads"
 )
 
 // ChannelServiceClient is the client API for ChannelService service.
@@ -37,6 +42,11 @@ type ChannelServiceClient interface {
 	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
 	UpdateChannel(ctx context.Context, in *UpdateChannelRequest, opts ...grpc.CallOption) (*UpdateChannelResponse, error)
 	DeleteChannel(ctx context.Context, in *DeleteChannelRequest, opts ...grpc.CallOption) (*DeleteChannelResponse, error)
+	StartThread(ctx context.Context, in *StartThreadRequest, opts ...grpc.CallOption) (*StartThreadResponse, error)
+	UpdateThread(ctx context.Context, in *UpdateThreadRequest, opts ...grpc.CallOption) (*UpdateThreadResponse, error)
+	JoinThread(ctx context.Context, in *JoinThreadRequest, opts ...grpc.CallOption) (*JoinThreadResponse, error)
+	LeaveThread(ctx context.Context, in *LeaveThreadRequest, opts ...grpc.CallOption) (*LeaveThreadResponse, error)
+	ListActiveThreads(ctx context.Context, in *ListActiveThreadsRequest, opts ...grpc.CallOption) (*ListActiveThreadsResponse, error)
 }
 
 type channelServiceClient struct {
@@ -97,6 +107,56 @@ func (c *channelServiceClient) DeleteChannel(ctx context.Context, in *DeleteChan
 	return out, nil
 }
 
+func (c *channelServiceClient) StartThread(ctx context.Context, in *StartThreadRequest, opts ...grpc.CallOption) (*StartThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartThreadResponse)
+	err := c.cc.Invoke(ctx, ChannelService_StartThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelServiceClient) UpdateThread(ctx context.Context, in *UpdateThreadRequest, opts ...grpc.CallOption) (*UpdateThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateThreadResponse)
+	err := c.cc.Invoke(ctx, ChannelService_UpdateThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelServiceClient) JoinThread(ctx context.Context, in *JoinThreadRequest, opts ...grpc.CallOption) (*JoinThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinThreadResponse)
+	err := c.cc.Invoke(ctx, ChannelService_JoinThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelServiceClient) LeaveThread(ctx context.Context, in *LeaveThreadRequest, opts ...grpc.CallOption) (*LeaveThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveThreadResponse)
+	err := c.cc.Invoke(ctx, ChannelService_LeaveThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelServiceClient) ListActiveThreads(ctx context.Context, in *ListActiveThreadsRequest, opts ...grpc.CallOption) (*ListActiveThreadsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActiveThreadsResponse)
+	err := c.cc.Invoke(ctx, ChannelService_ListActiveThreads_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChannelServiceServer is the server API for ChannelService service.
 // All implementations must embed UnimplementedChannelServiceServer
 // for forward compatibility.
@@ -108,6 +168,11 @@ type ChannelServiceServer interface {
 	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
 	UpdateChannel(context.Context, *UpdateChannelRequest) (*UpdateChannelResponse, error)
 	DeleteChannel(context.Context, *DeleteChannelRequest) (*DeleteChannelResponse, error)
+	StartThread(context.Context, *StartThreadRequest) (*StartThreadResponse, error)
+	UpdateThread(context.Context, *UpdateThreadRequest) (*UpdateThreadResponse, error)
+	JoinThread(context.Context, *JoinThreadRequest) (*JoinThreadResponse, error)
+	LeaveThread(context.Context, *LeaveThreadRequest) (*LeaveThreadResponse, error)
+	ListActiveThreads(context.Context, *ListActiveThreadsRequest) (*ListActiveThreadsResponse, error)
 	mustEmbedUnimplementedChannelServiceServer()
 }
 
@@ -133,6 +198,21 @@ func (UnimplementedChannelServiceServer) UpdateChannel(context.Context, *UpdateC
 func (UnimplementedChannelServiceServer) DeleteChannel(context.Context, *DeleteChannelRequest) (*DeleteChannelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteChannel not implemented")
 }
+func (UnimplementedChannelServiceServer) StartThread(context.Context, *StartThreadRequest) (*StartThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartThread not implemented")
+}
+func (UnimplementedChannelServiceServer) UpdateThread(context.Context, *UpdateThreadRequest) (*UpdateThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateThread not implemented")
+}
+func (UnimplementedChannelServiceServer) JoinThread(context.Context, *JoinThreadRequest) (*JoinThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinThread not implemented")
+}
+func (UnimplementedChannelServiceServer) LeaveThread(context.Context, *LeaveThreadRequest) (*LeaveThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveThread not implemented")
+}
+func (UnimplementedChannelServiceServer) ListActiveThreads(context.Context, *ListActiveThreadsRequest) (*ListActiveThreadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActiveThreads not implemented")
+}
 func (UnimplementedChannelServiceServer) mustEmbedUnimplementedChannelServiceServer() {}
 func (UnimplementedChannelServiceServer) testEmbeddedByValue()                        {}
 
@@ -244,6 +324,96 @@ func _ChannelService_DeleteChannel_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChannelService_StartThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).StartThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_StartThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).StartThread(ctx, req.(*StartThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelService_UpdateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).UpdateThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_UpdateThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).UpdateThread(ctx, req.(*UpdateThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelService_JoinThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).JoinThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_JoinThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).JoinThread(ctx, req.(*JoinThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelService_LeaveThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).LeaveThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_LeaveThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).LeaveThread(ctx, req.(*LeaveThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelService_ListActiveThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActiveThreadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).ListActiveThreads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_ListActiveThreads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).ListActiveThreads(ctx, req.(*ListActiveThreadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChannelService_ServiceDesc is the grpc.ServiceDesc for ChannelService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -271,6 +441,26 @@ var ChannelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteChannel",
 			Handler:    _ChannelService_DeleteChannel_Handler,
 		},
+		{
+			MethodName: "StartThread",
+			Handler:    _ChannelService_StartThread_Handler,
+		},
+		{
+			MethodName: "UpdateThread",
+			Handler:    _ChannelService_UpdateThread_Handler,
+		},
+		{
+			MethodName: "JoinThread",
+			Handler:    _ChannelService_JoinThread_Handler,
+		},
+		{
+			MethodName: "LeaveThread",
+			Handler:    _ChannelService_LeaveThread_Handler,
+		},
+		{
+			MethodName: "ListActiveThreads",
+			Handler:    _ChannelService_ListActiveThreads_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "channel_service.proto",
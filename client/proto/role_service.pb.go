@@ -0,0 +1,1188 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v6.31.1
+// source: role_service.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Role service request/response types
+type CreateRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Permissions   uint64                 `protobuf:"varint,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	Position      int32                  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoleRequest) Reset() {
+	*x = CreateRoleRequest{}
+	mi := &file_role_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoleRequest) ProtoMessage() {}
+
+func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoleRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoleRequest) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateRoleRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *CreateRoleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateRoleRequest) GetPermissions() uint64 {
+	if x != nil {
+		return x.Permissions
+	}
+	return 0
+}
+
+func (x *CreateRoleRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type CreateRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          *Role                  `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoleResponse) Reset() {
+	*x = CreateRoleResponse{}
+	mi := &file_role_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoleResponse) ProtoMessage() {}
+
+func (x *CreateRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoleResponse.ProtoReflect.Descriptor instead.
+func (*CreateRoleResponse) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRoleResponse) GetRole() *Role {
+	if x != nil {
+		return x.Role
+	}
+	return nil
+}
+
+type ListRolesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRolesRequest) Reset() {
+	*x = ListRolesRequest{}
+	mi := &file_role_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRolesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRolesRequest) ProtoMessage() {}
+
+func (x *ListRolesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRolesRequest.ProtoReflect.Descriptor instead.
+func (*ListRolesRequest) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListRolesRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+type ListRolesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []*Role                `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRolesResponse) Reset() {
+	*x = ListRolesResponse{}
+	mi := &file_role_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRolesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRolesResponse) ProtoMessage() {}
+
+func (x *ListRolesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRolesResponse.ProtoReflect.Descriptor instead.
+func (*ListRolesResponse) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListRolesResponse) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type UpdateRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Permissions   uint64                 `protobuf:"varint,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	Position      int32                  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	UpdateMask    []string               `protobuf:"bytes,5,rep,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"` // Fields to update
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRoleRequest) Reset() {
+	*x = UpdateRoleRequest{}
+	mi := &file_role_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoleRequest) ProtoMessage() {}
+
+func (x *UpdateRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRoleRequest) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateRoleRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *UpdateRoleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRoleRequest) GetPermissions() uint64 {
+	if x != nil {
+		return x.Permissions
+	}
+	return 0
+}
+
+func (x *UpdateRoleRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *UpdateRoleRequest) GetUpdateMask() []string {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          *Role                  `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRoleResponse) Reset() {
+	*x = UpdateRoleResponse{}
+	mi := &file_role_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoleResponse) ProtoMessage() {}
+
+func (x *UpdateRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRoleResponse) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateRoleResponse) GetRole() *Role {
+	if x != nil {
+		return x.Role
+	}
+	return nil
+}
+
+type DeleteRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoleRequest) Reset() {
+	*x = DeleteRoleRequest{}
+	mi := &file_role_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoleRequest) ProtoMessage() {}
+
+func (x *DeleteRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoleRequest) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteRoleRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+type DeleteRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoleResponse) Reset() {
+	*x = DeleteRoleResponse{}
+	mi := &file_role_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoleResponse) ProtoMessage() {}
+
+func (x *DeleteRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRoleResponse) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteRoleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type AddRoleMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddRoleMemberRequest) Reset() {
+	*x = AddRoleMemberRequest{}
+	mi := &file_role_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddRoleMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRoleMemberRequest) ProtoMessage() {}
+
+func (x *AddRoleMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRoleMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddRoleMemberRequest) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AddRoleMemberRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *AddRoleMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type AddRoleMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddRoleMemberResponse) Reset() {
+	*x = AddRoleMemberResponse{}
+	mi := &file_role_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddRoleMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRoleMemberResponse) ProtoMessage() {}
+
+func (x *AddRoleMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRoleMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddRoleMemberResponse) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AddRoleMemberResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RemoveRoleMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveRoleMemberRequest) Reset() {
+	*x = RemoveRoleMemberRequest{}
+	mi := &file_role_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveRoleMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRoleMemberRequest) ProtoMessage() {}
+
+func (x *RemoveRoleMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRoleMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveRoleMemberRequest) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoveRoleMemberRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *RemoveRoleMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveRoleMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveRoleMemberResponse) Reset() {
+	*x = RemoveRoleMemberResponse{}
+	mi := &file_role_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveRoleMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRoleMemberResponse) ProtoMessage() {}
+
+func (x *RemoveRoleMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRoleMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveRoleMemberResponse) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RemoveRoleMemberResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type SetPermissionOverwriteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Overwrite     *PermissionOverwrite   `protobuf:"bytes,1,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPermissionOverwriteRequest) Reset() {
+	*x = SetPermissionOverwriteRequest{}
+	mi := &file_role_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPermissionOverwriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPermissionOverwriteRequest) ProtoMessage() {}
+
+func (x *SetPermissionOverwriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPermissionOverwriteRequest.ProtoReflect.Descriptor instead.
+func (*SetPermissionOverwriteRequest) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetPermissionOverwriteRequest) GetOverwrite() *PermissionOverwrite {
+	if x != nil {
+		return x.Overwrite
+	}
+	return nil
+}
+
+type SetPermissionOverwriteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Overwrite     *PermissionOverwrite   `protobuf:"bytes,1,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPermissionOverwriteResponse) Reset() {
+	*x = SetPermissionOverwriteResponse{}
+	mi := &file_role_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPermissionOverwriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPermissionOverwriteResponse) ProtoMessage() {}
+
+func (x *SetPermissionOverwriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPermissionOverwriteResponse.ProtoReflect.Descriptor instead.
+func (*SetPermissionOverwriteResponse) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetPermissionOverwriteResponse) GetOverwrite() *PermissionOverwrite {
+	if x != nil {
+		return x.Overwrite
+	}
+	return nil
+}
+
+type DeletePermissionOverwriteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	TargetType    OverwriteTargetType    `protobuf:"varint,2,opt,name=target_type,json=targetType,proto3,enum=fuwa.OverwriteTargetType" json:"target_type,omitempty"`
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePermissionOverwriteRequest) Reset() {
+	*x = DeletePermissionOverwriteRequest{}
+	mi := &file_role_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePermissionOverwriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePermissionOverwriteRequest) ProtoMessage() {}
+
+func (x *DeletePermissionOverwriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePermissionOverwriteRequest.ProtoReflect.Descriptor instead.
+func (*DeletePermissionOverwriteRequest) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeletePermissionOverwriteRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *DeletePermissionOverwriteRequest) GetTargetType() OverwriteTargetType {
+	if x != nil {
+		return x.TargetType
+	}
+	return OverwriteTargetType_OVERWRITE_TARGET_TYPE_UNSPECIFIED
+}
+
+func (x *DeletePermissionOverwriteRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+type DeletePermissionOverwriteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePermissionOverwriteResponse) Reset() {
+	*x = DeletePermissionOverwriteResponse{}
+	mi := &file_role_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePermissionOverwriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePermissionOverwriteResponse) ProtoMessage() {}
+
+func (x *DeletePermissionOverwriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePermissionOverwriteResponse.ProtoReflect.Descriptor instead.
+func (*DeletePermissionOverwriteResponse) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeletePermissionOverwriteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListPermissionOverwritesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPermissionOverwritesRequest) Reset() {
+	*x = ListPermissionOverwritesRequest{}
+	mi := &file_role_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPermissionOverwritesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPermissionOverwritesRequest) ProtoMessage() {}
+
+func (x *ListPermissionOverwritesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPermissionOverwritesRequest.ProtoReflect.Descriptor instead.
+func (*ListPermissionOverwritesRequest) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListPermissionOverwritesRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+type ListPermissionOverwritesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Overwrites    []*PermissionOverwrite `protobuf:"bytes,1,rep,name=overwrites,proto3" json:"overwrites,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPermissionOverwritesResponse) Reset() {
+	*x = ListPermissionOverwritesResponse{}
+	mi := &file_role_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPermissionOverwritesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPermissionOverwritesResponse) ProtoMessage() {}
+
+func (x *ListPermissionOverwritesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPermissionOverwritesResponse.ProtoReflect.Descriptor instead.
+func (*ListPermissionOverwritesResponse) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListPermissionOverwritesResponse) GetOverwrites() []*PermissionOverwrite {
+	if x != nil {
+		return x.Overwrites
+	}
+	return nil
+}
+
+type GetPermissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	ChannelId     string                 `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"` // Takes precedence over server_id when set
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPermissionsRequest) Reset() {
+	*x = GetPermissionsRequest{}
+	mi := &file_role_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPermissionsRequest) ProtoMessage() {}
+
+func (x *GetPermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPermissionsRequest.ProtoReflect.Descriptor instead.
+func (*GetPermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetPermissionsRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *GetPermissionsRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *GetPermissionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetPermissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Permissions   uint64                 `protobuf:"varint,1,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPermissionsResponse) Reset() {
+	*x = GetPermissionsResponse{}
+	mi := &file_role_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPermissionsResponse) ProtoMessage() {}
+
+func (x *GetPermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_role_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPermissionsResponse.ProtoReflect.Descriptor instead.
+func (*GetPermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_role_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPermissionsResponse) GetPermissions() uint64 {
+	if x != nil {
+		return x.Permissions
+	}
+	return 0
+}
+
+var File_role_service_proto protoreflect.FileDescriptor
+
+const file_role_service_proto_rawDesc = "" +
+	"\n" +
+	"\x12role_service.proto\x12\x04fuwa\x1a\vtypes.proto\"\x82\x01\n" +
+	"\x11CreateRoleRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vpermissions\x18\x03 \x01(\x04R\vpermissions\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\"4\n" +
+	"\x12CreateRoleResponse\x12\x1e\n" +
+	"\x04role\x18\x01 \x01(\v2\n" +
+	".fuwa.RoleR\x04role\"/\n" +
+	"\x10ListRolesRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\"5\n" +
+	"\x11ListRolesResponse\x12 \n" +
+	"\x05roles\x18\x01 \x03(\v2\n" +
+	".fuwa.RoleR\x05roles\"\x9f\x01\n" +
+	"\x11UpdateRoleRequest\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vpermissions\x18\x03 \x01(\x04R\vpermissions\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\x12\x1f\n" +
+	"\vupdate_mask\x18\x05 \x03(\tR\n" +
+	"updateMask\"4\n" +
+	"\x12UpdateRoleResponse\x12\x1e\n" +
+	"\x04role\x18\x01 \x01(\v2\n" +
+	".fuwa.RoleR\x04role\",\n" +
+	"\x11DeleteRoleRequest\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\".\n" +
+	"\x12DeleteRoleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"H\n" +
+	"\x14AddRoleMemberRequest\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"1\n" +
+	"\x15AddRoleMemberResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"K\n" +
+	"\x17RemoveRoleMemberRequest\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"4\n" +
+	"\x18RemoveRoleMemberResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"X\n" +
+	"\x1dSetPermissionOverwriteRequest\x127\n" +
+	"\toverwrite\x18\x01 \x01(\v2\x19.fuwa.PermissionOverwriteR\toverwrite\"Y\n" +
+	"\x1eSetPermissionOverwriteResponse\x127\n" +
+	"\toverwrite\x18\x01 \x01(\v2\x19.fuwa.PermissionOverwriteR\toverwrite\"\x9a\x01\n" +
+	" DeletePermissionOverwriteRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12:\n" +
+	"\vtarget_type\x18\x02 \x01(\x0e2\x19.fuwa.OverwriteTargetTypeR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\"=\n" +
+	"!DeletePermissionOverwriteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"@\n" +
+	"\x1fListPermissionOverwritesRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\"]\n" +
+	" ListPermissionOverwritesResponse\x129\n" +
+	"\n" +
+	"overwrites\x18\x01 \x03(\v2\x19.fuwa.PermissionOverwriteR\n" +
+	"overwrites\"l\n" +
+	"\x15GetPermissionsRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x02 \x01(\tR\tchannelId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\":\n" +
+	"\x16GetPermissionsResponse\x12 \n" +
+	"\vpermissions\x18\x01 \x01(\x04R\vpermissions2\xb6\x06\n" +
+	"\vRoleService\x12?\n" +
+	"\n" +
+	"CreateRole\x12\x17.fuwa.CreateRoleRequest\x1a\x18.fuwa.CreateRoleResponse\x12<\n" +
+	"\tListRoles\x12\x16.fuwa.ListRolesRequest\x1a\x17.fuwa.ListRolesResponse\x12?\n" +
+	"\n" +
+	"UpdateRole\x12\x17.fuwa.UpdateRoleRequest\x1a\x18.fuwa.UpdateRoleResponse\x12?\n" +
+	"\n" +
+	"DeleteRole\x12\x17.fuwa.DeleteRoleRequest\x1a\x18.fuwa.DeleteRoleResponse\x12H\n" +
+	"\rAddRoleMember\x12\x1a.fuwa.AddRoleMemberRequest\x1a\x1b.fuwa.AddRoleMemberResponse\x12Q\n" +
+	"\x10RemoveRoleMember\x12\x1d.fuwa.RemoveRoleMemberRequest\x1a\x1e.fuwa.RemoveRoleMemberResponse\x12c\n" +
+	"\x16SetPermissionOverwrite\x12#.fuwa.SetPermissionOverwriteRequest\x1a$.fuwa.SetPermissionOverwriteResponse\x12l\n" +
+	"\x19DeletePermissionOverwrite\x12&.fuwa.DeletePermissionOverwriteRequest\x1a'.fuwa.DeletePermissionOverwriteResponse\x12i\n" +
+	"\x18ListPermissionOverwrites\x12%.fuwa.ListPermissionOverwritesRequest\x1a&.fuwa.ListPermissionOverwritesResponse\x12K\n" +
+	"\x0eGetPermissions\x12\x1b.fuwa.GetPermissionsRequest\x1a\x1c.fuwa.GetPermissionsResponseB\"Z github.com/waifu-devs/fuwa/protob\x06proto3"
+
+var (
+	file_role_service_proto_rawDescOnce sync.Once
+	file_role_service_proto_rawDescData []byte
+)
+
+func file_role_service_proto_rawDescGZIP() []byte {
+	file_role_service_proto_rawDescOnce.Do(func() {
+		file_role_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_role_service_proto_rawDesc), len(file_role_service_proto_rawDesc)))
+	})
+	return file_role_service_proto_rawDescData
+}
+
+var file_role_service_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_role_service_proto_goTypes = []any{
+	(*CreateRoleRequest)(nil),                 // 0: fuwa.CreateRoleRequest
+	(*CreateRoleResponse)(nil),                // 1: fuwa.CreateRoleResponse
+	(*ListRolesRequest)(nil),                  // 2: fuwa.ListRolesRequest
+	(*ListRolesResponse)(nil),                 // 3: fuwa.ListRolesResponse
+	(*UpdateRoleRequest)(nil),                 // 4: fuwa.UpdateRoleRequest
+	(*UpdateRoleResponse)(nil),                // 5: fuwa.UpdateRoleResponse
+	(*DeleteRoleRequest)(nil),                 // 6: fuwa.DeleteRoleRequest
+	(*DeleteRoleResponse)(nil),                // 7: fuwa.DeleteRoleResponse
+	(*AddRoleMemberRequest)(nil),              // 8: fuwa.AddRoleMemberRequest
+	(*AddRoleMemberResponse)(nil),             // 9: fuwa.AddRoleMemberResponse
+	(*RemoveRoleMemberRequest)(nil),           // 10: fuwa.RemoveRoleMemberRequest
+	(*RemoveRoleMemberResponse)(nil),          // 11: fuwa.RemoveRoleMemberResponse
+	(*SetPermissionOverwriteRequest)(nil),     // 12: fuwa.SetPermissionOverwriteRequest
+	(*SetPermissionOverwriteResponse)(nil),    // 13: fuwa.SetPermissionOverwriteResponse
+	(*DeletePermissionOverwriteRequest)(nil),  // 14: fuwa.DeletePermissionOverwriteRequest
+	(*DeletePermissionOverwriteResponse)(nil), // 15: fuwa.DeletePermissionOverwriteResponse
+	(*ListPermissionOverwritesRequest)(nil),   // 16: fuwa.ListPermissionOverwritesRequest
+	(*ListPermissionOverwritesResponse)(nil),  // 17: fuwa.ListPermissionOverwritesResponse
+	(*GetPermissionsRequest)(nil),             // 18: fuwa.GetPermissionsRequest
+	(*GetPermissionsResponse)(nil),            // 19: fuwa.GetPermissionsResponse
+	(*Role)(nil),                              // 20: fuwa.Role
+	(*PermissionOverwrite)(nil),               // 21: fuwa.PermissionOverwrite
+	(OverwriteTargetType)(0),                  // 22: fuwa.OverwriteTargetType
+}
+var file_role_service_proto_depIdxs = []int32{
+	20, // 0: fuwa.CreateRoleResponse.role:type_name -> fuwa.Role
+	20, // 1: fuwa.ListRolesResponse.roles:type_name -> fuwa.Role
+	20, // 2: fuwa.UpdateRoleResponse.role:type_name -> fuwa.Role
+	21, // 3: fuwa.SetPermissionOverwriteRequest.overwrite:type_name -> fuwa.PermissionOverwrite
+	21, // 4: fuwa.SetPermissionOverwriteResponse.overwrite:type_name -> fuwa.PermissionOverwrite
+	22, // 5: fuwa.DeletePermissionOverwriteRequest.target_type:type_name -> fuwa.OverwriteTargetType
+	21, // 6: fuwa.ListPermissionOverwritesResponse.overwrites:type_name -> fuwa.PermissionOverwrite
+	0,  // 7: fuwa.RoleService.CreateRole:input_type -> fuwa.CreateRoleRequest
+	2,  // 8: fuwa.RoleService.ListRoles:input_type -> fuwa.ListRolesRequest
+	4,  // 9: fuwa.RoleService.UpdateRole:input_type -> fuwa.UpdateRoleRequest
+	6,  // 10: fuwa.RoleService.DeleteRole:input_type -> fuwa.DeleteRoleRequest
+	8,  // 11: fuwa.RoleService.AddRoleMember:input_type -> fuwa.AddRoleMemberRequest
+	10, // 12: fuwa.RoleService.RemoveRoleMember:input_type -> fuwa.RemoveRoleMemberRequest
+	12, // 13: fuwa.RoleService.SetPermissionOverwrite:input_type -> fuwa.SetPermissionOverwriteRequest
+	14, // 14: fuwa.RoleService.DeletePermissionOverwrite:input_type -> fuwa.DeletePermissionOverwriteRequest
+	16, // 15: fuwa.RoleService.ListPermissionOverwrites:input_type -> fuwa.ListPermissionOverwritesRequest
+	18, // 16: fuwa.RoleService.GetPermissions:input_type -> fuwa.GetPermissionsRequest
+	1,  // 17: fuwa.RoleService.CreateRole:output_type -> fuwa.CreateRoleResponse
+	3,  // 18: fuwa.RoleService.ListRoles:output_type -> fuwa.ListRolesResponse
+	5,  // 19: fuwa.RoleService.UpdateRole:output_type -> fuwa.UpdateRoleResponse
+	7,  // 20: fuwa.RoleService.DeleteRole:output_type -> fuwa.DeleteRoleResponse
+	9,  // 21: fuwa.RoleService.AddRoleMember:output_type -> fuwa.AddRoleMemberResponse
+	11, // 22: fuwa.RoleService.RemoveRoleMember:output_type -> fuwa.RemoveRoleMemberResponse
+	13, // 23: fuwa.RoleService.SetPermissionOverwrite:output_type -> fuwa.SetPermissionOverwriteResponse
+	15, // 24: fuwa.RoleService.DeletePermissionOverwrite:output_type -> fuwa.DeletePermissionOverwriteResponse
+	17, // 25: fuwa.RoleService.ListPermissionOverwrites:output_type -> fuwa.ListPermissionOverwritesResponse
+	19, // 26: fuwa.RoleService.GetPermissions:output_type -> fuwa.GetPermissionsResponse
+	17, // [17:27] is the sub-list for method output_type
+	7,  // [7:17] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_role_service_proto_init() }
+func file_role_service_proto_init() {
+	if File_role_service_proto != nil {
+		return
+	}
+	file_types_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_role_service_proto_rawDesc), len(file_role_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_role_service_proto_goTypes,
+		DependencyIndexes: file_role_service_proto_depIdxs,
+		MessageInfos:      file_role_service_proto_msgTypes,
+	}.Build()
+	File_role_service_proto = out.File
+	file_role_service_proto_goTypes = nil
+	file_role_service_proto_depIdxs = nil
+}
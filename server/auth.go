@@ -0,0 +1,289 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/waifu-devs/fuwa/server/database"
+)
+
+var (
+	ErrMissingToken     = errors.New("missing bearer token")
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrSessionRevoked   = errors.New("session has been revoked")
+	ErrAuthDisabled     = errors.New("authentication is not configured (set FUWA_JWT_SECRET)")
+)
+
+// Principal is the authenticated caller attached to a request context.
+type Principal struct {
+	UserID    string
+	Username  string
+	SessionID string
+	ExpiresAt time.Time
+}
+
+// TokenClaims is the JWT payload issued and accepted by the server.
+type TokenClaims struct {
+	Subject   string `json:"sub"`
+	Username  string `json:"name,omitempty"`
+	SessionID string `json:"sid,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf,omitempty"`
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+type principalContextKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the given principal.
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal, if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// Authenticator validates HS256 JWTs signed with Config.JWTSecret and
+// installs the resulting Principal into the request context.
+type Authenticator struct {
+	secret        []byte
+	requireAuth   bool
+	publicMethods map[string]bool
+	sessions      *database.Queries
+}
+
+// NewAuthenticator verifies tokens against the sessions in the primary
+// database, so a token stops working as soon as its session is logged out,
+// revoked or rotated by a refresh. Without a database only the signature and
+// expiry are checked. Streams already open keep running until they end.
+func NewAuthenticator(config *Config, sessions *database.Queries) *Authenticator {
+	return &Authenticator{
+		secret:        []byte(config.JWTSecret),
+		requireAuth:   config.Environment == "production",
+		publicMethods: make(map[string]bool),
+		sessions:      sessions,
+	}
+}
+
+// AllowUnauthenticated marks full gRPC method names (e.g. "/fuwa.AuthService/Login")
+// that may be called without a token even in production.
+func (a *Authenticator) AllowUnauthenticated(methods ...string) {
+	for _, method := range methods {
+		a.publicMethods[method] = true
+	}
+}
+
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		newCtx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(newCtx, req)
+	}
+}
+
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		newCtx, err := a.authenticate(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: newCtx})
+	}
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	token, err := bearerTokenFromContext(ctx)
+	if err != nil {
+		if err == ErrMissingToken && (!a.requireAuth || a.isPublicMethod(fullMethod)) {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	claims, err := a.ParseToken(token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	if err := a.checkSession(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return ContextWithPrincipal(ctx, &Principal{
+		UserID:    claims.Subject,
+		Username:  claims.Username,
+		SessionID: claims.SessionID,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	}), nil
+}
+
+// checkSession rejects tokens whose session is gone or no longer live.
+func (a *Authenticator) checkSession(ctx context.Context, claims *TokenClaims) error {
+	if a.sessions == nil {
+		return nil
+	}
+	if claims.SessionID == "" {
+		return status.Error(codes.Unauthenticated, "invalid token: token has no session")
+	}
+
+	session, err := a.sessions.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return status.Errorf(codes.Unauthenticated, "invalid token: %v", ErrSessionRevoked)
+		}
+		return status.Errorf(codes.Internal, "failed to get session: %v", err)
+	}
+	if session.RevokedAt.Valid || session.UserID != claims.Subject {
+		return status.Errorf(codes.Unauthenticated, "invalid token: %v", ErrSessionRevoked)
+	}
+	return nil
+}
+
+// Enabled reports whether tokens can be issued and verified.
+func (a *Authenticator) Enabled() bool {
+	return len(a.secret) > 0
+}
+
+func (a *Authenticator) isPublicMethod(fullMethod string) bool {
+	if strings.HasPrefix(fullMethod, "/grpc.reflection.") {
+		return true
+	}
+	return a.publicMethods[fullMethod]
+}
+
+// SignToken encodes and signs the claims as an HS256 JWT.
+func (a *Authenticator) SignToken(claims TokenClaims) (string, error) {
+	if len(a.secret) == 0 {
+		return "", ErrAuthDisabled
+	}
+
+	headerBytes, err := json.Marshal(tokenHeader{Algorithm: "HS256", Type: "JWT"})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token header: %w", err)
+	}
+	claimsBytes, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerBytes) + "." + base64.RawURLEncoding.EncodeToString(claimsBytes)
+	signature := a.sign(signingInput)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// ParseToken verifies the signature and time-based claims of an HS256 JWT.
+// Tokens without an expiry are rejected.
+func (a *Authenticator) ParseToken(token string) (*TokenClaims, error) {
+	if len(a.secret) == 0 {
+		return nil, ErrAuthDisabled
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	var header tokenHeader
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, ErrMalformedToken
+	}
+	if header.Algorithm != "HS256" {
+		return nil, fmt.Errorf("unsupported signing algorithm %q", header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	if !hmac.Equal(signature, a.sign(parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidSignature
+	}
+
+	claimsBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	var claims TokenClaims
+	if err := json.Unmarshal(claimsBytes, &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	now := time.Now().Unix()
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+	if claims.ExpiresAt == 0 {
+		return nil, fmt.Errorf("token has no expiry")
+	}
+	if now >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return nil, fmt.Errorf("token not valid yet")
+	}
+
+	return &claims, nil
+}
+
+func (a *Authenticator) sign(signingInput string) []byte {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+func bearerTokenFromContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ErrMissingToken
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", ErrMissingToken
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "bearer") || strings.TrimSpace(token) == "" {
+		return "", ErrMalformedToken
+	}
+
+	return strings.TrimSpace(token), nil
+}
+
+// authenticatedStream overrides the stream context so handlers see the principal.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
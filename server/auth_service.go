@@ -0,0 +1,347 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{3,32}$`)
+
+const minPasswordLength = 8
+
+type authServiceServer struct {
+	pb.UnimplementedAuthServiceServer
+	conn            *sql.DB
+	db              *database.Queries
+	authenticator   *Authenticator
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+// NewAuthServiceServer serves accounts and sessions from the primary database.
+// conn may be nil when running without databases.
+func NewAuthServiceServer(conn *sql.DB, authenticator *Authenticator, config *Config) *authServiceServer {
+	s := &authServiceServer{
+		conn:            conn,
+		authenticator:   authenticator,
+		accessTokenTTL:  config.AccessTokenTTL,
+		refreshTokenTTL: config.RefreshTokenTTL,
+	}
+	if conn != nil {
+		s.db = database.New(conn)
+	}
+	return s
+}
+
+// PublicAuthMethods lists the AuthService RPCs that must be reachable without a token.
+func PublicAuthMethods() []string {
+	return []string{
+		pb.AuthService_Register_FullMethodName,
+		pb.AuthService_Login_FullMethodName,
+		pb.AuthService_RefreshToken_FullMethodName,
+		pb.AuthService_Logout_FullMethodName,
+	}
+}
+
+func (s *authServiceServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	if !usernamePattern.MatchString(req.Username) {
+		return nil, status.Error(codes.InvalidArgument, "username must be 3-32 characters of letters, digits, '_', '.' or '-'")
+	}
+	if len(req.Password) < minPasswordLength {
+		return nil, status.Errorf(codes.InvalidArgument, "password must be at least %d characters", minPasswordLength)
+	}
+	if err := s.requireEnabled(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.db.GetUserByUsername(ctx, req.Username); err == nil {
+		return nil, status.Error(codes.AlreadyExists, "username is already taken")
+	} else if err != sql.ErrNoRows {
+		return nil, status.Errorf(codes.Internal, "failed to check username: %v", err)
+	}
+
+	passwordHash, err := hashPassword(req.Password)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+	}
+
+	now := time.Now().Unix()
+	dbUser, err := s.db.CreateUser(ctx, database.CreateUserParams{
+		UserID:       id.New(id.User),
+		Username:     req.Username,
+		DisplayName:  sql.NullString{String: req.DisplayName, Valid: req.DisplayName != ""},
+		PasswordHash: passwordHash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, status.Error(codes.AlreadyExists, "username is already taken")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
+	}
+
+	tokens, err := s.issueTokens(ctx, &dbUser, "")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Registered user %s (%s)", dbUser.Username, dbUser.UserID)
+
+	return &pb.RegisterResponse{
+		User:   dbUserToProto(&dbUser),
+		Tokens: tokens,
+	}, nil
+}
+
+func (s *authServiceServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	if req.Username == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "username and password are required")
+	}
+	if err := s.requireEnabled(); err != nil {
+		return nil, err
+	}
+
+	dbUser, err := s.db.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	ok, err := verifyPassword(req.Password, dbUser.PasswordHash)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify password: %v", err)
+	}
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+	}
+
+	tokens, err := s.issueTokens(ctx, &dbUser, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LoginResponse{
+		User:   dbUserToProto(&dbUser),
+		Tokens: tokens,
+	}, nil
+}
+
+func (s *authServiceServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+	if err := s.requireEnabled(); err != nil {
+		return nil, err
+	}
+
+	session, err := s.db.GetSessionByRefreshTokenHash(ctx, hashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get session: %v", err)
+	}
+
+	// A rotated token being presented again means it leaked; kill every session
+	if session.RevokedAt.Valid {
+		if _, err := s.db.RevokeUserSessions(ctx, database.RevokeUserSessionsParams{
+			RevokedAt: sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+			UserID:    session.UserID,
+		}); err != nil {
+			log.Printf("Failed to revoke sessions for user %s after refresh token reuse: %v", session.UserID, err)
+		}
+		log.Printf("Refresh token reuse detected for user %s, all sessions revoked", session.UserID)
+		return nil, status.Error(codes.Unauthenticated, "refresh token has been revoked")
+	}
+	if time.Now().Unix() >= session.ExpiresAt {
+		return nil, status.Error(codes.Unauthenticated, "refresh token expired")
+	}
+
+	dbUser, err := s.db.GetUser(ctx, session.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.Unauthenticated, "user no longer exists")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	tokens, err := s.issueTokens(ctx, &dbUser, session.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RefreshTokenResponse{
+		Tokens: tokens,
+	}, nil
+}
+
+func (s *authServiceServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+
+	session, err := s.db.GetSessionByRefreshTokenHash(ctx, hashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get session: %v", err)
+	}
+
+	if principal, ok := PrincipalFromContext(ctx); ok && principal.UserID != session.UserID {
+		return nil, status.Error(codes.PermissionDenied, "refresh token belongs to another user")
+	}
+
+	if _, err := s.db.RevokeSession(ctx, database.RevokeSessionParams{
+		RevokedAt: sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+		SessionID: session.SessionID,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke session: %v", err)
+	}
+
+	return &pb.LogoutResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *authServiceServer) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	revoked, err := s.db.RevokeUserSessions(ctx, database.RevokeUserSessionsParams{
+		RevokedAt: sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+		UserID:    principal.UserID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke sessions: %v", err)
+	}
+
+	return &pb.RevokeAllSessionsResponse{
+		RevokedCount: revoked,
+	}, nil
+}
+
+func (s *authServiceServer) requireEnabled() error {
+	if s.db == nil {
+		return status.Error(codes.Unavailable, "database not available")
+	}
+	if !s.authenticator.Enabled() {
+		return status.Error(codes.FailedPrecondition, ErrAuthDisabled.Error())
+	}
+	return nil
+}
+
+// issueTokens starts a new session for the user and signs an access token for it.
+// When previousSessionID is set, that session is marked as rotated in the same
+// transaction so a refresh token can only ever be exchanged once, and a failure
+// never leaves the caller without a live session.
+func (s *authServiceServer) issueTokens(ctx context.Context, user *database.User, previousSessionID string) (*pb.AuthTokens, error) {
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate refresh token: %v", err)
+	}
+
+	now := time.Now()
+	sessionID := id.New(id.Session)
+	accessExpiresAt := now.Add(s.accessTokenTTL)
+	refreshExpiresAt := now.Add(s.refreshTokenTTL)
+
+	accessToken, err := s.authenticator.SignToken(TokenClaims{
+		Subject:   user.UserID,
+		Username:  user.Username,
+		SessionID: sessionID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: accessExpiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign access token: %v", err)
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+	qtx := s.db.WithTx(tx)
+
+	if previousSessionID != "" {
+		rotated, err := qtx.RotateSession(ctx, database.RotateSessionParams{
+			RevokedAt:  sql.NullInt64{Int64: now.Unix(), Valid: true},
+			ReplacedBy: sql.NullString{String: sessionID, Valid: true},
+			SessionID:  previousSessionID,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to rotate session: %v", err)
+		}
+		if rotated == 0 {
+			// Another request rotated this token first
+			return nil, status.Error(codes.Unauthenticated, "refresh token has been revoked")
+		}
+	}
+
+	if _, err := qtx.CreateSession(ctx, database.CreateSessionParams{
+		SessionID:        sessionID,
+		UserID:           user.UserID,
+		RefreshTokenHash: hashRefreshToken(refreshToken),
+		CreatedAt:        now.Unix(),
+		ExpiresAt:        refreshExpiresAt.Unix(),
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create session: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to commit session: %v", err)
+	}
+
+	return &pb.AuthTokens{
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessExpiresAt),
+		RefreshTokenExpiresAt: timestamppb.New(refreshExpiresAt),
+	}, nil
+}
+
+func generateRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Refresh tokens are stored hashed so a leaked database can't be replayed
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Helper function to convert database user to proto user
+func dbUserToProto(dbUser *database.User) *pb.User {
+	return &pb.User{
+		UserId:      dbUser.UserID,
+		Username:    dbUser.Username,
+		DisplayName: dbUser.DisplayName.String,
+		CreatedAt:   timestamppb.New(time.Unix(dbUser.CreatedAt, 0)),
+	}
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+func newTestAuthService(t *testing.T) (*authServiceServer, *Authenticator) {
+	t.Helper()
+
+	s := newTestServer(t, map[string]string{"FUWA_JWT_SECRET": "test-secret-test-secret-test-secret"})
+	queries, err := s.manager.GetPrimaryQueries()
+	if err != nil {
+		t.Fatalf("GetPrimaryQueries: %v", err)
+	}
+	conn, err := s.manager.GetPrimaryDatabase()
+	if err != nil {
+		t.Fatalf("GetPrimaryDatabase: %v", err)
+	}
+
+	authenticator := NewAuthenticator(s.config, queries)
+	return NewAuthServiceServer(conn, authenticator, s.config), authenticator
+}
+
+func authenticateToken(a *Authenticator, accessToken string) error {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+accessToken))
+	_, err := a.authenticate(ctx, pb.ChannelService_GetChannel_FullMethodName)
+	return err
+}
+
+func requireCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Fatalf("got %v, want %s", err, code)
+	}
+}
+
+func TestRefreshTokenRotates(t *testing.T) {
+	auth, authenticator := newTestAuthService(t)
+	ctx := context.Background()
+
+	registered, err := auth.Register(ctx, &pb.RegisterRequest{Username: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := authenticateToken(authenticator, registered.Tokens.AccessToken); err != nil {
+		t.Fatalf("fresh access token rejected: %v", err)
+	}
+
+	refreshed, err := auth.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: registered.Tokens.RefreshToken})
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+	if refreshed.Tokens.RefreshToken == registered.Tokens.RefreshToken {
+		t.Fatal("refresh token was not rotated")
+	}
+
+	// The rotated session's access token stops working with it
+	requireCode(t, authenticateToken(authenticator, registered.Tokens.AccessToken), codes.Unauthenticated)
+	if err := authenticateToken(authenticator, refreshed.Tokens.AccessToken); err != nil {
+		t.Fatalf("refreshed access token rejected: %v", err)
+	}
+
+	refreshed, err = auth.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: refreshed.Tokens.RefreshToken})
+	if err != nil {
+		t.Fatalf("second RefreshToken: %v", err)
+	}
+	if err := authenticateToken(authenticator, refreshed.Tokens.AccessToken); err != nil {
+		t.Fatalf("access token of second refresh rejected: %v", err)
+	}
+}
+
+func TestRefreshTokenReuseRevokesAllSessions(t *testing.T) {
+	auth, authenticator := newTestAuthService(t)
+	ctx := context.Background()
+
+	registered, err := auth.Register(ctx, &pb.RegisterRequest{Username: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	other, err := auth.Login(ctx, &pb.LoginRequest{Username: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	refreshed, err := auth.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: registered.Tokens.RefreshToken})
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+
+	// Presenting the rotated token again means it leaked
+	_, err = auth.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: registered.Tokens.RefreshToken})
+	requireCode(t, err, codes.Unauthenticated)
+
+	for name, tokens := range map[string]*pb.AuthTokens{"refreshed": refreshed.Tokens, "other": other.Tokens} {
+		if err := authenticateToken(authenticator, tokens.AccessToken); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s access token after reuse: got %v, want Unauthenticated", name, err)
+		}
+		_, err := auth.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: tokens.RefreshToken})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s refresh token after reuse: got %v, want Unauthenticated", name, err)
+		}
+	}
+}
+
+func TestRefreshTokenConcurrentExchange(t *testing.T) {
+	auth, _ := newTestAuthService(t)
+	ctx := context.Background()
+
+	registered, err := auth.Register(ctx, &pb.RegisterRequest{Username: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	const callers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := auth.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: registered.Tokens.RefreshToken})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var succeeded int
+	for err := range errs {
+		switch status.Code(err) {
+		case codes.OK:
+			succeeded++
+		case codes.Unauthenticated:
+		default:
+			t.Errorf("RefreshToken: %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("%d refreshes succeeded, want exactly 1", succeeded)
+	}
+}
+
+func TestLogoutAndRevokeAllSessions(t *testing.T) {
+	auth, authenticator := newTestAuthService(t)
+	ctx := context.Background()
+
+	registered, err := auth.Register(ctx, &pb.RegisterRequest{Username: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if _, err := auth.Logout(ctx, &pb.LogoutRequest{RefreshToken: registered.Tokens.RefreshToken}); err != nil {
+		t.Fatalf("Logout: %v", err)
+	}
+	requireCode(t, authenticateToken(authenticator, registered.Tokens.AccessToken), codes.Unauthenticated)
+	_, err = auth.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: registered.Tokens.RefreshToken})
+	requireCode(t, err, codes.Unauthenticated)
+
+	first, err := auth.Login(ctx, &pb.LoginRequest{Username: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	second, err := auth.Login(ctx, &pb.LoginRequest{Username: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	principal := ContextWithPrincipal(ctx, &Principal{UserID: registered.User.UserId})
+	revoked, err := auth.RevokeAllSessions(principal, &pb.RevokeAllSessionsRequest{})
+	if err != nil {
+		t.Fatalf("RevokeAllSessions: %v", err)
+	}
+	if revoked.RevokedCount != 2 {
+		t.Errorf("revoked %d sessions, want 2", revoked.RevokedCount)
+	}
+	for _, tokens := range []*pb.AuthTokens{first.Tokens, second.Tokens} {
+		requireCode(t, authenticateToken(authenticator, tokens.AccessToken), codes.Unauthenticated)
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseToken(t *testing.T) {
+	authenticator := NewAuthenticator(&Config{JWTSecret: "test-secret-test-secret-test-secret"}, nil)
+	now := time.Now().Unix()
+
+	sign := func(claims TokenClaims) string {
+		t.Helper()
+		token, err := authenticator.SignToken(claims)
+		if err != nil {
+			t.Fatalf("SignToken: %v", err)
+		}
+		return token
+	}
+	// withHeader re-signs a token's claims under another header
+	withHeader := func(token, header string) string {
+		parts := strings.Split(token, ".")
+		signingInput := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + parts[1]
+		return signingInput + "." + base64.RawURLEncoding.EncodeToString(authenticator.sign(signingInput))
+	}
+	valid := sign(TokenClaims{Subject: "alice", SessionID: "sess_1", IssuedAt: now, ExpiresAt: now + 60})
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", token: valid},
+		{name: "missing exp", token: sign(TokenClaims{Subject: "alice", IssuedAt: now}), wantErr: errAny},
+		{name: "expired", token: sign(TokenClaims{Subject: "alice", IssuedAt: now - 120, ExpiresAt: now - 60}), wantErr: ErrTokenExpired},
+		{name: "expires now", token: sign(TokenClaims{Subject: "alice", IssuedAt: now, ExpiresAt: now}), wantErr: ErrTokenExpired},
+		{name: "not valid yet", token: sign(TokenClaims{Subject: "alice", ExpiresAt: now + 120, NotBefore: now + 60}), wantErr: errAny},
+		{name: "no subject", token: sign(TokenClaims{ExpiresAt: now + 60}), wantErr: errAny},
+		{name: "alg none", token: withHeader(valid, `{"alg":"none","typ":"JWT"}`), wantErr: errAny},
+		{name: "alg HS512", token: withHeader(valid, `{"alg":"HS512","typ":"JWT"}`), wantErr: errAny},
+		{name: "tampered claims", token: valid[:strings.LastIndex(valid, ".")] + "x" + valid[strings.LastIndex(valid, "."):], wantErr: ErrInvalidSignature},
+		{name: "wrong secret", token: func() string {
+			other := NewAuthenticator(&Config{JWTSecret: "another-secret-another-secret"}, nil)
+			token, _ := other.SignToken(TokenClaims{Subject: "alice", ExpiresAt: now + 60})
+			return token
+		}(), wantErr: ErrInvalidSignature},
+		{name: "two segments", token: "a.b", wantErr: ErrMalformedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := authenticator.ParseToken(tt.token)
+			switch {
+			case tt.wantErr == nil:
+				if err != nil {
+					t.Fatalf("ParseToken: %v", err)
+				}
+				if claims.Subject != "alice" || claims.SessionID != "sess_1" {
+					t.Fatalf("claims %+v", claims)
+				}
+			case err == nil:
+				t.Fatalf("accepted token, want error")
+			case tt.wantErr != errAny && !errors.Is(err, tt.wantErr):
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// errAny matches any error in table tests that only care that one happened.
+var errAny = errors.New("any error")
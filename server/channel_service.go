@@ -6,6 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -13,19 +17,22 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
 	pb "github.com/waifu-devs/fuwa/server/proto"
 )
 
 type channelServiceServer struct {
 	pb.UnimplementedChannelServiceServer
-	db           *database.Queries
+	router       *DatabaseRouter
 	eventService *eventServiceServer
+	permissions  *PermissionResolver
 }
 
-func NewChannelServiceServer(db *database.Queries, eventService *eventServiceServer) *channelServiceServer {
+func NewChannelServiceServer(router *DatabaseRouter, eventService *eventServiceServer, permissions *PermissionResolver) *channelServiceServer {
 	return &channelServiceServer{
-		db:           db,
+		router:       router,
 		eventService: eventService,
+		permissions:  permissions,
 	}
 }
 
@@ -36,9 +43,44 @@ func (s *channelServiceServer) CreateChannel(ctx context.Context, req *pb.Create
 	if req.ServerId == "" {
 		return nil, status.Error(codes.InvalidArgument, "server_id is required")
 	}
+	if req.Type == pb.ChannelType_CHANNEL_TYPE_THREAD {
+		return nil, status.Error(codes.InvalidArgument, "threads are started from a message with StartThread")
+	}
+
+	db, err := s.router.CreateServer(ctx, req.ServerId)
+	if err != nil {
+		return nil, routeError(err, "server not found")
+	}
+
+	// The first channel in an empty server makes its creator the owner; the
+	// checks below apply either way
+	if err := s.permissions.BootstrapServer(ctx, req.ServerId, s.permissions.callerID(ctx)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to bootstrap server roles: %v", err)
+	}
+
+	if req.ParentId != "" {
+		parent, err := db.GetChannel(ctx, req.ParentId)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, status.Error(codes.NotFound, "parent channel not found")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get parent channel: %v", err)
+		}
+		if parent.ServerID.String != req.ServerId {
+			return nil, status.Error(codes.InvalidArgument, "parent channel belongs to another server")
+		}
+		if pb.ChannelType(parent.Type) == pb.ChannelType_CHANNEL_TYPE_THREAD {
+			return nil, status.Error(codes.InvalidArgument, "channels can't be created in a thread")
+		}
+		if err := s.permissions.RequireChannelPermission(ctx, &parent, PermManageChannels); err != nil {
+			return nil, err
+		}
+	} else if err := s.permissions.RequireServerPermission(ctx, req.ServerId, PermManageChannels); err != nil {
+		return nil, err
+	}
 
 	// Generate channel ID
-	channelID := fmt.Sprintf("channel_%d", time.Now().UnixNano())
+	channelID := id.New(id.Channel)
 	now := time.Now().Unix()
 
 	// Convert metadata to JSON
@@ -51,45 +93,52 @@ func (s *channelServiceServer) CreateChannel(ctx context.Context, req *pb.Create
 		metadataJSON = string(metadataBytes)
 	}
 
-	// Create channel in database
-	dbChannel, err := s.db.CreateChannel(ctx, database.CreateChannelParams{
-		ChannelID: channelID,
-		Name:      req.Name,
-		Type:      int64(req.Type),
-		ServerID:  sql.NullString{String: req.ServerId, Valid: req.ServerId != ""},
-		ParentID:  sql.NullString{String: req.ParentId, Valid: req.ParentId != ""},
-		Metadata:  sql.NullString{String: metadataJSON, Valid: metadataJSON != ""},
-		CreatedAt: now,
-		UpdatedAt: now,
-	})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create channel: %v", err)
+	// The route lives in the primary database, so it is registered first; a
+	// route to a channel that never got created just resolves to "not found"
+	if err := s.router.AddRoute(ctx, channelID, req.ServerId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to register channel route: %v", err)
 	}
 
-	// Convert to proto message
-	protoChannel := dbChannelToProto(&dbChannel)
+	// Create the channel and its channel.created event in one transaction
+	var protoChannel *pb.Channel
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", req.ServerId), func(ctx context.Context, tx *database.Queries) error {
+		dbChannel, err := tx.CreateChannel(ctx, database.CreateChannelParams{
+			ChannelID: channelID,
+			Name:      req.Name,
+			Type:      int64(req.Type),
+			ServerID:  sql.NullString{String: req.ServerId, Valid: req.ServerId != ""},
+			ParentID:  sql.NullString{String: req.ParentId, Valid: req.ParentId != ""},
+			Metadata:  sql.NullString{String: metadataJSON, Valid: metadataJSON != ""},
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to create channel: %v", err)
+		}
+
+		// Convert to proto message
+		protoChannel = dbChannelToProto(&dbChannel)
 
-	// Publish channel.created event
-	if s.eventService != nil {
-		eventID := fmt.Sprintf("channel-created-%d", time.Now().UnixNano())
-		event := &pb.Event{
-			EventId:   eventID,
+		return s.eventService.appendEvent(ctx, tx, &pb.Event{
+			EventId:   id.New(id.Event),
 			EventType: "channel.created",
 			Scope:     fmt.Sprintf("server:%s", req.ServerId),
 			ActorId:   getActorFromContext(ctx),
 			Timestamp: timestamppb.Now(),
+			Payload:   eventPayload(&pb.ChannelCreatedPayload{Channel: protoChannel}),
 			Metadata: map[string]string{
 				"channel_id":   channelID,
 				"channel_name": req.Name,
 			},
-			Sequence: time.Now().Unix(),
-		}
-
-		_, err = s.eventService.Publish(ctx, &pb.PublishRequest{Event: event})
-		if err != nil {
-			log.Printf("Failed to publish channel.created event: %v", err)
+		})
+	})
+	if err != nil {
+		if err := s.router.RemoveRoute(ctx, channelID); err != nil {
+			log.Printf("Failed to remove route for channel %s: %v", channelID, err)
 		}
+		return nil, txError(err, "create channel")
 	}
+	s.eventService.notify()
 
 	return &pb.CreateChannelResponse{
 		Channel: protoChannel,
@@ -101,7 +150,12 @@ func (s *channelServiceServer) GetChannel(ctx context.Context, req *pb.GetChanne
 		return nil, status.Error(codes.InvalidArgument, "channel_id is required")
 	}
 
-	dbChannel, err := s.db.GetChannel(ctx, req.ChannelId)
+	db, _, err := s.router.ForResource(ctx, req.ChannelId)
+	if err != nil {
+		return nil, routeError(err, "channel not found")
+	}
+
+	dbChannel, err := db.GetChannel(ctx, req.ChannelId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, status.Error(codes.NotFound, "channel not found")
@@ -109,12 +163,31 @@ func (s *channelServiceServer) GetChannel(ctx context.Context, req *pb.GetChanne
 		return nil, status.Errorf(codes.Internal, "failed to get channel: %v", err)
 	}
 
+	if err := s.permissions.RequireChannelPermission(ctx, &dbChannel, PermViewChannel); err != nil {
+		return nil, err
+	}
+
+	channel := dbChannelToProto(&dbChannel)
+	if err := loadThreadMetadata(ctx, db, []*pb.Channel{channel}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get thread: %v", err)
+	}
+
 	return &pb.GetChannelResponse{
-		Channel: dbChannelToProto(&dbChannel),
+		Channel: channel,
 	}, nil
 }
 
 func (s *channelServiceServer) ListChannels(ctx context.Context, req *pb.ListChannelsRequest) (*pb.ListChannelsResponse, error) {
+	// Every server has its own database, so listing is always per server
+	if req.ServerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "server_id is required")
+	}
+
+	db, err := s.router.ForServer(ctx, req.ServerId)
+	if err != nil {
+		return nil, routeError(err, "server not found")
+	}
+
 	limit := int64(50) // Default limit
 	if req.Limit > 0 && req.Limit <= 100 {
 		limit = int64(req.Limit)
@@ -127,7 +200,7 @@ func (s *channelServiceServer) ListChannels(ctx context.Context, req *pb.ListCha
 		fmt.Sscanf(req.PageToken, "%d", &offset)
 	}
 
-	dbChannels, err := s.db.ListChannels(ctx, database.ListChannelsParams{
+	dbChannels, err := db.ListChannels(ctx, database.ListChannelsParams{
 		ServerID: sql.NullString{String: req.ServerId, Valid: req.ServerId != ""},
 		Column2:  req.ServerId,
 		ParentID: sql.NullString{String: req.ParentId, Valid: req.ParentId != ""},
@@ -139,14 +212,24 @@ func (s *channelServiceServer) ListChannels(ctx context.Context, req *pb.ListCha
 		return nil, status.Errorf(codes.Internal, "failed to list channels: %v", err)
 	}
 
-	channels := make([]*pb.Channel, len(dbChannels))
-	for i, dbChannel := range dbChannels {
-		channels[i] = dbChannelToProto(&dbChannel)
+	userID := s.permissions.callerID(ctx)
+	channels := make([]*pb.Channel, 0, len(dbChannels))
+	for _, dbChannel := range dbChannels {
+		visible, err := s.permissions.CanViewChannel(ctx, userID, &dbChannel)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to resolve permissions: %v", err)
+		}
+		if visible {
+			channels = append(channels, dbChannelToProto(&dbChannel))
+		}
+	}
+	if err := loadThreadMetadata(ctx, db, channels); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get threads: %v", err)
 	}
 
-	// Calculate next page token
+	// Calculate next page token; hidden channels still count towards the page
 	var nextPageToken string
-	if len(channels) == int(limit) {
+	if len(dbChannels) == int(limit) {
 		nextPageToken = fmt.Sprintf("%d", offset+limit)
 	}
 
@@ -161,8 +244,55 @@ func (s *channelServiceServer) UpdateChannel(ctx context.Context, req *pb.Update
 		return nil, status.Error(codes.InvalidArgument, "channel_id is required")
 	}
 
+	// Without a mask, the fields that are set are updated
+	mask := req.UpdateMask
+	if len(mask) == 0 {
+		if req.Name != "" {
+			mask = append(mask, "name")
+		}
+		if req.ParentId != "" {
+			mask = append(mask, "parent_id")
+		}
+		if len(req.Metadata) > 0 {
+			mask = append(mask, "metadata")
+		}
+		if len(mask) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "update_mask is required")
+		}
+	}
+	var updateName, updateParent, replaceMetadata bool
+	var metadataKeys []string
+	for _, field := range mask {
+		key, isKey := strings.CutPrefix(field, "metadata.")
+		switch {
+		case field == "name":
+			updateName = true
+		case field == "parent_id":
+			updateParent = true
+		case field == "metadata":
+			replaceMetadata = true
+		case isKey && key != "":
+			if !slices.Contains(metadataKeys, key) {
+				metadataKeys = append(metadataKeys, key)
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown update_mask field %q", field)
+		}
+	}
+	if replaceMetadata && len(metadataKeys) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask can't hold both metadata and metadata keys")
+	}
+	if updateName && req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel name is required")
+	}
+
+	db, _, err := s.router.ForResource(ctx, req.ChannelId)
+	if err != nil {
+		return nil, routeError(err, "channel not found")
+	}
+
 	// Get existing channel first
-	existingChannel, err := s.db.GetChannel(ctx, req.ChannelId)
+	existingChannel, err := db.GetChannel(ctx, req.ChannelId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, status.Error(codes.NotFound, "channel not found")
@@ -170,62 +300,155 @@ func (s *channelServiceServer) UpdateChannel(ctx context.Context, req *pb.Update
 		return nil, status.Errorf(codes.Internal, "failed to get channel: %v", err)
 	}
 
-	// Prepare update parameters
-	name := existingChannel.Name
-	metadata := existingChannel.Metadata.String
-
-	// Apply updates based on update_mask
-	for _, field := range req.UpdateMask {
-		switch field {
-		case "name":
-			name = req.Name
-		case "metadata":
-			if req.Metadata != nil {
-				metadataBytes, err := json.Marshal(req.Metadata)
-				if err != nil {
-					return nil, status.Errorf(codes.Internal, "failed to marshal metadata: %v", err)
+	if err := s.permissions.RequireChannelPermission(ctx, &existingChannel, PermManageChannels); err != nil {
+		return nil, err
+	}
+	if updateParent && req.ParentId != existingChannel.ParentID.String {
+		if err := s.checkChannelParent(ctx, db, &existingChannel, req.ParentId); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update the channel and record channel.updated in one transaction.
+	// Fields that end up unchanged are left out of changed_fields, and an
+	// update that changes nothing records nothing
+	var protoChannel *pb.Channel
+	var changed []string
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", existingChannel.ServerID.String), func(ctx context.Context, tx *database.Queries) error {
+		dbChannel, err := tx.GetChannel(ctx, req.ChannelId)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return status.Error(codes.NotFound, "channel not found")
+			}
+			return status.Errorf(codes.Internal, "failed to get channel: %v", err)
+		}
+		protoChannel = dbChannelToProto(&dbChannel)
+
+		if updateName && req.Name != protoChannel.Name {
+			changed = append(changed, "name")
+			protoChannel.Name = req.Name
+		}
+		if updateParent && req.ParentId != protoChannel.ParentId {
+			changed = append(changed, "parent_id")
+			protoChannel.ParentId = req.ParentId
+		}
+		metadata := maps.Clone(protoChannel.Metadata)
+		if replaceMetadata && !maps.Equal(req.Metadata, protoChannel.Metadata) {
+			changed = append(changed, "metadata")
+			metadata = maps.Clone(req.Metadata)
+		}
+		for _, key := range metadataKeys {
+			value, set := req.Metadata[key]
+			current, exists := metadata[key]
+			switch {
+			case set && (!exists || value != current):
+				if metadata == nil {
+					metadata = make(map[string]string)
 				}
-				metadata = string(metadataBytes)
+				metadata[key] = value
+			case !set && exists:
+				delete(metadata, key)
+			default:
+				continue
 			}
+			changed = append(changed, "metadata."+key)
+		}
+		if len(changed) == 0 {
+			return nil
 		}
-	}
 
-	// Update channel
-	dbChannel, err := s.db.UpdateChannel(ctx, database.UpdateChannelParams{
-		Name:      name,
-		Metadata:  sql.NullString{String: metadata, Valid: metadata != ""},
-		UpdatedAt: time.Now().Unix(),
-		ChannelID: req.ChannelId,
-	})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update channel: %v", err)
-	}
+		metadataJSON, err := channelMetadataJSON(metadata)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		dbChannel, err = tx.UpdateChannel(ctx, database.UpdateChannelParams{
+			Name:      protoChannel.Name,
+			ParentID:  sql.NullString{String: protoChannel.ParentId, Valid: protoChannel.ParentId != ""},
+			Metadata:  metadataJSON,
+			UpdatedAt: time.Now().Unix(),
+			ChannelID: req.ChannelId,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to update channel: %v", err)
+		}
+
+		protoChannel = dbChannelToProto(&dbChannel)
 
-	// Publish channel.updated event
-	if s.eventService != nil {
-		eventID := fmt.Sprintf("channel-updated-%d", time.Now().UnixNano())
-		event := &pb.Event{
-			EventId:   eventID,
+		return s.eventService.appendEvent(ctx, tx, &pb.Event{
+			EventId:   id.New(id.Event),
 			EventType: "channel.updated",
 			Scope:     fmt.Sprintf("server:%s", existingChannel.ServerID.String),
 			ActorId:   getActorFromContext(ctx),
 			Timestamp: timestamppb.Now(),
+			Payload: eventPayload(&pb.ChannelUpdatedPayload{
+				Channel:       protoChannel,
+				ChangedFields: changed,
+			}),
 			Metadata: map[string]string{
 				"channel_id":     req.ChannelId,
-				"changed_fields": fmt.Sprintf("%v", req.UpdateMask),
+				"changed_fields": fmt.Sprintf("%v", changed),
 			},
-			Sequence: time.Now().Unix(),
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "update channel")
+	}
+	if len(changed) > 0 {
+		s.eventService.notify()
+	}
+
+	return &pb.UpdateChannelResponse{
+		Channel: protoChannel,
+	}, nil
+}
+
+// checkChannelParent checks that a channel may move under parentID, or to
+// the top level if it is empty. Like creating a channel there, this takes
+// MANAGE_CHANNELS in the new parent or in the server.
+func (s *channelServiceServer) checkChannelParent(ctx context.Context, db *database.Queries, channel *database.Channel, parentID string) error {
+	if pb.ChannelType(channel.Type) == pb.ChannelType_CHANNEL_TYPE_THREAD {
+		return status.Error(codes.InvalidArgument, "threads can't change their parent")
+	}
+	if parentID == "" {
+		return s.permissions.RequireServerPermission(ctx, channel.ServerID.String, PermManageChannels)
+	}
+
+	parent, err := db.GetChannel(ctx, parentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return status.Error(codes.NotFound, "parent channel not found")
 		}
+		return status.Errorf(codes.Internal, "failed to get parent channel: %v", err)
+	}
+	if parent.ServerID.String != channel.ServerID.String {
+		return status.Error(codes.InvalidArgument, "parent channel belongs to another server")
+	}
+	if pb.ChannelType(parent.Type) == pb.ChannelType_CHANNEL_TYPE_THREAD {
+		return status.Error(codes.InvalidArgument, "channels can't be moved into a thread")
+	}
+	if err := s.permissions.RequireChannelPermission(ctx, &parent, PermManageChannels); err != nil {
+		return err
+	}
 
-		_, err = s.eventService.Publish(ctx, &pb.PublishRequest{Event: event})
+	// The channel can't end up among its own ancestors. The walk up from the
+	// new parent is bounded like the permission chain, in case of a cycle
+	ancestor := parent
+	for depth := 0; depth < maxChannelDepth; depth++ {
+		if ancestor.ChannelID == channel.ChannelID {
+			return status.Error(codes.InvalidArgument, "a channel can't be moved under itself or its subchannels")
+		}
+		if !ancestor.ParentID.Valid || ancestor.ParentID.String == "" {
+			return nil
+		}
+		ancestor, err = db.GetChannel(ctx, ancestor.ParentID.String)
 		if err != nil {
-			log.Printf("Failed to publish channel.updated event: %v", err)
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return status.Errorf(codes.Internal, "failed to get parent channel: %v", err)
 		}
 	}
-
-	return &pb.UpdateChannelResponse{
-		Channel: dbChannelToProto(&dbChannel),
-	}, nil
+	return status.Error(codes.InvalidArgument, "channels can't be nested that deep")
 }
 
 func (s *channelServiceServer) DeleteChannel(ctx context.Context, req *pb.DeleteChannelRequest) (*pb.DeleteChannelResponse, error) {
@@ -233,8 +456,13 @@ func (s *channelServiceServer) DeleteChannel(ctx context.Context, req *pb.Delete
 		return nil, status.Error(codes.InvalidArgument, "channel_id is required")
 	}
 
+	db, _, err := s.router.ForResource(ctx, req.ChannelId)
+	if err != nil {
+		return nil, routeError(err, "channel not found")
+	}
+
 	// Get channel info before deletion for event
-	existingChannel, err := s.db.GetChannel(ctx, req.ChannelId)
+	existingChannel, err := db.GetChannel(ctx, req.ChannelId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, status.Error(codes.NotFound, "channel not found")
@@ -242,39 +470,88 @@ func (s *channelServiceServer) DeleteChannel(ctx context.Context, req *pb.Delete
 		return nil, status.Errorf(codes.Internal, "failed to get channel: %v", err)
 	}
 
-	// Delete channel
-	err = s.db.DeleteChannel(ctx, req.ChannelId)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete channel: %v", err)
+	if err := s.permissions.RequireChannelPermission(ctx, &existingChannel, PermManageChannels); err != nil {
+		return nil, err
 	}
 
-	// Publish channel.deleted event
-	if s.eventService != nil {
-		eventID := fmt.Sprintf("channel-deleted-%d", time.Now().UnixNano())
-		event := &pb.Event{
-			EventId:   eventID,
-			EventType: "channel.deleted",
-			Scope:     fmt.Sprintf("server:%s", existingChannel.ServerID.String),
-			ActorId:   getActorFromContext(ctx),
-			Timestamp: timestamppb.Now(),
-			Metadata: map[string]string{
-				"channel_id":   req.ChannelId,
-				"channel_name": existingChannel.Name,
-			},
-			Sequence: time.Now().Unix(),
+	// Delete the channel and record channel.deleted in one transaction.
+	// Subchannels have to be moved or deleted first; threads go along with
+	// the channel, each recorded by its own channel.deleted
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", existingChannel.ServerID.String), func(ctx context.Context, tx *database.Queries) error {
+		children, err := tx.ListChildChannels(ctx, sql.NullString{String: req.ChannelId, Valid: true})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list subchannels: %v", err)
+		}
+		for _, child := range children {
+			if pb.ChannelType(child.Type) != pb.ChannelType_CHANNEL_TYPE_THREAD {
+				return status.Error(codes.FailedPrecondition, "channel has subchannels; move or delete them first")
+			}
 		}
 
-		_, err = s.eventService.Publish(ctx, &pb.PublishRequest{Event: event})
-		if err != nil {
-			log.Printf("Failed to publish channel.deleted event: %v", err)
+		for i := range children {
+			if err := s.deleteChannel(ctx, tx, &children[i], 0); err != nil {
+				return err
+			}
 		}
+		return s.deleteChannel(ctx, tx, &existingChannel, int64(len(children)))
+	})
+	if err != nil {
+		return nil, txError(err, "delete channel")
 	}
+	s.eventService.notify()
 
 	return &pb.DeleteChannelResponse{
 		Success: true,
 	}, nil
 }
 
+// deleteChannel deletes a channel within tx and records channel.deleted. Its
+// route is kept so the channel's event log stays reachable, with the content
+// of its messages redacted like the purge job does. Foreign keys take the
+// attachments, embeds, reactions and revisions of its messages along.
+func (s *channelServiceServer) deleteChannel(ctx context.Context, tx *database.Queries, channel *database.Channel, deletedThreads int64) error {
+	if err := redactScopeMessageEvents(ctx, tx, fmt.Sprintf("channel:%s", channel.ChannelID)); err != nil {
+		return status.Errorf(codes.Internal, "failed to redact message events: %v", err)
+	}
+
+	deletedMessages, err := tx.DeleteMessagesByChannelId(ctx, channel.ChannelID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to delete messages: %v", err)
+	}
+
+	if err := deleteThread(ctx, tx, channel.ChannelID); err != nil {
+		return status.Errorf(codes.Internal, "failed to delete thread: %v", err)
+	}
+
+	if err := tx.DeleteChannel(ctx, channel.ChannelID); err != nil {
+		return status.Errorf(codes.Internal, "failed to delete channel: %v", err)
+	}
+
+	if err := tx.DeletePermissionOverwritesByChannelId(ctx, channel.ChannelID); err != nil {
+		return status.Errorf(codes.Internal, "failed to delete permission overwrites: %v", err)
+	}
+
+	return s.eventService.appendEvent(ctx, tx, &pb.Event{
+		EventId:   id.New(id.Event),
+		EventType: "channel.deleted",
+		Scope:     fmt.Sprintf("server:%s", channel.ServerID.String),
+		ActorId:   getActorFromContext(ctx),
+		Timestamp: timestamppb.Now(),
+		Payload: eventPayload(&pb.ChannelDeletedPayload{
+			ChannelId:       channel.ChannelID,
+			ServerId:        channel.ServerID.String,
+			DeletedMessages: deletedMessages,
+			DeletedThreads:  deletedThreads,
+		}),
+		Metadata: map[string]string{
+			"channel_id":       channel.ChannelID,
+			"channel_name":     channel.Name,
+			"deleted_messages": strconv.FormatInt(deletedMessages, 10),
+			"deleted_threads":  strconv.FormatInt(deletedThreads, 10),
+		},
+	})
+}
+
 // Helper function to convert database channel to proto channel
 func dbChannelToProto(dbChannel *database.Channel) *pb.Channel {
 	var metadata map[string]string
@@ -294,7 +571,11 @@ func dbChannelToProto(dbChannel *database.Channel) *pb.Channel {
 	}
 }
 
+// getActorFromContext returns the authenticated caller's user ID, falling
+// back to "system" for unauthenticated (development) or internal calls.
 func getActorFromContext(ctx context.Context) string {
-	// TODO: Extract user ID from JWT token or similar
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		return principal.UserID
+	}
 	return "system"
 }
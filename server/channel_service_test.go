@@ -0,0 +1,120 @@
+package server
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+func TestThreadsOnlyComeFromStartThread(t *testing.T) {
+	s, channelID := newTestServerWithChannel(t)
+	alice := s.as(t, "alice")
+
+	sent, err := s.messages.SendMessage(alice, &pb.SendMessageRequest{ChannelId: channelID, Content: "hello"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	thread, err := s.channels.StartThread(alice, &pb.StartThreadRequest{MessageId: sent.Message.MessageId})
+	if err != nil {
+		t.Fatalf("StartThread: %v", err)
+	}
+	threadID := thread.Thread.ChannelId
+
+	_, err = s.channels.CreateChannel(alice, &pb.CreateChannelRequest{Name: "sneaky", Type: pb.ChannelType_CHANNEL_TYPE_THREAD, ServerId: "srv1", ParentId: channelID})
+	requireCode(t, err, codes.InvalidArgument)
+
+	_, err = s.channels.CreateChannel(alice, &pb.CreateChannelRequest{Name: "nested", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1", ParentId: threadID})
+	requireCode(t, err, codes.InvalidArgument)
+
+	other, err := s.channels.CreateChannel(alice, &pb.CreateChannelRequest{Name: "other", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1"})
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+	_, err = s.channels.UpdateChannel(alice, &pb.UpdateChannelRequest{ChannelId: other.Channel.ChannelId, ParentId: threadID, UpdateMask: []string{"parent_id"}})
+	requireCode(t, err, codes.InvalidArgument)
+}
+
+func TestDeleteChannelRedactsMessageEvents(t *testing.T) {
+	s, channelID := newTestServerWithChannel(t)
+	alice := s.as(t, "alice")
+
+	sent, err := s.messages.SendMessage(alice, &pb.SendMessageRequest{ChannelId: channelID, Content: "secret"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	_, err = s.messages.UpdateMessage(alice, &pb.UpdateMessageRequest{MessageId: sent.Message.MessageId, Content: "still secret", UpdateMask: []string{"content"}})
+	if err != nil {
+		t.Fatalf("UpdateMessage: %v", err)
+	}
+	if _, err := s.channels.DeleteChannel(alice, &pb.DeleteChannelRequest{ChannelId: channelID}); err != nil {
+		t.Fatalf("DeleteChannel: %v", err)
+	}
+
+	// GetEvents hides deleted channels, so the log is read from the database
+	db, _, err := s.router.ForResource(alice, channelID)
+	if err != nil {
+		t.Fatalf("ForResource: %v", err)
+	}
+	stored, err := db.GetEventsByScope(alice, database.GetEventsByScopeParams{Scope: "channel:" + channelID, Limit: 100})
+	if err != nil {
+		t.Fatalf("GetEventsByScope: %v", err)
+	}
+
+	var redacted int
+	for i := range stored {
+		event := dbEventToProto(&stored[i])
+		var payload interface{ GetMessage() *pb.Message }
+		switch event.EventType {
+		case "message.sent":
+			payload = &pb.MessageSentPayload{}
+		case "message.updated":
+			payload = &pb.MessageUpdatedPayload{}
+		default:
+			continue
+		}
+		if err := event.Payload.UnmarshalTo(payload.(proto.Message)); err != nil {
+			t.Fatalf("unpack %s: %v", event.EventType, err)
+		}
+		if content := payload.GetMessage().GetContent(); content != "" {
+			t.Errorf("%s still holds %q after the channel was deleted", event.EventType, content)
+		}
+		redacted++
+	}
+	if redacted != 2 {
+		t.Errorf("found %d message events, want 2", redacted)
+	}
+}
+
+func TestMoveChannelUnderDescendantIsRejected(t *testing.T) {
+	s, channelID := newTestServerWithChannel(t)
+	alice := s.as(t, "alice")
+
+	parentID := channelID
+	var descendants []string
+	for _, name := range []string{"child", "grandchild"} {
+		resp, err := s.channels.CreateChannel(alice, &pb.CreateChannelRequest{Name: name, Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1", ParentId: parentID})
+		if err != nil {
+			t.Fatalf("CreateChannel: %v", err)
+		}
+		parentID = resp.Channel.ChannelId
+		descendants = append(descendants, parentID)
+	}
+
+	for _, target := range append([]string{channelID}, descendants...) {
+		_, err := s.channels.UpdateChannel(alice, &pb.UpdateChannelRequest{ChannelId: channelID, ParentId: target, UpdateMask: []string{"parent_id"}})
+		requireCode(t, err, codes.InvalidArgument)
+	}
+
+	// Moving the grandchild up under the channel itself is fine
+	moved, err := s.channels.UpdateChannel(alice, &pb.UpdateChannelRequest{ChannelId: descendants[1], ParentId: channelID, UpdateMask: []string{"parent_id"}})
+	if err != nil {
+		t.Fatalf("UpdateChannel: %v", err)
+	}
+	if moved.Channel.ParentId != channelID {
+		t.Errorf("parent is %q, want %q", moved.Channel.ParentId, channelID)
+	}
+}
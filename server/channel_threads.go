@@ -0,0 +1,539 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// threadAutoArchiveDurations are the minutes of inactivity after which a
+// thread may be archived.
+var threadAutoArchiveDurations = []int32{60, 1440, 4320, 10080}
+
+const (
+	defaultThreadAutoArchiveDuration = 1440
+	maxThreadNameLength              = 100
+)
+
+// StartThread creates a thread channel anchored to a message. A message has
+// at most one thread, and threads can't be started inside threads.
+func (s *channelServiceServer) StartThread(ctx context.Context, req *pb.StartThreadRequest) (*pb.StartThreadResponse, error) {
+	if req.MessageId == "" {
+		return nil, status.Error(codes.InvalidArgument, "message_id is required")
+	}
+	duration, err := threadAutoArchiveDuration(req.AutoArchiveDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	db, serverID, err := s.router.ForResource(ctx, req.MessageId)
+	if err != nil {
+		return nil, routeError(err, "message not found")
+	}
+
+	message, err := db.GetMessage(ctx, req.MessageId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get message: %v", err)
+	}
+
+	parent, err := db.GetChannel(ctx, message.ChannelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get channel: %v", err)
+	}
+	if err := s.permissions.RequireChannelPermission(ctx, &parent, PermSendMessages); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		return nil, err
+	}
+	if pb.ChannelType(parent.Type) == pb.ChannelType_CHANNEL_TYPE_THREAD {
+		return nil, status.Error(codes.FailedPrecondition, "threads can't be started in a thread")
+	}
+
+	if _, err := db.GetThreadByMessageId(ctx, req.MessageId); err == nil {
+		return nil, status.Error(codes.AlreadyExists, "message already has a thread")
+	} else if err != sql.ErrNoRows {
+		return nil, status.Errorf(codes.Internal, "failed to get thread: %v", err)
+	}
+
+	name, err := threadName(req.Name, message.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	threadID := id.New(id.Channel)
+	ownerID := getActorFromContext(ctx)
+	now := time.Now().Unix()
+
+	// Threads are channels, so they are routed like them
+	if err := s.router.AddRoute(ctx, threadID, serverID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to register thread route: %v", err)
+	}
+
+	// Create the channel, its thread state and the thread.created event in
+	// one transaction
+	var protoThread *pb.Channel
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", parent.ServerID.String), func(ctx context.Context, tx *database.Queries) error {
+		dbChannel, err := tx.CreateChannel(ctx, database.CreateChannelParams{
+			ChannelID: threadID,
+			Name:      name,
+			Type:      int64(pb.ChannelType_CHANNEL_TYPE_THREAD),
+			ServerID:  parent.ServerID,
+			ParentID:  sql.NullString{String: parent.ChannelID, Valid: true},
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to create thread channel: %v", err)
+		}
+
+		dbThread, err := tx.CreateThread(ctx, database.CreateThreadParams{
+			ChannelID:           threadID,
+			ParentID:            parent.ChannelID,
+			MessageID:           message.MessageID,
+			OwnerID:             ownerID,
+			AutoArchiveDuration: int64(duration),
+			LastActivityAt:      now,
+			CreatedAt:           now,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to create thread: %v", err)
+		}
+
+		if _, err := tx.AddThreadMember(ctx, database.AddThreadMemberParams{
+			ChannelID: threadID,
+			UserID:    ownerID,
+			JoinedAt:  now,
+		}); err != nil {
+			return status.Errorf(codes.Internal, "failed to add thread member: %v", err)
+		}
+
+		protoThread = dbChannelToProto(&dbChannel)
+		protoThread.Thread = threadToProto(&dbThread, []string{ownerID})
+
+		return s.eventService.appendEvent(ctx, tx, &pb.Event{
+			EventId:   id.New(id.Event),
+			EventType: "thread.created",
+			Scope:     fmt.Sprintf("server:%s", parent.ServerID.String),
+			ActorId:   ownerID,
+			Timestamp: timestamppb.Now(),
+			Payload:   eventPayload(&pb.ThreadCreatedPayload{Thread: protoThread}),
+			Metadata: map[string]string{
+				"channel_id": threadID,
+				"parent_id":  parent.ChannelID,
+				"message_id": message.MessageID,
+			},
+		})
+	})
+	if err != nil {
+		if err := s.router.RemoveRoute(ctx, threadID); err != nil {
+			log.Printf("Failed to remove route for thread %s: %v", threadID, err)
+		}
+		return nil, txError(err, "start thread")
+	}
+	s.eventService.notify()
+
+	return &pb.StartThreadResponse{
+		Thread: protoThread,
+	}, nil
+}
+
+// UpdateThread archives, locks or changes the auto-archive duration of a
+// thread. Its owner may archive it and change the duration while it is
+// unlocked; anything else needs MANAGE_CHANNELS.
+func (s *channelServiceServer) UpdateThread(ctx context.Context, req *pb.UpdateThreadRequest) (*pb.UpdateThreadResponse, error) {
+	if len(req.UpdateMask) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask is required")
+	}
+
+	_, channel, thread, err := s.getThread(ctx, req.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := s.permissions.callerID(ctx)
+	perms, err := s.permissions.ChannelPermissions(ctx, userID, channel)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to resolve permissions: %v", err)
+	}
+	if !perms.Has(PermViewChannel) {
+		return nil, status.Error(codes.NotFound, "channel not found")
+	}
+	canManage := perms.Has(PermManageChannels)
+	if !canManage {
+		if thread.Locked != 0 {
+			return nil, status.Error(codes.PermissionDenied, "thread is locked")
+		}
+		if userID != thread.OwnerID {
+			return nil, status.Error(codes.PermissionDenied, "only the thread owner can update the thread")
+		}
+	}
+
+	now := time.Now().Unix()
+	params := database.UpdateThreadParams{
+		Archived:            thread.Archived,
+		Locked:              thread.Locked,
+		AutoArchiveDuration: thread.AutoArchiveDuration,
+		LastActivityAt:      thread.LastActivityAt,
+		ChannelID:           thread.ChannelID,
+	}
+	for _, field := range req.UpdateMask {
+		switch field {
+		case "archived":
+			params.Archived = boolToInt64(req.Archived)
+			// Unarchiving counts as activity, or an inactive thread would
+			// stay archived
+			if !req.Archived {
+				params.LastActivityAt = now
+			}
+		case "locked":
+			if !canManage {
+				return nil, status.Error(codes.PermissionDenied, "locking a thread requires MANAGE_CHANNELS")
+			}
+			params.Locked = boolToInt64(req.Locked)
+		case "auto_archive_duration":
+			duration, err := threadAutoArchiveDuration(req.AutoArchiveDuration)
+			if err != nil {
+				return nil, err
+			}
+			params.AutoArchiveDuration = int64(duration)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown update_mask field %q", field)
+		}
+	}
+
+	// Update the thread and record thread.updated in one transaction
+	var protoThread *pb.Channel
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", channel.ServerID.String), func(ctx context.Context, tx *database.Queries) error {
+		if _, err := tx.UpdateThread(ctx, params); err != nil {
+			return status.Errorf(codes.Internal, "failed to update thread: %v", err)
+		}
+
+		protoThread = dbChannelToProto(channel)
+		if err := loadThreadMetadata(ctx, tx, []*pb.Channel{protoThread}); err != nil {
+			return status.Errorf(codes.Internal, "failed to get thread: %v", err)
+		}
+
+		return s.eventService.appendEvent(ctx, tx, &pb.Event{
+			EventId:   id.New(id.Event),
+			EventType: "thread.updated",
+			Scope:     fmt.Sprintf("server:%s", channel.ServerID.String),
+			ActorId:   getActorFromContext(ctx),
+			Timestamp: timestamppb.Now(),
+			Payload: eventPayload(&pb.ThreadUpdatedPayload{
+				Thread:        protoThread,
+				ChangedFields: req.UpdateMask,
+			}),
+			Metadata: map[string]string{
+				"channel_id":     thread.ChannelID,
+				"changed_fields": fmt.Sprintf("%v", req.UpdateMask),
+			},
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "update thread")
+	}
+	s.eventService.notify()
+
+	return &pb.UpdateThreadResponse{
+		Thread: protoThread,
+	}, nil
+}
+
+// JoinThread makes the caller a member of a thread. Sending a message to a
+// thread joins it as well.
+func (s *channelServiceServer) JoinThread(ctx context.Context, req *pb.JoinThreadRequest) (*pb.JoinThreadResponse, error) {
+	_, channel, thread, err := s.getThread(ctx, req.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.permissions.RequireChannelPermission(ctx, channel, PermViewChannel); err != nil {
+		return nil, err
+	}
+
+	userID := getActorFromContext(ctx)
+	joined := false
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", channel.ServerID.String), func(ctx context.Context, tx *database.Queries) error {
+		added, err := tx.AddThreadMember(ctx, database.AddThreadMemberParams{
+			ChannelID: thread.ChannelID,
+			UserID:    userID,
+			JoinedAt:  time.Now().Unix(),
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to add thread member: %v", err)
+		}
+		if added == 0 {
+			return nil
+		}
+		joined = true
+
+		return s.appendThreadMemberEvent(ctx, tx, "thread.member_added", channel, thread, userID)
+	})
+	if err != nil {
+		return nil, txError(err, "join thread")
+	}
+	if joined {
+		s.eventService.notify()
+	}
+
+	return &pb.JoinThreadResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *channelServiceServer) LeaveThread(ctx context.Context, req *pb.LeaveThreadRequest) (*pb.LeaveThreadResponse, error) {
+	_, channel, thread, err := s.getThread(ctx, req.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.permissions.RequireChannelPermission(ctx, channel, PermViewChannel); err != nil {
+		return nil, err
+	}
+
+	userID := getActorFromContext(ctx)
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", channel.ServerID.String), func(ctx context.Context, tx *database.Queries) error {
+		removed, err := tx.RemoveThreadMember(ctx, database.RemoveThreadMemberParams{
+			ChannelID: thread.ChannelID,
+			UserID:    userID,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to remove thread member: %v", err)
+		}
+		if removed == 0 {
+			return status.Error(codes.NotFound, "not a member of this thread")
+		}
+
+		return s.appendThreadMemberEvent(ctx, tx, "thread.member_removed", channel, thread, userID)
+	})
+	if err != nil {
+		return nil, txError(err, "leave thread")
+	}
+	s.eventService.notify()
+
+	return &pb.LeaveThreadResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *channelServiceServer) ListActiveThreads(ctx context.Context, req *pb.ListActiveThreadsRequest) (*pb.ListActiveThreadsResponse, error) {
+	if req.ChannelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel_id is required")
+	}
+
+	db, _, err := s.router.ForResource(ctx, req.ChannelId)
+	if err != nil {
+		return nil, routeError(err, "channel not found")
+	}
+
+	parent, err := db.GetChannel(ctx, req.ChannelId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "channel not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get channel: %v", err)
+	}
+	if err := s.permissions.RequireChannelPermission(ctx, &parent, PermViewChannel); err != nil {
+		return nil, err
+	}
+
+	dbThreads, err := db.ListActiveThreads(ctx, database.ListActiveThreadsParams{
+		ParentID: req.ChannelId,
+		Now:      time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list threads: %v", err)
+	}
+
+	// Threads inherit the parent's permissions but may have overwrites
+	userID := s.permissions.callerID(ctx)
+	threads := make([]*pb.Channel, 0, len(dbThreads))
+	for i := range dbThreads {
+		visible, err := s.permissions.CanViewChannel(ctx, userID, &dbThreads[i])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to resolve permissions: %v", err)
+		}
+		if visible {
+			threads = append(threads, dbChannelToProto(&dbThreads[i]))
+		}
+	}
+	if err := loadThreadMetadata(ctx, db, threads); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get threads: %v", err)
+	}
+
+	return &pb.ListActiveThreadsResponse{
+		Threads: threads,
+	}, nil
+}
+
+// getThread loads a thread channel and its thread state.
+func (s *channelServiceServer) getThread(ctx context.Context, channelID string) (*database.Queries, *database.Channel, *database.Thread, error) {
+	if channelID == "" {
+		return nil, nil, nil, status.Error(codes.InvalidArgument, "channel_id is required")
+	}
+
+	db, _, err := s.router.ForResource(ctx, channelID)
+	if err != nil {
+		return nil, nil, nil, routeError(err, "channel not found")
+	}
+
+	channel, err := db.GetChannel(ctx, channelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, nil, status.Error(codes.NotFound, "channel not found")
+		}
+		return nil, nil, nil, status.Errorf(codes.Internal, "failed to get channel: %v", err)
+	}
+
+	thread, err := db.GetThread(ctx, channelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, nil, status.Error(codes.FailedPrecondition, "channel is not a thread")
+		}
+		return nil, nil, nil, status.Errorf(codes.Internal, "failed to get thread: %v", err)
+	}
+	return db, &channel, &thread, nil
+}
+
+func (s *channelServiceServer) appendThreadMemberEvent(ctx context.Context, tx *database.Queries, eventType string, channel *database.Channel, thread *database.Thread, userID string) error {
+	return s.eventService.appendEvent(ctx, tx, &pb.Event{
+		EventId:   id.New(id.Event),
+		EventType: eventType,
+		Scope:     fmt.Sprintf("server:%s", channel.ServerID.String),
+		ActorId:   getActorFromContext(ctx),
+		Timestamp: timestamppb.Now(),
+		Payload: eventPayload(&pb.ThreadMemberPayload{
+			ThreadId: thread.ChannelID,
+			ParentId: thread.ParentID,
+			UserId:   userID,
+		}),
+		Metadata: map[string]string{
+			"channel_id": thread.ChannelID,
+			"user_id":    userID,
+		},
+	})
+}
+
+// deleteThread removes the thread state of a channel, if it is a thread.
+func deleteThread(ctx context.Context, tx *database.Queries, channelID string) error {
+	if err := tx.DeleteThreadMembersByChannelId(ctx, channelID); err != nil {
+		return err
+	}
+	return tx.DeleteThread(ctx, channelID)
+}
+
+// loadThreadMetadata sets the thread state of the thread channels among
+// channels.
+func loadThreadMetadata(ctx context.Context, db *database.Queries, channels []*pb.Channel) error {
+	byID := make(map[string]*pb.Channel)
+	var threadIDs []string
+	for _, channel := range channels {
+		if channel.Type == pb.ChannelType_CHANNEL_TYPE_THREAD {
+			byID[channel.ChannelId] = channel
+			threadIDs = append(threadIDs, channel.ChannelId)
+		}
+	}
+	if len(threadIDs) == 0 {
+		return nil
+	}
+
+	threads, err := db.ListThreadsByChannelIds(ctx, threadIDs)
+	if err != nil {
+		return err
+	}
+	members, err := db.ListThreadMembers(ctx, threadIDs)
+	if err != nil {
+		return err
+	}
+	memberIDs := make(map[string][]string)
+	for _, member := range members {
+		memberIDs[member.ChannelID] = append(memberIDs[member.ChannelID], member.UserID)
+	}
+
+	for i := range threads {
+		byID[threads[i].ChannelID].Thread = threadToProto(&threads[i], memberIDs[threads[i].ChannelID])
+	}
+	return nil
+}
+
+// loadMessageThreads sets the thread ID of messages threads were started
+// from.
+func loadMessageThreads(ctx context.Context, db *database.Queries, messages []*pb.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	byID, messageIDs := indexMessages(messages)
+
+	threads, err := db.ListThreadsByMessageIds(ctx, messageIDs)
+	if err != nil {
+		return err
+	}
+	for _, thread := range threads {
+		byID[thread.MessageID].ThreadId = thread.ChannelID
+	}
+	return nil
+}
+
+// threadToProto converts a thread's state. Threads without activity for
+// their auto-archive duration are reported as archived.
+func threadToProto(thread *database.Thread, memberIDs []string) *pb.ThreadMetadata {
+	inactiveSince := time.Unix(thread.LastActivityAt, 0).Add(time.Duration(thread.AutoArchiveDuration) * time.Minute)
+	return &pb.ThreadMetadata{
+		MessageId:           thread.MessageID,
+		OwnerId:             thread.OwnerID,
+		Archived:            thread.Archived != 0 || !time.Now().Before(inactiveSince),
+		Locked:              thread.Locked != 0,
+		AutoArchiveDuration: int32(thread.AutoArchiveDuration),
+		MemberIds:           memberIDs,
+		MessageCount:        int32(thread.MessageCount),
+		LastActivityAt:      timestamppb.New(time.Unix(thread.LastActivityAt, 0)),
+	}
+}
+
+func threadAutoArchiveDuration(minutes int32) (int32, error) {
+	if minutes == 0 {
+		return defaultThreadAutoArchiveDuration, nil
+	}
+	if !slices.Contains(threadAutoArchiveDurations, minutes) {
+		return 0, status.Errorf(codes.InvalidArgument, "auto_archive_duration must be one of %v", threadAutoArchiveDurations)
+	}
+	return minutes, nil
+}
+
+// threadName returns the requested name, or the start of the message's
+// first line when none is given.
+func threadName(name, content string) (string, error) {
+	if name != "" {
+		if utf8.RuneCountInString(name) > maxThreadNameLength {
+			return "", status.Errorf(codes.InvalidArgument, "thread name must be at most %d characters", maxThreadNameLength)
+		}
+		return name, nil
+	}
+
+	name, _, _ = strings.Cut(strings.TrimSpace(content), "\n")
+	if runes := []rune(name); len(runes) > maxThreadNameLength {
+		name = string(runes[:maxThreadNameLength])
+	}
+	if name == "" {
+		name = "thread"
+	}
+	return name, nil
+}
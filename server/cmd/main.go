@@ -1,14 +1,19 @@
 package main
 
 import (
+	"expvar"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/waifu-devs/fuwa/server"
 	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
 	pb "github.com/waifu-devs/fuwa/server/proto"
 )
 
@@ -19,8 +24,20 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(config, os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		os.Exit(runReplay(config, os.Args[2:]))
+	}
+
 	log.Printf("Starting Fuwa server with config: %v", config)
 
+	// Servers sharing databases need distinct node IDs to never create the same IDs
+	if err := id.SetNode(config.NodeID); err != nil {
+		log.Fatalf("Failed to set node ID: %v", err)
+	}
+
 	// Set up multi-database manager
 	dbManager := server.NewMultiDatabaseManager(config)
 	defer dbManager.Close()
@@ -30,22 +47,74 @@ func main() {
 		log.Fatalf("Failed to initialize databases: %v", err)
 	}
 
-	// Get primary database queries instance (will auto-create if none exist)
+	// Close per-server databases nobody has used for a while
+	dbManager.StartIdleEviction(time.Minute)
+
+	// Get primary database queries instance for instance-wide data such as users
 	var queries *database.Queries
 	queries, err = dbManager.GetPrimaryQueries()
 	if err != nil {
 		log.Fatalf("Failed to get database queries: %v", err)
 	}
 
+	primaryDB, err := dbManager.GetPrimaryDatabase()
+	if err != nil {
+		log.Fatalf("Failed to get primary database: %v", err)
+	}
+
 	if queries == nil {
 		log.Printf("Warning: Running without database connections")
 	}
 
+	// Authenticate every RPC against a live session and attach the caller to the request context
+	authenticator := server.NewAuthenticator(config, queries)
+	authenticator.AllowUnauthenticated(server.PublicAuthMethods()...)
+	if config.JWTSecret == "" {
+		log.Printf("Warning: FUWA_JWT_SECRET is not set, all requests will be unauthenticated")
+	}
+
+	// Route each request to the database of the server it targets
+	router := server.NewDatabaseRouter(dbManager)
+
+	// Resolve role and channel overwrite permissions for authenticated callers
+	permissions := server.NewPermissionResolver(router, config)
+
 	// Create services
-	eventService := server.NewEventServiceServer(queries)
-	channelService := server.NewChannelServiceServer(queries, eventService)
-	messageService := server.NewMessageServiceServer(queries, eventService)
-	configService := server.NewConfigServiceServer(config, eventService, nil) // TODO: Implement ConfigStore
+	authService := server.NewAuthServiceServer(primaryDB, authenticator, config)
+	eventService := server.NewEventServiceServer(config, router, permissions)
+	// Fan committed events out to subscribers from the outbox
+	eventService.StartDispatcher(time.Second)
+	defer eventService.StopDispatcher()
+
+	// Apply event retention, compact config events and refresh snapshots
+	maintenance, err := server.NewEventMaintenance(config, router, eventService)
+	if err != nil {
+		log.Fatalf("Failed to set up event maintenance: %v", err)
+	}
+	maintenance.Start(config.EventMaintenanceInterval)
+	defer maintenance.Stop()
+
+	// Erase deleted messages once FUWA_MESSAGE_PURGE_AFTER has passed
+	purger := server.NewMessagePurger(config, router, eventService)
+	purger.Start(config.EventMaintenanceInterval)
+	defer purger.Stop()
+
+	// Expose subscriber queue depths and drops under /debug/vars
+	expvar.Publish("events", expvar.Func(func() any { return eventService.Metrics() }))
+	if config.MetricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", expvar.Handler())
+		go func() {
+			log.Printf("Serving metrics on %s/debug/vars", config.MetricsAddr)
+			if err := http.ListenAndServe(config.MetricsAddr, mux); err != nil {
+				log.Printf("Metrics server stopped: %v", err)
+			}
+		}()
+	}
+	channelService := server.NewChannelServiceServer(router, eventService, permissions)
+	messageService := server.NewMessageServiceServer(router, eventService, permissions)
+	configService := server.NewConfigServiceServer(config, router, eventService, server.NewDatabaseConfigStore(router), permissions)
+	roleService := server.NewRoleServiceServer(router, eventService, permissions)
 
 	// Set up gRPC server
 	lis, err := net.Listen("tcp", ":50051")
@@ -53,19 +122,24 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authenticator.UnaryInterceptor(), dbManager.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(authenticator.StreamInterceptor(), dbManager.StreamInterceptor()),
+	)
 
 	// Register all services
+	pb.RegisterAuthServiceServer(s, authService)
 	pb.RegisterEventServiceServer(s, eventService)
 	pb.RegisterChannelServiceServer(s, channelService)
 	pb.RegisterMessageServiceServer(s, messageService)
 	pb.RegisterConfigServiceServer(s, configService)
+	pb.RegisterRoleServiceServer(s, roleService)
 
 	// Enable reflection for tools like grpcurl
 	reflection.Register(s)
 
 	log.Println("Fuwa gRPC server starting on :50051")
-	log.Println("Services registered: EventService, ChannelService, MessageService, ConfigService")
+	log.Println("Services registered: AuthService, EventService, ChannelService, MessageService, ConfigService, RoleService")
 	if len(dbManager.ListDatabases()) > 0 {
 		log.Printf("Connected databases: %v", dbManager.ListDatabases())
 	} else {
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/waifu-devs/fuwa/server"
+)
+
+const migrateUsage = `Usage: fuwa-server migrate <up|down|status> [--db <name>]
+
+  up      back up and apply pending migrations (all databases unless --db is set)
+  down    back up and roll back the latest migration of the --db database
+  status  report current and latest schema version without changing anything
+
+Stop the server before running up or down.
+`
+
+// runMigrate implements the "migrate" subcommand and returns the exit code.
+func runMigrate(config *server.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	command := args[0]
+	flags := flag.NewFlagSet("migrate "+command, flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, migrateUsage) }
+	dbName := flags.String("db", "", "database name (file name without .db)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	dbManager := server.NewMultiDatabaseManager(config)
+	defer dbManager.Close()
+
+	names := []string{*dbName}
+	if *dbName == "" {
+		if command == "down" {
+			fmt.Fprintln(os.Stderr, "migrate down requires --db")
+			return 2
+		}
+		var err error
+		names, err = dbManager.DatabaseFiles()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(names) == 0 {
+			fmt.Printf("No databases found in %s\n", config.DataPath)
+			return 0
+		}
+	}
+
+	var run func(name string) (server.MigrationStatus, error)
+	switch command {
+	case "up":
+		run = dbManager.MigrateUp
+	case "down":
+		run = dbManager.MigrateDown
+	case "status":
+		run = dbManager.MigrationStatus
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	exitCode := 0
+	for _, name := range names {
+		migrationStatus, err := run(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			exitCode = 1
+			continue
+		}
+		fmt.Println(migrationStatus)
+		if migrationStatus.Backup != "" {
+			fmt.Printf("  backup: %s\n", migrationStatus.Backup)
+		}
+	}
+	return exitCode
+}
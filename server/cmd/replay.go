@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/waifu-devs/fuwa/server"
+)
+
+const replayUsage = `Usage: fuwa-server replay [--db <name>] [--projections <names>] [--verify]
+
+Rebuild the read-model tables of each database (all databases unless --db is
+set) from its event log. A backup is taken first.
+
+  --projections  comma separated projections to run (default all: %s)
+  --verify       replay without saving and report rows that would change
+
+Sensitive config values are redacted in the event log and are kept as stored.
+Stop the server before running a replay.
+`
+
+// runReplay implements the "replay" subcommand and returns the exit code.
+func runReplay(config *server.Config, args []string) int {
+	usage := fmt.Sprintf(replayUsage, strings.Join(server.ProjectionNames(), ","))
+
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	dbName := flags.String("db", "", "database name (file name without .db)")
+	projections := flags.String("projections", "", "comma separated projections to run")
+	verify := flags.Bool("verify", false, "report differences instead of saving")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	dbManager := server.NewMultiDatabaseManager(config)
+	defer dbManager.Close()
+
+	names := []string{*dbName}
+	if *dbName == "" {
+		var err error
+		names, err = dbManager.DatabaseFiles()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(names) == 0 {
+			fmt.Printf("No databases found in %s\n", config.DataPath)
+			return 0
+		}
+	}
+
+	opts := server.ReplayOptions{Verify: *verify}
+	if *projections != "" {
+		opts.Projections = strings.Split(*projections, ",")
+	}
+
+	exitCode := 0
+	for _, name := range names {
+		opts.Progress = func(applied, total int64) {
+			fmt.Printf("\r%s: %d/%d events", name, applied, total)
+		}
+
+		report, err := dbManager.ReplayDatabase(context.Background(), name, opts)
+		fmt.Println()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			exitCode = 1
+			continue
+		}
+
+		fmt.Printf("%s: replayed %d events\n", name, report.Events)
+		if report.Backup != "" {
+			fmt.Printf("  backup: %s\n", report.Backup)
+		}
+		for _, skipped := range report.Skipped {
+			fmt.Printf("  skipped %s\n", skipped)
+		}
+		if *verify {
+			if len(report.Differences) == 0 {
+				fmt.Println("  no differences")
+			}
+			for _, difference := range report.Differences {
+				fmt.Printf("  %s\n", difference)
+			}
+			if len(report.Differences) > 0 {
+				exitCode = 1
+			}
+		}
+	}
+	return exitCode
+}
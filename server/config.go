@@ -7,6 +7,9 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/waifu-devs/fuwa/server/id"
 )
 
 type Config struct {
@@ -21,6 +24,27 @@ type Config struct {
 	TursoURL       string
 	TursoAuthToken string
 	EncryptionKey  string
+	AdminUsers     string
+
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	DBMaxOpen     int
+	DBIdleTimeout time.Duration
+
+	SubscriberQueueSize    int
+	SubscriberOverflow     OverflowPolicy
+	SubscriberBlockTimeout time.Duration
+
+	MetricsAddr string
+
+	EventRetention           string
+	EventMaintenanceInterval time.Duration
+	SnapshotEvery            int
+
+	MessagePurgeAfter time.Duration
+
+	NodeID int
 }
 
 func LoadConfig() (*Config, error) {
@@ -31,6 +55,21 @@ func LoadConfig() (*Config, error) {
 		LogLevel:       "info",
 		Environment:    "development",
 		AllowedOrigins: "*",
+
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: 30 * 24 * time.Hour,
+
+		DBMaxOpen:     64,
+		DBIdleTimeout: 10 * time.Minute,
+
+		SubscriberQueueSize:    256,
+		SubscriberOverflow:     OverflowDropOldest,
+		SubscriberBlockTimeout: 5 * time.Second,
+
+		EventMaintenanceInterval: time.Hour,
+		SnapshotEvery:            1000,
+
+		MessagePurgeAfter: 30 * 24 * time.Hour,
 	}
 
 	envVars, err := loadEnvFile(".env")
@@ -125,6 +164,68 @@ func (c *Config) applyEnvVars(envVars map[string]string) {
 	if env, exists := envVars["FUWA_ENVIRONMENT"]; exists {
 		c.Environment = env
 	}
+	if adminUsers, exists := envVars["FUWA_ADMIN_USERS"]; exists {
+		c.AdminUsers = adminUsers
+	}
+	if ttl, exists := envVars["FUWA_ACCESS_TOKEN_TTL"]; exists {
+		if d, err := time.ParseDuration(ttl); err == nil {
+			c.AccessTokenTTL = d
+		}
+	}
+	if ttl, exists := envVars["FUWA_REFRESH_TOKEN_TTL"]; exists {
+		if d, err := time.ParseDuration(ttl); err == nil {
+			c.RefreshTokenTTL = d
+		}
+	}
+	if maxOpen, exists := envVars["FUWA_DB_MAX_OPEN"]; exists {
+		if n, err := strconv.Atoi(maxOpen); err == nil {
+			c.DBMaxOpen = n
+		}
+	}
+	if timeout, exists := envVars["FUWA_DB_IDLE_TIMEOUT"]; exists {
+		if d, err := time.ParseDuration(timeout); err == nil {
+			c.DBIdleTimeout = d
+		}
+	}
+	if size, exists := envVars["FUWA_SUBSCRIBER_QUEUE_SIZE"]; exists {
+		if n, err := strconv.Atoi(size); err == nil {
+			c.SubscriberQueueSize = n
+		}
+	}
+	if policy, exists := envVars["FUWA_SUBSCRIBER_OVERFLOW"]; exists {
+		c.SubscriberOverflow = OverflowPolicy(policy)
+	}
+	if timeout, exists := envVars["FUWA_SUBSCRIBER_BLOCK_TIMEOUT"]; exists {
+		if d, err := time.ParseDuration(timeout); err == nil {
+			c.SubscriberBlockTimeout = d
+		}
+	}
+	if addr, exists := envVars["FUWA_METRICS_ADDR"]; exists {
+		c.MetricsAddr = addr
+	}
+	if retention, exists := envVars["FUWA_EVENT_RETENTION"]; exists {
+		c.EventRetention = retention
+	}
+	if interval, exists := envVars["FUWA_EVENT_MAINTENANCE_INTERVAL"]; exists {
+		if d, err := time.ParseDuration(interval); err == nil {
+			c.EventMaintenanceInterval = d
+		}
+	}
+	if every, exists := envVars["FUWA_SNAPSHOT_EVERY"]; exists {
+		if n, err := strconv.Atoi(every); err == nil {
+			c.SnapshotEvery = n
+		}
+	}
+	if after, exists := envVars["FUWA_MESSAGE_PURGE_AFTER"]; exists {
+		if d, err := time.ParseDuration(after); err == nil {
+			c.MessagePurgeAfter = d
+		}
+	}
+	if node, exists := envVars["FUWA_NODE_ID"]; exists {
+		if n, err := strconv.Atoi(node); err == nil {
+			c.NodeID = n
+		}
+	}
 }
 
 func (c *Config) applyFuwaEnvVars() {
@@ -142,6 +243,20 @@ func (c *Config) applyFuwaEnvVars() {
 		"FUWA_TURSO_URL",
 		"FUWA_TURSO_AUTH_TOKEN",
 		"FUWA_ENCRYPTION_KEY",
+		"FUWA_ADMIN_USERS",
+		"FUWA_ACCESS_TOKEN_TTL",
+		"FUWA_REFRESH_TOKEN_TTL",
+		"FUWA_DB_MAX_OPEN",
+		"FUWA_DB_IDLE_TIMEOUT",
+		"FUWA_SUBSCRIBER_QUEUE_SIZE",
+		"FUWA_SUBSCRIBER_OVERFLOW",
+		"FUWA_SUBSCRIBER_BLOCK_TIMEOUT",
+		"FUWA_METRICS_ADDR",
+		"FUWA_EVENT_RETENTION",
+		"FUWA_EVENT_MAINTENANCE_INTERVAL",
+		"FUWA_SNAPSHOT_EVERY",
+		"FUWA_MESSAGE_PURGE_AFTER",
+		"FUWA_NODE_ID",
 	}
 
 	for _, key := range envKeys {
@@ -163,9 +278,39 @@ func (c *Config) validate() error {
 	if c.Environment == "production" && c.JWTSecret == "" {
 		return fmt.Errorf("JWT_SECRET is required in production environment")
 	}
+	if c.AccessTokenTTL <= 0 || c.RefreshTokenTTL <= 0 {
+		return fmt.Errorf("token TTLs must be positive, got access=%s refresh=%s", c.AccessTokenTTL, c.RefreshTokenTTL)
+	}
 	if c.EncryptionKey == "" {
 		return fmt.Errorf("encryption key is required (set FUWA_ENCRYPTION_KEY)")
 	}
+	if c.DBMaxOpen < 0 {
+		return fmt.Errorf("database open limit cannot be negative, got %d", c.DBMaxOpen)
+	}
+	if c.SubscriberQueueSize < 1 {
+		return fmt.Errorf("subscriber queue size must be positive, got %d", c.SubscriberQueueSize)
+	}
+	if !c.SubscriberOverflow.valid() {
+		return fmt.Errorf("subscriber overflow policy must be one of %s, %s or %s, got %q", OverflowDropOldest, OverflowDisconnect, OverflowBlock, c.SubscriberOverflow)
+	}
+	if c.SubscriberOverflow == OverflowBlock && c.SubscriberBlockTimeout <= 0 {
+		return fmt.Errorf("subscriber block timeout must be positive, got %s", c.SubscriberBlockTimeout)
+	}
+	if _, err := parseRetentionRules(c.EventRetention); err != nil {
+		return fmt.Errorf("invalid event retention: %w", err)
+	}
+	if c.EventMaintenanceInterval < 0 {
+		return fmt.Errorf("event maintenance interval cannot be negative, got %s", c.EventMaintenanceInterval)
+	}
+	if c.SnapshotEvery < 0 {
+		return fmt.Errorf("snapshot interval cannot be negative, got %d", c.SnapshotEvery)
+	}
+	if c.MessagePurgeAfter < 0 {
+		return fmt.Errorf("message purge period cannot be negative, got %s", c.MessagePurgeAfter)
+	}
+	if c.NodeID < 0 || c.NodeID > id.MaxNode {
+		return fmt.Errorf("node ID must be between 0 and %d, got %d", id.MaxNode, c.NodeID)
+	}
 	return nil
 }
 
@@ -195,7 +340,21 @@ func (c *Config) String() string {
   AllowedOrigins: %s
   TursoURL: %s
   TursoAuthToken: %s
-  EncryptionKey: %s`,
+  EncryptionKey: %s
+  AdminUsers: %s
+  AccessTokenTTL: %s
+  RefreshTokenTTL: %s
+  DBMaxOpen: %d
+  DBIdleTimeout: %s
+  SubscriberQueueSize: %d
+  SubscriberOverflow: %s
+  SubscriberBlockTimeout: %s
+  MetricsAddr: %s
+  EventRetention: %s
+  EventMaintenanceInterval: %s
+  SnapshotEvery: %d
+  MessagePurgeAfter: %s
+  NodeID: %d`,
 		c.Host,
 		c.Port,
 		c.Environment,
@@ -206,5 +365,19 @@ func (c *Config) String() string {
 		c.TursoURL,
 		tursoAuthToken,
 		encryptionKey,
+		c.AdminUsers,
+		c.AccessTokenTTL,
+		c.RefreshTokenTTL,
+		c.DBMaxOpen,
+		c.DBIdleTimeout,
+		c.SubscriberQueueSize,
+		c.SubscriberOverflow,
+		c.SubscriberBlockTimeout,
+		c.MetricsAddr,
+		c.EventRetention,
+		c.EventMaintenanceInterval,
+		c.SnapshotEvery,
+		c.MessagePurgeAfter,
+		c.NodeID,
 	)
 }
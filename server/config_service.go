@@ -2,37 +2,41 @@ package server
 
 import (
 	"context"
-	"fmt"
 	"strings"
-	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
 	pb "github.com/waifu-devs/fuwa/server/proto"
 )
 
 type configServiceServer struct {
 	pb.UnimplementedConfigServiceServer
 	config       *Config
+	router       *DatabaseRouter
 	eventService *eventServiceServer
 	configStore  ConfigStore
+	permissions  *PermissionResolver
 }
 
 type ConfigStore interface {
-	GetConfig(scope, key string) (*pb.ConfigValue, error)
-	GetConfigs(scope string, keys []string) (map[string]*pb.ConfigValue, error)
-	SetConfig(scope, key string, value *pb.ConfigValue, updatedBy string) (*pb.ConfigValue, error)
-	DeleteConfig(scope, key string, deletedBy string) (*pb.ConfigValue, error)
-	ListConfigKeys(scope, keyPrefix string) ([]*pb.ConfigInfo, error)
+	GetConfig(ctx context.Context, scope, key string) (*pb.ConfigValue, error)
+	GetConfigs(ctx context.Context, scope string, keys []string) (map[string]*pb.ConfigValue, error)
+	SetConfig(ctx context.Context, scope, key string, value *pb.ConfigValue, updatedBy string) (*pb.ConfigValue, error)
+	DeleteConfig(ctx context.Context, scope, key string, deletedBy string) (*pb.ConfigValue, error)
+	ListConfigKeys(ctx context.Context, scope, keyPrefix string) ([]*pb.ConfigInfo, error)
 }
 
-func NewConfigServiceServer(config *Config, eventService *eventServiceServer, configStore ConfigStore) *configServiceServer {
+func NewConfigServiceServer(config *Config, router *DatabaseRouter, eventService *eventServiceServer, configStore ConfigStore, permissions *PermissionResolver) *configServiceServer {
 	return &configServiceServer{
 		config:       config,
+		router:       router,
 		eventService: eventService,
 		configStore:  configStore,
+		permissions:  permissions,
 	}
 }
 
@@ -41,6 +45,15 @@ func (s *configServiceServer) GetConfig(ctx context.Context, req *pb.GetConfigRe
 		return nil, status.Error(codes.InvalidArgument, "scope is required")
 	}
 
+	if err := s.requireReadAccess(ctx, req.Scope); err != nil {
+		return nil, err
+	}
+	if req.IncludeSensitive {
+		if err := s.permissions.RequireScopePermission(ctx, req.Scope, PermManageConfig); err != nil {
+			return nil, err
+		}
+	}
+
 	configs := make(map[string]*pb.ConfigValue)
 
 	if req.Scope == "server" || strings.HasPrefix(req.Scope, "server:") {
@@ -57,9 +70,9 @@ func (s *configServiceServer) GetConfig(ctx context.Context, req *pb.GetConfigRe
 		var err error
 
 		if len(req.Keys) == 0 {
-			storeConfigs, err = s.configStore.GetConfigs(req.Scope, nil)
+			storeConfigs, err = s.configStore.GetConfigs(ctx, req.Scope, nil)
 		} else {
-			storeConfigs, err = s.configStore.GetConfigs(req.Scope, req.Keys)
+			storeConfigs, err = s.configStore.GetConfigs(ctx, req.Scope, req.Keys)
 		}
 
 		if err == nil {
@@ -85,6 +98,10 @@ func (s *configServiceServer) ListConfigs(ctx context.Context, req *pb.ListConfi
 		return nil, status.Error(codes.InvalidArgument, "scope is required")
 	}
 
+	if err := s.requireReadAccess(ctx, req.Scope); err != nil {
+		return nil, err
+	}
+
 	var configInfos []*pb.ConfigInfo
 
 	if req.Scope == "server" || strings.HasPrefix(req.Scope, "server:") {
@@ -93,7 +110,7 @@ func (s *configServiceServer) ListConfigs(ctx context.Context, req *pb.ListConfi
 	}
 
 	if s.configStore != nil {
-		storeConfigInfos, err := s.configStore.ListConfigKeys(req.Scope, req.KeyPrefix)
+		storeConfigInfos, err := s.configStore.ListConfigKeys(ctx, req.Scope, req.KeyPrefix)
 		if err == nil {
 			configInfos = append(configInfos, storeConfigInfos...)
 		}
@@ -116,26 +133,38 @@ func (s *configServiceServer) SetConfig(ctx context.Context, req *pb.SetConfigRe
 		return nil, status.Error(codes.InvalidArgument, "value is required")
 	}
 
+	if err := s.permissions.RequireScopePermission(ctx, req.Scope, PermManageConfig); err != nil {
+		return nil, err
+	}
+
 	if s.configStore == nil {
 		return nil, status.Error(codes.Unimplemented, "config storage not available")
 	}
 
 	actorId := s.getActorFromContext(ctx)
 
-	previousValue, err := s.configStore.SetConfig(req.Scope, req.Key, req.Value, actorId)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to set config: %v", err)
-	}
+	// Store the value and record config.updated in one transaction
+	var previousValue *pb.ConfigValue
+	var event *pb.Event
+	err := s.router.InScopeTx(ctx, req.Scope, func(ctx context.Context, tx *database.Queries) error {
+		var err error
+		previousValue, err = s.configStore.SetConfig(ctx, req.Scope, req.Key, req.Value, actorId)
+		if err != nil {
+			return err
+		}
 
-	eventId, err := s.publishConfigUpdatedEvent(req.Scope, req.Key, previousValue, req.Value, actorId, req.Description)
+		event = s.configUpdatedEvent(req.Scope, req.Key, previousValue, req.Value, actorId, req.Description)
+		return s.eventService.appendEvent(ctx, tx, event)
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to publish config event: %v", err)
+		return nil, txError(err, "set config")
 	}
+	s.eventService.notify()
 
 	return &pb.SetConfigResponse{
 		Success:       true,
 		PreviousValue: previousValue,
-		EventId:       eventId,
+		EventId:       event.EventId,
 	}, nil
 }
 
@@ -147,29 +176,47 @@ func (s *configServiceServer) DeleteConfig(ctx context.Context, req *pb.DeleteCo
 		return nil, status.Error(codes.InvalidArgument, "key is required")
 	}
 
+	if err := s.permissions.RequireScopePermission(ctx, req.Scope, PermManageConfig); err != nil {
+		return nil, err
+	}
+
 	if s.configStore == nil {
 		return nil, status.Error(codes.Unimplemented, "config storage not available")
 	}
 
 	actorId := s.getActorFromContext(ctx)
 
-	deletedValue, err := s.configStore.DeleteConfig(req.Scope, req.Key, actorId)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete config: %v", err)
-	}
+	// Delete the value and record config.deleted in one transaction
+	var deletedValue *pb.ConfigValue
+	var event *pb.Event
+	err := s.router.InScopeTx(ctx, req.Scope, func(ctx context.Context, tx *database.Queries) error {
+		var err error
+		deletedValue, err = s.configStore.DeleteConfig(ctx, req.Scope, req.Key, actorId)
+		if err != nil {
+			return err
+		}
 
-	eventId, err := s.publishConfigDeletedEvent(req.Scope, req.Key, deletedValue, actorId, req.Reason)
+		event = s.configDeletedEvent(req.Scope, req.Key, deletedValue, actorId, req.Reason)
+		return s.eventService.appendEvent(ctx, tx, event)
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to publish config event: %v", err)
+		return nil, txError(err, "delete config")
 	}
+	s.eventService.notify()
 
 	return &pb.DeleteConfigResponse{
 		Success:      true,
 		DeletedValue: deletedValue,
-		EventId:      eventId,
+		EventId:      event.EventId,
 	}, nil
 }
 
+// requireReadAccess hides channel and user scoped configs from callers who
+// cannot see the channel or are not that user.
+func (s *configServiceServer) requireReadAccess(ctx context.Context, scope string) error {
+	return s.permissions.RequireScopeRead(ctx, scope)
+}
+
 func (s *configServiceServer) getServerConfigs() map[string]*pb.ConfigValue {
 	configs := make(map[string]*pb.ConfigValue)
 
@@ -264,73 +311,74 @@ func (s *configServiceServer) getServerConfigInfos() []*pb.ConfigInfo {
 func (s *configServiceServer) filterSensitiveValues(configs map[string]*pb.ConfigValue) map[string]*pb.ConfigValue {
 	filtered := make(map[string]*pb.ConfigValue)
 	for k, v := range configs {
-		if v.IsSensitive {
-			filtered[k] = &pb.ConfigValue{
-				Value:       &pb.ConfigValue_StringValue{StringValue: "***"},
-				Type:        v.Type,
-				IsSensitive: true,
-			}
-		} else {
-			filtered[k] = v
-		}
+		filtered[k] = redactSensitiveValue(v)
 	}
 	return filtered
 }
 
-func (s *configServiceServer) publishConfigUpdatedEvent(scope, key string, oldValue, newValue *pb.ConfigValue, updatedBy, description string) (string, error) {
-	if s.eventService == nil {
-		return "", nil
+// redactSensitiveValue hides the value of sensitive configs, e.g. in event payloads
+func redactSensitiveValue(v *pb.ConfigValue) *pb.ConfigValue {
+	if v == nil || !v.IsSensitive {
+		return v
 	}
+	return &pb.ConfigValue{
+		Value:       &pb.ConfigValue_StringValue{StringValue: "***"},
+		Type:        v.Type,
+		IsSensitive: true,
+	}
+}
 
-	eventId := fmt.Sprintf("config-updated-%d", time.Now().UnixNano())
+// configUpdatedEvent builds the config.updated event for a SetConfig.
+func (s *configServiceServer) configUpdatedEvent(scope, key string, oldValue, newValue *pb.ConfigValue, updatedBy, description string) *pb.Event {
+	now := timestamppb.Now()
 
-	event := &pb.Event{
-		EventId:   eventId,
+	return &pb.Event{
+		EventId:   id.New(id.Event),
 		EventType: "config.updated",
 		Scope:     scope,
 		ActorId:   updatedBy,
-		Timestamp: timestamppb.Now(),
+		Timestamp: now,
+		Payload: eventPayload(&pb.ConfigUpdatedPayload{
+			Scope:       scope,
+			Key:         key,
+			OldValue:    redactSensitiveValue(oldValue),
+			NewValue:    redactSensitiveValue(newValue),
+			UpdatedBy:   updatedBy,
+			Description: description,
+			Timestamp:   now,
+		}),
 		Metadata: map[string]string{
 			"config_key": key,
 		},
-		Sequence: time.Now().Unix(),
 	}
-
-	_, err := s.eventService.Publish(context.Background(), &pb.PublishRequest{
-		Event: event,
-	})
-
-	return eventId, err
 }
 
-func (s *configServiceServer) publishConfigDeletedEvent(scope, key string, deletedValue *pb.ConfigValue, deletedBy, reason string) (string, error) {
-	if s.eventService == nil {
-		return "", nil
-	}
-
-	eventId := fmt.Sprintf("config-deleted-%d", time.Now().UnixNano())
+// configDeletedEvent builds the config.deleted event for a DeleteConfig.
+func (s *configServiceServer) configDeletedEvent(scope, key string, deletedValue *pb.ConfigValue, deletedBy, reason string) *pb.Event {
+	now := timestamppb.Now()
 
-	event := &pb.Event{
-		EventId:   eventId,
+	return &pb.Event{
+		EventId:   id.New(id.Event),
 		EventType: "config.deleted",
 		Scope:     scope,
 		ActorId:   deletedBy,
-		Timestamp: timestamppb.Now(),
+		Timestamp: now,
+		Payload: eventPayload(&pb.ConfigDeletedPayload{
+			Scope:        scope,
+			Key:          key,
+			DeletedValue: redactSensitiveValue(deletedValue),
+			DeletedBy:    deletedBy,
+			Reason:       reason,
+			Timestamp:    now,
+		}),
 		Metadata: map[string]string{
 			"config_key": key,
 		},
-		Sequence: time.Now().Unix(),
 	}
-
-	_, err := s.eventService.Publish(context.Background(), &pb.PublishRequest{
-		Event: event,
-	})
-
-	return eventId, err
 }
 
 func (s *configServiceServer) getActorFromContext(ctx context.Context) string {
-	return "system"
+	return getActorFromContext(ctx)
 }
 
 func contains(slice []string, item string) bool {
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// databaseConfigStore keeps config values in the config_values table of the
+// database a scope lives in. It looks the database up through the router, so
+// writes made inside DatabaseRouter.InScopeTx join that transaction.
+type databaseConfigStore struct {
+	router *DatabaseRouter
+}
+
+func NewDatabaseConfigStore(router *DatabaseRouter) *databaseConfigStore {
+	return &databaseConfigStore{router: router}
+}
+
+func (c *databaseConfigStore) GetConfig(ctx context.Context, scope, key string) (*pb.ConfigValue, error) {
+	db, err := c.router.ForScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := db.GetConfig(ctx, database.GetConfigParams{Scope: scope, Key: key})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dbConfigToProto(&row)
+}
+
+func (c *databaseConfigStore) GetConfigs(ctx context.Context, scope string, keys []string) (map[string]*pb.ConfigValue, error) {
+	db, err := c.router.ForScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []database.ConfigValue
+	if len(keys) == 0 {
+		rows, err = db.ListConfigKeys(ctx, database.ListConfigKeysParams{
+			Scope:   scope,
+			Column2: sql.NullString{Valid: true},
+			Column3: "",
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, key := range keys {
+			row, err := db.GetConfig(ctx, database.GetConfigParams{Scope: scope, Key: key})
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	configs := make(map[string]*pb.ConfigValue, len(rows))
+	for i := range rows {
+		value, err := dbConfigToProto(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		configs[rows[i].Key] = value
+	}
+	return configs, nil
+}
+
+func (c *databaseConfigStore) SetConfig(ctx context.Context, scope, key string, value *pb.ConfigValue, updatedBy string) (*pb.ConfigValue, error) {
+	previous, err := c.GetConfig(ctx, scope, key)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := c.router.ForScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := setConfigParams(scope, key, value, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.SetConfig(ctx, params); err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+func (c *databaseConfigStore) DeleteConfig(ctx context.Context, scope, key string, deletedBy string) (*pb.ConfigValue, error) {
+	db, err := c.router.ForScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := db.DeleteConfig(ctx, database.DeleteConfigParams{Scope: scope, Key: key})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "config %s not found in scope %s", key, scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dbConfigToProto(&row)
+}
+
+func (c *databaseConfigStore) ListConfigKeys(ctx context.Context, scope, keyPrefix string) ([]*pb.ConfigInfo, error) {
+	db, err := c.router.ForScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.ListConfigKeys(ctx, database.ListConfigKeysParams{
+		Scope:   scope,
+		Column2: sql.NullString{String: keyPrefix, Valid: true},
+		Column3: keyPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*pb.ConfigInfo, 0, len(rows))
+	for i := range rows {
+		value, err := dbConfigToProto(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, &pb.ConfigInfo{
+			Key:         rows[i].Key,
+			Type:        value.Type,
+			IsSensitive: value.IsSensitive,
+			Constraints: value.Constraints,
+			CreatedAt:   timestamppb.New(time.Unix(rows[i].CreatedAt, 0)),
+			UpdatedAt:   timestamppb.New(time.Unix(rows[i].UpdatedAt, 0)),
+		})
+	}
+	return infos, nil
+}
+
+// setConfigParams encodes a config value for the config_values table.
+func setConfigParams(scope, key string, value *pb.ConfigValue, now int64) (database.SetConfigParams, error) {
+	encoded, err := protojson.Marshal(value)
+	if err != nil {
+		return database.SetConfigParams{}, status.Errorf(codes.InvalidArgument, "invalid config value: %v", err)
+	}
+
+	var constraints sql.NullString
+	if value.Constraints != nil {
+		encodedConstraints, err := protojson.Marshal(value.Constraints)
+		if err != nil {
+			return database.SetConfigParams{}, status.Errorf(codes.InvalidArgument, "invalid config constraints: %v", err)
+		}
+		constraints = sql.NullString{String: string(encodedConstraints), Valid: true}
+	}
+
+	return database.SetConfigParams{
+		Scope:       scope,
+		Key:         key,
+		Value:       string(encoded),
+		Type:        int64(value.Type),
+		IsSensitive: boolToInt64(value.IsSensitive),
+		Constraints: constraints,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// dbConfigToProto decodes a stored config value. The value column holds the
+// whole ConfigValue as protojson; type and sensitivity are also kept in their
+// own columns so they can be queried.
+func dbConfigToProto(row *database.ConfigValue) (*pb.ConfigValue, error) {
+	value := &pb.ConfigValue{}
+	if err := protojson.Unmarshal([]byte(row.Value), value); err != nil {
+		return nil, fmt.Errorf("failed to decode config %s: %w", row.Key, err)
+	}
+	value.Type = pb.ConfigValueType(row.Type)
+	value.IsSensitive = row.IsSensitive != 0
+	return value, nil
+}
@@ -0,0 +1,591 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// Consumer groups give at-least-once delivery of one scope to a set of
+// cooperating consumers. Each event goes to one member of the group and is
+// redelivered, to any member, until it is acked. The group's position, the
+// sequence up to which every event has been acked, is stored in
+// consumer_offsets in the scope's database, so a group picks up where it
+// left off after a restart. Events that keep failing are moved to the
+// "dead_letter:<group>" scope as consumer.dead_lettered events.
+//
+// A group lives in memory while it has members; settings other than
+// max_in_flight are fixed by the first member and must match for the rest.
+
+const (
+	defaultConsumerMaxInFlight   = 10
+	maxConsumerMaxInFlight       = 1000
+	defaultConsumerAckTimeout    = 30 * time.Second
+	defaultConsumerMaxDeliveries = 5
+
+	// consumerLoadBatch bounds how many undelivered events a group holds
+	consumerLoadBatch = 100
+	// consumerTickInterval is how often groups look for expired deliveries
+	consumerTickInterval = 500 * time.Millisecond
+)
+
+var consumerGroupNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// deadLetterScope is the scope a group's dead-lettered events go to.
+func deadLetterScope(group string) string {
+	return "dead_letter:" + group
+}
+
+type consumerGroup struct {
+	s     *eventServiceServer
+	name  string
+	scope string
+
+	eventTypes    []pattern
+	rawEventTypes []string
+	rawFilter     string
+	filter        *eventFilter
+	ackTimeout    time.Duration
+	maxDeliveries int32
+
+	// userID is the first member, whose permissions decide which events
+	// about channels the group may see
+	userID     string
+	visibility *channelVisibilityCache
+
+	mu         sync.Mutex
+	members    []*consumerMember
+	nextMember int
+	// pending holds loaded events waiting for a member, oldest first
+	pending  []*consumerDelivery
+	inFlight map[int64]*consumerDelivery
+	// acked is the stored position; next is the next sequence to load
+	acked int64
+	next  int64
+
+	kick chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+type consumerDelivery struct {
+	event    *pb.Event
+	count    int32
+	member   *consumerMember
+	deadline time.Time
+}
+
+type consumerMember struct {
+	userID      string
+	maxInFlight int
+	inFlight    int
+	out         chan *pb.ConsumedEvent
+}
+
+func (s *eventServiceServer) Consume(req *pb.ConsumeRequest, stream pb.EventService_ConsumeServer) error {
+	ctx := stream.Context()
+
+	if !consumerGroupNamePattern.MatchString(req.Group) {
+		return status.Error(codes.InvalidArgument, "group must be 1-64 letters, digits, '_', '.' or '-'")
+	}
+	if err := validateConsumerScope(req.Scope); err != nil {
+		return err
+	}
+	if err := s.permissions.RequireScopeRead(ctx, req.Scope); err != nil {
+		return err
+	}
+
+	eventTypes, _, err := parseSubscriptionPatterns(req.EventTypes, nil)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	filter, err := compileEventFilter(req.Filter, s.permissions.callerID(ctx))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	maxInFlight := int(req.MaxInFlight)
+	if maxInFlight <= 0 {
+		maxInFlight = defaultConsumerMaxInFlight
+	}
+	if maxInFlight > maxConsumerMaxInFlight {
+		return status.Errorf(codes.InvalidArgument, "max_in_flight must be at most %d", maxConsumerMaxInFlight)
+	}
+	ackTimeout := time.Duration(req.AckTimeoutSeconds) * time.Second
+	if ackTimeout <= 0 {
+		ackTimeout = defaultConsumerAckTimeout
+	}
+	maxDeliveries := req.MaxDeliveries
+	if maxDeliveries <= 0 {
+		maxDeliveries = defaultConsumerMaxDeliveries
+	}
+
+	member := &consumerMember{
+		userID:      s.permissions.callerID(ctx),
+		maxInFlight: maxInFlight,
+		out:         make(chan *pb.ConsumedEvent, maxInFlight),
+	}
+
+	group, err := s.joinConsumerGroup(ctx, req, &consumerGroup{
+		s:             s,
+		name:          req.Group,
+		scope:         req.Scope,
+		eventTypes:    eventTypes,
+		rawEventTypes: req.EventTypes,
+		rawFilter:     req.Filter,
+		filter:        filter,
+		ackTimeout:    ackTimeout,
+		maxDeliveries: maxDeliveries,
+		userID:        member.userID,
+		visibility:    newChannelVisibilityCache(),
+		inFlight:      make(map[int64]*consumerDelivery),
+		kick:          make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}, member)
+	if err != nil {
+		return err
+	}
+	defer s.leaveConsumerGroup(group, member)
+
+	log.Printf("Consumer joined group %s on %s", req.Group, req.Scope)
+	for {
+		select {
+		case delivery := <-member.out:
+			if err := stream.Send(delivery); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			log.Printf("Consumer left group %s on %s", req.Group, req.Scope)
+			return nil
+		}
+	}
+}
+
+func (s *eventServiceServer) Ack(ctx context.Context, req *pb.AckRequest) (*pb.AckResponse, error) {
+	group, err := s.activeConsumerGroup(ctx, req.Group, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	acked, err := group.ack(ctx, req.Sequences)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AckResponse{AckedSequence: acked}, nil
+}
+
+func (s *eventServiceServer) Nack(ctx context.Context, req *pb.NackRequest) (*pb.NackResponse, error) {
+	group, err := s.activeConsumerGroup(ctx, req.Group, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	group.nack(req.Sequences)
+	return &pb.NackResponse{Success: true}, nil
+}
+
+func validateConsumerScope(scope string) error {
+	if scope == "" {
+		return status.Error(codes.InvalidArgument, "scope is required")
+	}
+	if _, err := parseScopePattern(scope); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Parsing puts channels under any server, so look at the scope itself
+	if strings.ContainsAny(scope, "*/") {
+		return status.Error(codes.InvalidArgument, "scope must be a single concrete scope, not a pattern")
+	}
+	return nil
+}
+
+// joinConsumerGroup adds a member to a group, loading the group's stored
+// position if it isn't active yet.
+func (s *eventServiceServer) joinConsumerGroup(ctx context.Context, req *pb.ConsumeRequest, candidate *consumerGroup, member *consumerMember) (*consumerGroup, error) {
+	s.consumerMu.Lock()
+	defer s.consumerMu.Unlock()
+
+	group := s.consumerGroups[req.Scope][req.Group]
+	if group != nil {
+		if !slices.Equal(group.rawEventTypes, candidate.rawEventTypes) || group.rawFilter != candidate.rawFilter ||
+			group.ackTimeout != candidate.ackTimeout || group.maxDeliveries != candidate.maxDeliveries {
+			return nil, status.Errorf(codes.FailedPrecondition, "consumer group %s is active on %s with different settings", req.Group, req.Scope)
+		}
+	} else {
+		group = candidate
+		acked, err := s.loadConsumerOffset(ctx, group.name, group.scope)
+		if err != nil {
+			return nil, err
+		}
+		group.acked = acked
+		group.next = acked + 1
+
+		if s.consumerGroups[req.Scope] == nil {
+			s.consumerGroups[req.Scope] = make(map[string]*consumerGroup)
+		}
+		s.consumerGroups[req.Scope][req.Group] = group
+		go group.run()
+	}
+
+	group.mu.Lock()
+	group.members = append(group.members, member)
+	group.mu.Unlock()
+	group.wake()
+	return group, nil
+}
+
+// leaveConsumerGroup removes a member, handing its unacked events to the
+// rest of the group. The last member to leave stops the group.
+func (s *eventServiceServer) leaveConsumerGroup(group *consumerGroup, member *consumerMember) {
+	s.consumerMu.Lock()
+	group.mu.Lock()
+	group.members = slices.DeleteFunc(group.members, func(m *consumerMember) bool { return m == member })
+	var returned []*consumerDelivery
+	for sequence, delivery := range group.inFlight {
+		if delivery.member == member {
+			delete(group.inFlight, sequence)
+			delivery.member = nil
+			returned = append(returned, delivery)
+		}
+	}
+	group.requeue(returned)
+	empty := len(group.members) == 0
+	group.mu.Unlock()
+
+	if !empty {
+		s.consumerMu.Unlock()
+		group.wake()
+		return
+	}
+
+	// Stop the group before anyone can rejoin, so a new instance loads the
+	// final position
+	delete(s.consumerGroups[group.scope], group.name)
+	if len(s.consumerGroups[group.scope]) == 0 {
+		delete(s.consumerGroups, group.scope)
+	}
+	close(group.stop)
+	<-group.done
+	s.consumerMu.Unlock()
+}
+
+// activeConsumerGroup finds a group the caller is consuming from.
+func (s *eventServiceServer) activeConsumerGroup(ctx context.Context, name, scope string) (*consumerGroup, error) {
+	s.consumerMu.Lock()
+	group := s.consumerGroups[scope][name]
+	s.consumerMu.Unlock()
+	if group == nil {
+		return nil, status.Errorf(codes.NotFound, "consumer group %s is not active on %s", name, scope)
+	}
+
+	callerID := s.permissions.callerID(ctx)
+	group.mu.Lock()
+	defer group.mu.Unlock()
+	for _, member := range group.members {
+		if member.userID == callerID {
+			return group, nil
+		}
+	}
+	return nil, status.Errorf(codes.PermissionDenied, "not a member of consumer group %s", name)
+}
+
+func (s *eventServiceServer) loadConsumerOffset(ctx context.Context, group, scope string) (int64, error) {
+	db, err := s.router.ForScope(ctx, scope)
+	if err != nil {
+		return 0, routeError(err, "scope not found")
+	}
+
+	acked, err := db.GetConsumerOffset(ctx, database.GetConsumerOffsetParams{GroupName: group, Scope: scope})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get consumer offset: %v", err)
+	}
+	return acked, nil
+}
+
+// kickConsumerGroups wakes the groups consuming a scope after an event was
+// committed to it.
+func (s *eventServiceServer) kickConsumerGroups(scope string) {
+	s.consumerMu.Lock()
+	defer s.consumerMu.Unlock()
+	for _, group := range s.consumerGroups[scope] {
+		group.wake()
+	}
+}
+
+func (g *consumerGroup) wake() {
+	select {
+	case g.kick <- struct{}{}:
+	default:
+	}
+}
+
+func (g *consumerGroup) run() {
+	defer close(g.done)
+	ticker := time.NewTicker(consumerTickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.kick:
+		case <-ticker.C:
+		case <-g.stop:
+			return
+		}
+		g.pump()
+	}
+}
+
+// pump redelivers expired events, loads new ones and hands them to members
+// with free capacity.
+func (g *consumerGroup) pump() {
+	ctx, release := WithDatabaseLeases(context.Background())
+	defer release()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	var expired []*consumerDelivery
+	for sequence, delivery := range g.inFlight {
+		if now.After(delivery.deadline) {
+			delete(g.inFlight, sequence)
+			delivery.member.inFlight--
+			delivery.member = nil
+			expired = append(expired, delivery)
+		}
+	}
+	g.requeue(expired)
+
+	if len(g.pending) < consumerLoadBatch {
+		g.load(ctx)
+	}
+	g.assign(ctx, now)
+
+	if err := g.commit(ctx); err != nil {
+		log.Printf("Failed to store offset of consumer group %s on %s: %v", g.name, g.scope, err)
+	}
+}
+
+// load reads the next events of the scope. Events the group doesn't want are
+// skipped without being delivered and count as acked.
+func (g *consumerGroup) load(ctx context.Context) {
+	db, err := g.s.router.ForScope(ctx, g.scope)
+	if err != nil {
+		log.Printf("Failed to resolve database for consumer group %s on %s: %v", g.name, g.scope, err)
+		return
+	}
+
+	limit := int64(consumerLoadBatch - len(g.pending))
+	rows, err := db.GetEvents(ctx, database.GetEventsParams{
+		Scope:         g.scope,
+		AllEventTypes: true,
+		FromSequence:  g.next,
+		ToSequence:    math.MaxInt64,
+		Limit:         limit,
+	})
+	if err != nil {
+		log.Printf("Failed to load events for consumer group %s on %s: %v", g.name, g.scope, err)
+		return
+	}
+
+	// Check visibility as the group's user, under the pump's leases
+	callerCtx := ctx
+	if g.userID != "" {
+		callerCtx = ContextWithPrincipal(ctx, &Principal{UserID: g.userID})
+	}
+
+	for i := range rows {
+		event := dbEventToProto(&rows[i])
+		g.next = event.Sequence + 1
+		if eventTypeMatches(g.eventTypes, event.EventType) && g.filter.matches(event) && g.s.canSeeEvent(callerCtx, event, g.visibility) {
+			g.pending = append(g.pending, &consumerDelivery{event: event})
+		}
+	}
+
+	// A full page may have been filtered away entirely; keep reading
+	if int64(len(rows)) == limit {
+		g.wake()
+	}
+}
+
+// assign hands pending events to members round-robin, dead-lettering those
+// that ran out of deliveries.
+func (g *consumerGroup) assign(ctx context.Context, now time.Time) {
+	for len(g.pending) > 0 {
+		delivery := g.pending[0]
+		if delivery.count >= g.maxDeliveries {
+			if err := g.deadLetter(ctx, delivery); err != nil {
+				log.Printf("Failed to dead-letter event %d of consumer group %s on %s: %v", delivery.event.Sequence, g.name, g.scope, err)
+				return
+			}
+			g.pending = g.pending[1:]
+			continue
+		}
+
+		member := g.pickMember()
+		if member == nil {
+			return
+		}
+
+		consumed := &pb.ConsumedEvent{Event: delivery.event, DeliveryCount: delivery.count + 1}
+		select {
+		case member.out <- consumed:
+		default:
+			// The member hasn't read events that already expired; skip it
+			// until it catches up
+			return
+		}
+
+		g.pending = g.pending[1:]
+		delivery.count++
+		delivery.member = member
+		delivery.deadline = now.Add(g.ackTimeout)
+		member.inFlight++
+		g.inFlight[delivery.event.Sequence] = delivery
+	}
+}
+
+func (g *consumerGroup) pickMember() *consumerMember {
+	for range g.members {
+		member := g.members[g.nextMember%len(g.members)]
+		g.nextMember = (g.nextMember + 1) % len(g.members)
+		if member.inFlight < member.maxInFlight {
+			return member
+		}
+	}
+	return nil
+}
+
+// requeue puts returned deliveries back in front of the pending events.
+func (g *consumerGroup) requeue(deliveries []*consumerDelivery) {
+	if len(deliveries) == 0 {
+		return
+	}
+	slices.SortFunc(deliveries, func(a, b *consumerDelivery) int {
+		return int(a.event.Sequence - b.event.Sequence)
+	})
+	g.pending = append(deliveries, g.pending...)
+}
+
+func (g *consumerGroup) deadLetter(ctx context.Context, delivery *consumerDelivery) error {
+	scope := deadLetterScope(g.name)
+	err := g.s.router.InScopeTx(ctx, scope, func(ctx context.Context, tx *database.Queries) error {
+		return g.s.appendEvent(ctx, tx, &pb.Event{
+			EventId:   id.New(id.Event),
+			EventType: "consumer.dead_lettered",
+			Scope:     scope,
+			ActorId:   "system",
+			Timestamp: timestamppb.Now(),
+			Payload: eventPayload(&pb.DeadLetterPayload{
+				Event:         delivery.event,
+				Group:         g.name,
+				DeliveryCount: delivery.count,
+			}),
+			Metadata: map[string]string{
+				"group":    g.name,
+				"scope":    g.scope,
+				"event_id": delivery.event.EventId,
+				"sequence": fmt.Sprintf("%d", delivery.event.Sequence),
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	g.s.notify()
+	return nil
+}
+
+// ack settles delivered events and stores the group's new position.
+// Sequences the group no longer waits for, e.g. acked twice, are ignored.
+func (g *consumerGroup) ack(ctx context.Context, sequences []int64) (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, sequence := range sequences {
+		if delivery, ok := g.inFlight[sequence]; ok {
+			delete(g.inFlight, sequence)
+			delivery.member.inFlight--
+			continue
+		}
+		// An expired delivery may be acked before it goes out again
+		g.pending = slices.DeleteFunc(g.pending, func(d *consumerDelivery) bool {
+			return d.event.Sequence == sequence
+		})
+	}
+
+	if err := g.commit(ctx); err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to store consumer offset: %v", err)
+	}
+	g.wake()
+	return g.acked, nil
+}
+
+// nack hands in-flight events back for immediate redelivery. The delivery
+// still counts towards max_deliveries.
+func (g *consumerGroup) nack(sequences []int64) {
+	g.mu.Lock()
+	var returned []*consumerDelivery
+	for _, sequence := range sequences {
+		if delivery, ok := g.inFlight[sequence]; ok {
+			delete(g.inFlight, sequence)
+			delivery.member.inFlight--
+			delivery.member = nil
+			returned = append(returned, delivery)
+		}
+	}
+	g.requeue(returned)
+	g.mu.Unlock()
+
+	g.wake()
+}
+
+// commit stores the group's position: everything before the oldest event
+// still pending or in flight, or everything loaded if there is none.
+func (g *consumerGroup) commit(ctx context.Context) error {
+	position := g.next - 1
+	for _, delivery := range g.pending {
+		position = min(position, delivery.event.Sequence-1)
+	}
+	for sequence := range g.inFlight {
+		position = min(position, sequence-1)
+	}
+	if position <= g.acked {
+		return nil
+	}
+
+	db, err := g.s.router.ForScope(ctx, g.scope)
+	if err != nil {
+		return err
+	}
+	err = db.SetConsumerOffset(ctx, database.SetConsumerOffsetParams{
+		GroupName:     g.name,
+		Scope:         g.scope,
+		AckedSequence: position,
+		UpdatedAt:     time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	g.acked = position
+	return nil
+}
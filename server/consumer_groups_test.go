@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// consumedStream collects the events a consumer group member is sent.
+type consumedStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *pb.ConsumedEvent
+}
+
+func (s *consumedStream) Context() context.Context { return s.ctx }
+
+func (s *consumedStream) Send(event *pb.ConsumedEvent) error {
+	s.events <- event
+	return nil
+}
+
+// next returns the next event sent, failing the test if none arrives.
+func (s *consumedStream) next(t *testing.T) *pb.ConsumedEvent {
+	t.Helper()
+	select {
+	case event := <-s.events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event consumed")
+		return nil
+	}
+}
+
+// consume joins a consumer group as userID, holding database leases like the
+// gRPC interceptors do. The returned stop leaves the group and releases them.
+func consume(t *testing.T, s *testServer, userID string, req *pb.ConsumeRequest) (*consumedStream, func()) {
+	t.Helper()
+
+	ctx, release := WithDatabaseLeases(ContextWithPrincipal(context.Background(), &Principal{UserID: userID}))
+	ctx, cancel := context.WithCancel(ctx)
+	stream := &consumedStream{ctx: ctx, events: make(chan *pb.ConsumedEvent, 100)}
+	done := make(chan error, 1)
+	go func() { done <- s.events.Consume(req, stream) }()
+
+	var once bool
+	stop := func() {
+		if once {
+			return
+		}
+		once = true
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Consume: %v", err)
+		}
+		release()
+	}
+	t.Cleanup(stop)
+	return stream, stop
+}
+
+func consumerGroupMembers(s *testServer, req *pb.ConsumeRequest) int {
+	s.events.consumerMu.Lock()
+	group := s.events.consumerGroups[req.Scope][req.Group]
+	s.events.consumerMu.Unlock()
+	if group == nil {
+		return 0
+	}
+	group.mu.Lock()
+	defer group.mu.Unlock()
+	return len(group.members)
+}
+
+func waitForMembers(t *testing.T, s *testServer, req *pb.ConsumeRequest, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for consumerGroupMembers(s, req) != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("consumer group has %d members, want %d", consumerGroupMembers(s, req), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConsumerGroupOutlivesFirstMember(t *testing.T) {
+	s := newTestServer(t, nil)
+	alice, release := WithDatabaseLeases(ContextWithPrincipal(context.Background(), &Principal{UserID: "alice"}))
+	defer release()
+	channel, err := s.channels.CreateChannel(alice, &pb.CreateChannelRequest{Name: "jobs", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1"})
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+	req := &pb.ConsumeRequest{Group: "workers", Scope: "channel:" + channel.Channel.ChannelId, EventTypes: []string{"app.*"}}
+
+	_, stopFirst := consume(t, s, "alice", req)
+	waitForMembers(t, s, req, 1)
+	second, stopSecond := consume(t, s, "alice", req)
+	waitForMembers(t, s, req, 2)
+
+	// The group keeps going on the second member's stream once the first
+	// member's request, and its leases, are gone
+	stopFirst()
+	waitForMembers(t, s, req, 1)
+	for range 3 {
+		if _, err := s.events.Publish(alice, &pb.PublishRequest{Event: &pb.Event{Scope: req.Scope, EventType: "app.job"}}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	var sequences []int64
+	for range 3 {
+		consumed := second.next(t)
+		sequences = append(sequences, consumed.Event.Sequence)
+	}
+	ack, err := s.events.Ack(alice, &pb.AckRequest{Group: req.Group, Scope: req.Scope, Sequences: sequences})
+	if err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	if ack.AckedSequence != sequences[len(sequences)-1] {
+		t.Errorf("acked up to %d, want %d", ack.AckedSequence, sequences[len(sequences)-1])
+	}
+	stopSecond()
+	release()
+
+	// Nothing the group did holds on to a database
+	s.manager.mu.Lock()
+	defer s.manager.mu.Unlock()
+	for name, entry := range s.manager.databases {
+		if name != PrimaryDatabaseName && entry.refs != 0 {
+			t.Errorf("%s has %d references after every member left", name, entry.refs)
+		}
+	}
+}
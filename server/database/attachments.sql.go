@@ -7,17 +7,20 @@ package database
 
 import (
 	"context"
+	"strings"
 )
 
 const createAttachment = `-- name: CreateAttachment :one
-INSERT INTO attachments (attachment_id, message_id, filename, content_type, size, url)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO attachments (attachment_id, message_id, channel_id, author_id, filename, content_type, size, url)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING attachment_id, message_id, channel_id, author_id, filename, content_type, size, url
 `
 
 type CreateAttachmentParams struct {
 	AttachmentID string `json:"attachment_id"`
 	MessageID    string `json:"message_id"`
+	ChannelID    string `json:"channel_id"`
+	AuthorID     string `json:"author_id"`
 	Filename     string `json:"filename"`
 	ContentType  string `json:"content_type"`
 	Size         int64  `json:"size"`
@@ -28,6 +31,8 @@ func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentPara
 	row := q.db.QueryRowContext(ctx, createAttachment,
 		arg.AttachmentID,
 		arg.MessageID,
+		arg.ChannelID,
+		arg.AuthorID,
 		arg.Filename,
 		arg.ContentType,
 		arg.Size,
@@ -47,6 +52,15 @@ func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentPara
 	return i, err
 }
 
+const deleteAllAttachments = `-- name: DeleteAllAttachments :exec
+DELETE FROM attachments
+`
+
+func (q *Queries) DeleteAllAttachments(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteAllAttachments)
+	return err
+}
+
 const deleteAttachment = `-- name: DeleteAttachment :exec
 DELETE FROM attachments
 WHERE attachment_id = ?
@@ -57,6 +71,26 @@ func (q *Queries) DeleteAttachment(ctx context.Context, attachmentID string) err
 	return err
 }
 
+const deleteAttachmentsByChannelId = `-- name: DeleteAttachmentsByChannelId :exec
+DELETE FROM attachments
+WHERE message_id IN (SELECT message_id FROM messages WHERE channel_id = ?)
+`
+
+func (q *Queries) DeleteAttachmentsByChannelId(ctx context.Context, channelID string) error {
+	_, err := q.db.ExecContext(ctx, deleteAttachmentsByChannelId, channelID)
+	return err
+}
+
+const deleteAttachmentsByMessageId = `-- name: DeleteAttachmentsByMessageId :exec
+DELETE FROM attachments
+WHERE message_id = ?
+`
+
+func (q *Queries) DeleteAttachmentsByMessageId(ctx context.Context, messageID string) error {
+	_, err := q.db.ExecContext(ctx, deleteAttachmentsByMessageId, messageID)
+	return err
+}
+
 const getAttachment = `-- name: GetAttachment :one
 SELECT attachment_id, message_id, channel_id, author_id, filename, content_type, size, url FROM attachments
 WHERE attachment_id = ?
@@ -114,3 +148,51 @@ func (q *Queries) GetAttachmentsByMessageId(ctx context.Context, messageID strin
 	}
 	return items, nil
 }
+
+const listAttachmentsByMessageIds = `-- name: ListAttachmentsByMessageIds :many
+SELECT attachment_id, message_id, channel_id, author_id, filename, content_type, size, url FROM attachments
+WHERE message_id IN (/*SLICE:message_ids*/?)
+ORDER BY message_id, rowid
+`
+
+func (q *Queries) ListAttachmentsByMessageIds(ctx context.Context, messageIds []string) ([]Attachment, error) {
+	query := listAttachmentsByMessageIds
+	var queryParams []interface{}
+	if len(messageIds) > 0 {
+		for _, v := range messageIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:message_ids*/?", strings.Repeat(",?", len(messageIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:message_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.AttachmentID,
+			&i.MessageID,
+			&i.ChannelID,
+			&i.AuthorID,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.Url,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
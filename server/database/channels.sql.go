@@ -10,6 +10,18 @@ import (
 	"database/sql"
 )
 
+const countChannelsByServerId = `-- name: CountChannelsByServerId :one
+SELECT COUNT(*) FROM channels
+WHERE server_id = ?
+`
+
+func (q *Queries) CountChannelsByServerId(ctx context.Context, serverID sql.NullString) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countChannelsByServerId, serverID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createChannel = `-- name: CreateChannel :one
 INSERT INTO channels (channel_id, name, type, server_id, parent_id, metadata, created_at, updated_at)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
@@ -52,6 +64,15 @@ func (q *Queries) CreateChannel(ctx context.Context, arg CreateChannelParams) (C
 	return i, err
 }
 
+const deleteAllChannels = `-- name: DeleteAllChannels :exec
+DELETE FROM channels
+`
+
+func (q *Queries) DeleteAllChannels(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteAllChannels)
+	return err
+}
+
 const deleteChannel = `-- name: DeleteChannel :exec
 DELETE FROM channels
 WHERE channel_id = ?
@@ -62,6 +83,17 @@ func (q *Queries) DeleteChannel(ctx context.Context, channelID string) error {
 	return err
 }
 
+const detachChildChannels = `-- name: DetachChildChannels :exec
+UPDATE channels
+SET parent_id = NULL
+WHERE parent_id = ?
+`
+
+func (q *Queries) DetachChildChannels(ctx context.Context, parentID sql.NullString) error {
+	_, err := q.db.ExecContext(ctx, detachChildChannels, parentID)
+	return err
+}
+
 const getChannel = `-- name: GetChannel :one
 SELECT channel_id, name, type, server_id, parent_id, metadata, created_at, updated_at FROM channels
 WHERE channel_id = ?
@@ -83,6 +115,43 @@ func (q *Queries) GetChannel(ctx context.Context, channelID string) (Channel, er
 	return i, err
 }
 
+const listAllChannels = `-- name: ListAllChannels :many
+SELECT channel_id, name, type, server_id, parent_id, metadata, created_at, updated_at FROM channels
+ORDER BY channel_id
+`
+
+func (q *Queries) ListAllChannels(ctx context.Context) ([]Channel, error) {
+	rows, err := q.db.QueryContext(ctx, listAllChannels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Channel
+	for rows.Next() {
+		var i Channel
+		if err := rows.Scan(
+			&i.ChannelID,
+			&i.Name,
+			&i.Type,
+			&i.ServerID,
+			&i.ParentID,
+			&i.Metadata,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listChannels = `-- name: ListChannels :many
 SELECT channel_id, name, type, server_id, parent_id, metadata, created_at, updated_at FROM channels
 WHERE (server_id = ? OR ? = '')
@@ -177,15 +246,54 @@ func (q *Queries) ListChannelsByServerId(ctx context.Context, serverID sql.NullS
 	return items, nil
 }
 
+const listChildChannels = `-- name: ListChildChannels :many
+SELECT channel_id, name, type, server_id, parent_id, metadata, created_at, updated_at FROM channels
+WHERE parent_id = ?
+ORDER BY created_at, channel_id
+`
+
+func (q *Queries) ListChildChannels(ctx context.Context, parentID sql.NullString) ([]Channel, error) {
+	rows, err := q.db.QueryContext(ctx, listChildChannels, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Channel
+	for rows.Next() {
+		var i Channel
+		if err := rows.Scan(
+			&i.ChannelID,
+			&i.Name,
+			&i.Type,
+			&i.ServerID,
+			&i.ParentID,
+			&i.Metadata,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateChannel = `-- name: UpdateChannel :one
 UPDATE channels 
-SET name = ?, metadata = ?, updated_at = ?
+SET name = ?, parent_id = ?, metadata = ?, updated_at = ?
 WHERE channel_id = ?
 RETURNING channel_id, name, type, server_id, parent_id, metadata, created_at, updated_at
 `
 
 type UpdateChannelParams struct {
 	Name      string         `json:"name"`
+	ParentID  sql.NullString `json:"parent_id"`
 	Metadata  sql.NullString `json:"metadata"`
 	UpdatedAt int64          `json:"updated_at"`
 	ChannelID string         `json:"channel_id"`
@@ -194,6 +302,7 @@ type UpdateChannelParams struct {
 func (q *Queries) UpdateChannel(ctx context.Context, arg UpdateChannelParams) (Channel, error) {
 	row := q.db.QueryRowContext(ctx, updateChannel,
 		arg.Name,
+		arg.ParentID,
 		arg.Metadata,
 		arg.UpdatedAt,
 		arg.ChannelID,
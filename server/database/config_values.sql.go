@@ -38,6 +38,16 @@ func (q *Queries) DeleteConfig(ctx context.Context, arg DeleteConfigParams) (Con
 	return i, err
 }
 
+const deleteNonSensitiveConfigs = `-- name: DeleteNonSensitiveConfigs :exec
+DELETE FROM config_values
+WHERE is_sensitive = 0
+`
+
+func (q *Queries) DeleteNonSensitiveConfigs(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteNonSensitiveConfigs)
+	return err
+}
+
 const getConfig = `-- name: GetConfig :one
 SELECT scope, "key", value, type, is_sensitive, constraints, created_at, updated_at FROM config_values
 WHERE scope = ? AND key = ?
@@ -118,6 +128,43 @@ func (q *Queries) GetConfigs(ctx context.Context, arg GetConfigsParams) ([]Confi
 	return items, nil
 }
 
+const listAllConfigs = `-- name: ListAllConfigs :many
+SELECT scope, key, value, type, is_sensitive, constraints, created_at, updated_at FROM config_values
+ORDER BY scope, key
+`
+
+func (q *Queries) ListAllConfigs(ctx context.Context) ([]ConfigValue, error) {
+	rows, err := q.db.QueryContext(ctx, listAllConfigs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ConfigValue
+	for rows.Next() {
+		var i ConfigValue
+		if err := rows.Scan(
+			&i.Scope,
+			&i.Key,
+			&i.Value,
+			&i.Type,
+			&i.IsSensitive,
+			&i.Constraints,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listConfigKeys = `-- name: ListConfigKeys :many
 SELECT scope, "key", value, type, is_sensitive, constraints, created_at, updated_at FROM config_values
 WHERE scope = ?
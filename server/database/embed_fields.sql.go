@@ -7,16 +7,16 @@ package database
 
 import (
 	"context"
+	"strings"
 )
 
 const createEmbedField = `-- name: CreateEmbedField :one
-INSERT INTO embed_fields (field_id, embed_id, name, value, inline)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO embed_fields (embed_id, name, value, inline)
+VALUES (?, ?, ?, ?)
 RETURNING field_id, embed_id, name, value, inline
 `
 
 type CreateEmbedFieldParams struct {
-	FieldID int64  `json:"field_id"`
 	EmbedID int64  `json:"embed_id"`
 	Name    string `json:"name"`
 	Value   string `json:"value"`
@@ -25,7 +25,6 @@ type CreateEmbedFieldParams struct {
 
 func (q *Queries) CreateEmbedField(ctx context.Context, arg CreateEmbedFieldParams) (EmbedField, error) {
 	row := q.db.QueryRowContext(ctx, createEmbedField,
-		arg.FieldID,
 		arg.EmbedID,
 		arg.Name,
 		arg.Value,
@@ -42,6 +41,15 @@ func (q *Queries) CreateEmbedField(ctx context.Context, arg CreateEmbedFieldPara
 	return i, err
 }
 
+const deleteAllEmbedFields = `-- name: DeleteAllEmbedFields :exec
+DELETE FROM embed_fields
+`
+
+func (q *Queries) DeleteAllEmbedFields(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteAllEmbedFields)
+	return err
+}
+
 const deleteEmbedField = `-- name: DeleteEmbedField :exec
 DELETE FROM embed_fields
 WHERE field_id = ?
@@ -52,6 +60,29 @@ func (q *Queries) DeleteEmbedField(ctx context.Context, fieldID int64) error {
 	return err
 }
 
+const deleteEmbedFieldsByChannelId = `-- name: DeleteEmbedFieldsByChannelId :exec
+DELETE FROM embed_fields
+WHERE embed_id IN (
+  SELECT embed_id FROM embeds
+  WHERE message_id IN (SELECT message_id FROM messages WHERE channel_id = ?)
+)
+`
+
+func (q *Queries) DeleteEmbedFieldsByChannelId(ctx context.Context, channelID string) error {
+	_, err := q.db.ExecContext(ctx, deleteEmbedFieldsByChannelId, channelID)
+	return err
+}
+
+const deleteEmbedFieldsByMessageId = `-- name: DeleteEmbedFieldsByMessageId :exec
+DELETE FROM embed_fields
+WHERE embed_id IN (SELECT embed_id FROM embeds WHERE message_id = ?)
+`
+
+func (q *Queries) DeleteEmbedFieldsByMessageId(ctx context.Context, messageID string) error {
+	_, err := q.db.ExecContext(ctx, deleteEmbedFieldsByMessageId, messageID)
+	return err
+}
+
 const getEmbedFieldsByEmbedId = `-- name: GetEmbedFieldsByEmbedId :many
 SELECT field_id, embed_id, name, value, inline FROM embed_fields
 WHERE embed_id = ?
@@ -85,3 +116,49 @@ func (q *Queries) GetEmbedFieldsByEmbedId(ctx context.Context, embedID int64) ([
 	}
 	return items, nil
 }
+
+const listEmbedFieldsByMessageIds = `-- name: ListEmbedFieldsByMessageIds :many
+SELECT embed_fields.field_id, embed_fields.embed_id, embed_fields.name, embed_fields.value, embed_fields.inline FROM embed_fields
+JOIN embeds ON embeds.embed_id = embed_fields.embed_id
+WHERE embeds.message_id IN (/*SLICE:message_ids*/?)
+ORDER BY embed_fields.embed_id, embed_fields.field_id
+`
+
+func (q *Queries) ListEmbedFieldsByMessageIds(ctx context.Context, messageIds []string) ([]EmbedField, error) {
+	query := listEmbedFieldsByMessageIds
+	var queryParams []interface{}
+	if len(messageIds) > 0 {
+		for _, v := range messageIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:message_ids*/?", strings.Repeat(",?", len(messageIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:message_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EmbedField
+	for rows.Next() {
+		var i EmbedField
+		if err := rows.Scan(
+			&i.FieldID,
+			&i.EmbedID,
+			&i.Name,
+			&i.Value,
+			&i.Inline,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
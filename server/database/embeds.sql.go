@@ -8,16 +8,16 @@ package database
 import (
 	"context"
 	"database/sql"
+	"strings"
 )
 
 const createEmbed = `-- name: CreateEmbed :one
-INSERT INTO embeds (embed_id, message_id, title, description, url, color, thumbnail_url, image_url)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO embeds (message_id, title, description, url, color, thumbnail_url, image_url)
+VALUES (?, ?, ?, ?, ?, ?, ?)
 RETURNING embed_id, message_id, title, description, url, color, thumbnail_url, image_url
 `
 
 type CreateEmbedParams struct {
-	EmbedID      int64          `json:"embed_id"`
 	MessageID    string         `json:"message_id"`
 	Title        sql.NullString `json:"title"`
 	Description  sql.NullString `json:"description"`
@@ -29,7 +29,6 @@ type CreateEmbedParams struct {
 
 func (q *Queries) CreateEmbed(ctx context.Context, arg CreateEmbedParams) (Embed, error) {
 	row := q.db.QueryRowContext(ctx, createEmbed,
-		arg.EmbedID,
 		arg.MessageID,
 		arg.Title,
 		arg.Description,
@@ -52,6 +51,15 @@ func (q *Queries) CreateEmbed(ctx context.Context, arg CreateEmbedParams) (Embed
 	return i, err
 }
 
+const deleteAllEmbeds = `-- name: DeleteAllEmbeds :exec
+DELETE FROM embeds
+`
+
+func (q *Queries) DeleteAllEmbeds(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteAllEmbeds)
+	return err
+}
+
 const deleteEmbed = `-- name: DeleteEmbed :exec
 DELETE FROM embeds
 WHERE embed_id = ?
@@ -62,6 +70,26 @@ func (q *Queries) DeleteEmbed(ctx context.Context, embedID int64) error {
 	return err
 }
 
+const deleteEmbedsByChannelId = `-- name: DeleteEmbedsByChannelId :exec
+DELETE FROM embeds
+WHERE message_id IN (SELECT message_id FROM messages WHERE channel_id = ?)
+`
+
+func (q *Queries) DeleteEmbedsByChannelId(ctx context.Context, channelID string) error {
+	_, err := q.db.ExecContext(ctx, deleteEmbedsByChannelId, channelID)
+	return err
+}
+
+const deleteEmbedsByMessageId = `-- name: DeleteEmbedsByMessageId :exec
+DELETE FROM embeds
+WHERE message_id = ?
+`
+
+func (q *Queries) DeleteEmbedsByMessageId(ctx context.Context, messageID string) error {
+	_, err := q.db.ExecContext(ctx, deleteEmbedsByMessageId, messageID)
+	return err
+}
+
 const getEmbed = `-- name: GetEmbed :one
 SELECT embed_id, message_id, title, description, url, color, thumbnail_url, image_url FROM embeds
 WHERE embed_id = ?
@@ -119,3 +147,51 @@ func (q *Queries) GetEmbedsByMessageId(ctx context.Context, messageID string) ([
 	}
 	return items, nil
 }
+
+const listEmbedsByMessageIds = `-- name: ListEmbedsByMessageIds :many
+SELECT embed_id, message_id, title, description, url, color, thumbnail_url, image_url FROM embeds
+WHERE message_id IN (/*SLICE:message_ids*/?)
+ORDER BY message_id, embed_id
+`
+
+func (q *Queries) ListEmbedsByMessageIds(ctx context.Context, messageIds []string) ([]Embed, error) {
+	query := listEmbedsByMessageIds
+	var queryParams []interface{}
+	if len(messageIds) > 0 {
+		for _, v := range messageIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:message_ids*/?", strings.Repeat(",?", len(messageIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:message_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Embed
+	for rows.Next() {
+		var i Embed
+		if err := rows.Scan(
+			&i.EmbedID,
+			&i.MessageID,
+			&i.Title,
+			&i.Description,
+			&i.Url,
+			&i.Color,
+			&i.ThumbnailUrl,
+			&i.ImageUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
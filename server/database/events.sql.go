@@ -11,6 +11,39 @@ import (
 	"strings"
 )
 
+const compactConfigEvents = `-- name: CompactConfigEvents :execrows
+DELETE FROM events
+WHERE scope = ? AND event_type IN ('config.updated', 'config.deleted')
+  AND event_id NOT IN (SELECT event_id FROM event_outbox)
+  AND sequence <= COALESCE((SELECT MIN(acked_sequence) FROM consumer_offsets WHERE consumer_offsets.scope = events.scope), sequence)
+  AND EXISTS (
+    SELECT 1 FROM events AS newer
+    WHERE newer.scope = events.scope
+      AND newer.event_type IN ('config.updated', 'config.deleted')
+      AND json_extract(newer.metadata, '$.config_key') = json_extract(events.metadata, '$.config_key')
+      AND newer.sequence > events.sequence
+  )
+`
+
+func (q *Queries) CompactConfigEvents(ctx context.Context, scope string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, compactConfigEvents, scope)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countEvents = `-- name: CountEvents :one
+SELECT COUNT(*) FROM events
+`
+
+func (q *Queries) CountEvents(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countEvents)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createEvent = `-- name: CreateEvent :one
 INSERT INTO events (event_id, event_type, scope, actor_id, timestamp, payload, metadata, sequence)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
@@ -53,6 +86,48 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event
 	return i, err
 }
 
+const deleteEventsBefore = `-- name: DeleteEventsBefore :execrows
+DELETE FROM events
+WHERE scope = ? AND event_type = ? AND timestamp < ?
+  AND event_id NOT IN (SELECT event_id FROM event_outbox)
+  AND sequence <= COALESCE((SELECT MIN(acked_sequence) FROM consumer_offsets WHERE consumer_offsets.scope = events.scope), sequence)
+`
+
+type DeleteEventsBeforeParams struct {
+	Scope     string `json:"scope"`
+	EventType string `json:"event_type"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+func (q *Queries) DeleteEventsBefore(ctx context.Context, arg DeleteEventsBeforeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteEventsBefore, arg.Scope, arg.EventType, arg.Timestamp)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteEventsUpToSequence = `-- name: DeleteEventsUpToSequence :execrows
+DELETE FROM events
+WHERE scope = ? AND event_type = ? AND sequence <= ?
+  AND event_id NOT IN (SELECT event_id FROM event_outbox)
+  AND sequence <= COALESCE((SELECT MIN(acked_sequence) FROM consumer_offsets WHERE consumer_offsets.scope = events.scope), sequence)
+`
+
+type DeleteEventsUpToSequenceParams struct {
+	Scope     string `json:"scope"`
+	EventType string `json:"event_type"`
+	Sequence  int64  `json:"sequence"`
+}
+
+func (q *Queries) DeleteEventsUpToSequence(ctx context.Context, arg DeleteEventsUpToSequenceParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteEventsUpToSequence, arg.Scope, arg.EventType, arg.Sequence)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getEvent = `-- name: GetEvent :one
 SELECT event_id, event_type, scope, actor_id, timestamp, payload, metadata, sequence FROM events
 WHERE event_id = ?
@@ -77,7 +152,7 @@ func (q *Queries) GetEvent(ctx context.Context, eventID string) (Event, error) {
 const getEvents = `-- name: GetEvents :many
 SELECT event_id, event_type, scope, actor_id, timestamp, payload, metadata, sequence FROM events
 WHERE scope = ?
-  AND (event_type IN (/*SLICE:event_types*/?) OR /*SLICE:event_types*/? IS NULL)
+  AND (CAST(? AS BOOLEAN) OR event_type IN (/*SLICE:event_types*/?))
   AND sequence >= ?
   AND sequence <= ?
 ORDER BY sequence ASC
@@ -85,17 +160,19 @@ LIMIT ?
 `
 
 type GetEventsParams struct {
-	Scope      string   `json:"scope"`
-	EventTypes []string `json:"event_types"`
-	Sequence   int64    `json:"sequence"`
-	Sequence_2 int64    `json:"sequence_2"`
-	Limit      int64    `json:"limit"`
+	Scope         string   `json:"scope"`
+	AllEventTypes bool     `json:"all_event_types"`
+	EventTypes    []string `json:"event_types"`
+	FromSequence  int64    `json:"from_sequence"`
+	ToSequence    int64    `json:"to_sequence"`
+	Limit         int64    `json:"limit"`
 }
 
 func (q *Queries) GetEvents(ctx context.Context, arg GetEventsParams) ([]Event, error) {
 	query := getEvents
 	var queryParams []interface{}
 	queryParams = append(queryParams, arg.Scope)
+	queryParams = append(queryParams, arg.AllEventTypes)
 	if len(arg.EventTypes) > 0 {
 		for _, v := range arg.EventTypes {
 			queryParams = append(queryParams, v)
@@ -104,8 +181,8 @@ func (q *Queries) GetEvents(ctx context.Context, arg GetEventsParams) ([]Event,
 	} else {
 		query = strings.Replace(query, "/*SLICE:event_types*/?", "NULL", 1)
 	}
-	queryParams = append(queryParams, arg.Sequence)
-	queryParams = append(queryParams, arg.Sequence_2)
+	queryParams = append(queryParams, arg.FromSequence)
+	queryParams = append(queryParams, arg.ToSequence)
 	queryParams = append(queryParams, arg.Limit)
 	rows, err := q.db.QueryContext(ctx, query, queryParams...)
 	if err != nil {
@@ -228,6 +305,18 @@ func (q *Queries) GetEventsByTypeAndScope(ctx context.Context, arg GetEventsByTy
 	return items, nil
 }
 
+const getLastEventSequence = `-- name: GetLastEventSequence :one
+SELECT last_sequence FROM event_sequences
+WHERE scope = ?
+`
+
+func (q *Queries) GetLastEventSequence(ctx context.Context, scope string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLastEventSequence, scope)
+	var last_sequence int64
+	err := row.Scan(&last_sequence)
+	return last_sequence, err
+}
+
 const getLatestSequence = `-- name: GetLatestSequence :one
 SELECT COALESCE(MAX(sequence), 0) as max_sequence
 FROM events
@@ -240,3 +329,244 @@ func (q *Queries) GetLatestSequence(ctx context.Context, scope string) (interfac
 	err := row.Scan(&max_sequence)
 	return max_sequence, err
 }
+
+const getNthNewestEventSequence = `-- name: GetNthNewestEventSequence :one
+SELECT sequence FROM events
+WHERE scope = ? AND event_type = ?
+ORDER BY sequence DESC
+LIMIT 1 OFFSET ?
+`
+
+type GetNthNewestEventSequenceParams struct {
+	Scope     string `json:"scope"`
+	EventType string `json:"event_type"`
+	Offset    int64  `json:"offset"`
+}
+
+func (q *Queries) GetNthNewestEventSequence(ctx context.Context, arg GetNthNewestEventSequenceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getNthNewestEventSequence, arg.Scope, arg.EventType, arg.Offset)
+	var sequence int64
+	err := row.Scan(&sequence)
+	return sequence, err
+}
+
+const listEventScopes = `-- name: ListEventScopes :many
+SELECT DISTINCT scope FROM events
+ORDER BY scope
+`
+
+func (q *Queries) ListEventScopes(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listEventScopes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var scope string
+		if err := rows.Scan(&scope); err != nil {
+			return nil, err
+		}
+		items = append(items, scope)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventsForReplay = `-- name: ListEventsForReplay :many
+SELECT events.rowid, events.event_id, events.event_type, events.scope, events.actor_id, events.timestamp, events.payload, events.metadata, events.sequence
+FROM events
+WHERE events.rowid > ?
+ORDER BY events.rowid ASC
+LIMIT ?
+`
+
+type ListEventsForReplayParams struct {
+	Rowid int64 `json:"rowid"`
+	Limit int64 `json:"limit"`
+}
+
+type ListEventsForReplayRow struct {
+	Rowid int64 `json:"rowid"`
+	Event Event `json:"event"`
+}
+
+func (q *Queries) ListEventsForReplay(ctx context.Context, arg ListEventsForReplayParams) ([]ListEventsForReplayRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEventsForReplay, arg.Rowid, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEventsForReplayRow
+	for rows.Next() {
+		var i ListEventsForReplayRow
+		if err := rows.Scan(
+			&i.Rowid,
+			&i.Event.EventID,
+			&i.Event.EventType,
+			&i.Event.Scope,
+			&i.Event.ActorID,
+			&i.Event.Timestamp,
+			&i.Event.Payload,
+			&i.Event.Metadata,
+			&i.Event.Sequence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventTypesByScope = `-- name: ListEventTypesByScope :many
+SELECT DISTINCT event_type FROM events
+WHERE scope = ?
+ORDER BY event_type
+`
+
+func (q *Queries) ListEventTypesByScope(ctx context.Context, scope string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listEventTypesByScope, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var event_type string
+		if err := rows.Scan(&event_type); err != nil {
+			return nil, err
+		}
+		items = append(items, event_type)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMessageEvents = `-- name: ListMessageEvents :many
+SELECT event_id, event_type, scope, actor_id, timestamp, payload, metadata, sequence FROM events
+WHERE scope = ?
+  AND json_extract(metadata, '$.message_id') = CAST(? AS TEXT)
+ORDER BY sequence
+`
+
+type ListMessageEventsParams struct {
+	Scope     string `json:"scope"`
+	MessageID string `json:"message_id"`
+}
+
+func (q *Queries) ListMessageEvents(ctx context.Context, arg ListMessageEventsParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listMessageEvents, arg.Scope, arg.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.EventID,
+			&i.EventType,
+			&i.Scope,
+			&i.ActorID,
+			&i.Timestamp,
+			&i.Payload,
+			&i.Metadata,
+			&i.Sequence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScopeMessageEvents = `-- name: ListScopeMessageEvents :many
+SELECT event_id, event_type, scope, actor_id, timestamp, payload, metadata, sequence FROM events
+WHERE scope = ?
+  AND event_type IN ('message.sent', 'message.updated')
+ORDER BY sequence
+`
+
+func (q *Queries) ListScopeMessageEvents(ctx context.Context, scope string) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listScopeMessageEvents, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.EventID,
+			&i.EventType,
+			&i.Scope,
+			&i.ActorID,
+			&i.Timestamp,
+			&i.Payload,
+			&i.Metadata,
+			&i.Sequence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const nextEventSequence = `-- name: NextEventSequence :one
+INSERT INTO event_sequences (scope, last_sequence)
+VALUES (?, 1)
+ON CONFLICT (scope) DO UPDATE SET last_sequence = last_sequence + 1
+RETURNING last_sequence
+`
+
+func (q *Queries) NextEventSequence(ctx context.Context, scope string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, nextEventSequence, scope)
+	var last_sequence int64
+	err := row.Scan(&last_sequence)
+	return last_sequence, err
+}
+
+const updateEventPayload = `-- name: UpdateEventPayload :exec
+UPDATE events
+SET payload = ?
+WHERE event_id = ?
+`
+
+type UpdateEventPayloadParams struct {
+	Payload sql.NullString `json:"payload"`
+	EventID string         `json:"event_id"`
+}
+
+func (q *Queries) UpdateEventPayload(ctx context.Context, arg UpdateEventPayloadParams) error {
+	_, err := q.db.ExecContext(ctx, updateEventPayload, arg.Payload, arg.EventID)
+	return err
+}
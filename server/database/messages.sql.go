@@ -8,12 +8,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"strings"
 )
 
 const createMessage = `-- name: CreateMessage :one
 INSERT INTO messages (message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id)
 VALUES (?, ?, ?, ?, ?, ?, ?)
-RETURNING message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id
+RETURNING message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at
 `
 
 type CreateMessageParams struct {
@@ -45,10 +46,22 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ReplyToID,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.PurgedAt,
 	)
 	return i, err
 }
 
+const deleteAllMessages = `-- name: DeleteAllMessages :exec
+DELETE FROM messages
+`
+
+func (q *Queries) DeleteAllMessages(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteAllMessages)
+	return err
+}
+
 const deleteMessage = `-- name: DeleteMessage :exec
 DELETE FROM messages
 WHERE message_id = ?
@@ -59,9 +72,22 @@ func (q *Queries) DeleteMessage(ctx context.Context, messageID string) error {
 	return err
 }
 
+const deleteMessagesByChannelId = `-- name: DeleteMessagesByChannelId :execrows
+DELETE FROM messages
+WHERE channel_id = ?
+`
+
+func (q *Queries) DeleteMessagesByChannelId(ctx context.Context, channelID string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteMessagesByChannelId, channelID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getMessage = `-- name: GetMessage :one
-SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id FROM messages
-WHERE message_id = ?
+SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at FROM messages
+WHERE message_id = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) GetMessage(ctx context.Context, messageID string) (Message, error) {
@@ -75,35 +101,190 @@ func (q *Queries) GetMessage(ctx context.Context, messageID string) (Message, er
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ReplyToID,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.PurgedAt,
 	)
 	return i, err
 }
 
-const getMessages = `-- name: GetMessages :many
-SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id FROM messages
-WHERE channel_id = ?
-  AND (created_at < ? OR ? = 0)
-  AND (created_at > ? OR ? = 0)
+const getMessageIncludingDeleted = `-- name: GetMessageIncludingDeleted :one
+SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at FROM messages
+WHERE message_id = ?
+`
+
+func (q *Queries) GetMessageIncludingDeleted(ctx context.Context, messageID string) (Message, error) {
+	row := q.db.QueryRowContext(ctx, getMessageIncludingDeleted, messageID)
+	var i Message
+	err := row.Scan(
+		&i.MessageID,
+		&i.ChannelID,
+		&i.AuthorID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReplyToID,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.PurgedAt,
+	)
+	return i, err
+}
+
+const getMessagesByChannelId = `-- name: GetMessagesByChannelId :many
+SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at FROM messages
+WHERE channel_id = ? AND deleted_at IS NULL
 ORDER BY created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type GetMessagesByChannelIdParams struct {
+	ChannelID string `json:"channel_id"`
+	Limit     int64  `json:"limit"`
+	Offset    int64  `json:"offset"`
+}
+
+func (q *Queries) GetMessagesByChannelId(ctx context.Context, arg GetMessagesByChannelIdParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, getMessagesByChannelId, arg.ChannelID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Message
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.ChannelID,
+			&i.AuthorID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReplyToID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.PurgedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllMessages = `-- name: ListAllMessages :many
+SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at FROM messages
+ORDER BY message_id
+`
+
+func (q *Queries) ListAllMessages(ctx context.Context) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, listAllMessages)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Message
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.ChannelID,
+			&i.AuthorID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReplyToID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.PurgedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLatestMessages = `-- name: ListLatestMessages :many
+SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at FROM messages
+WHERE channel_id = ? AND deleted_at IS NULL
+ORDER BY created_at DESC, message_id DESC
+LIMIT ?
+`
+
+type ListLatestMessagesParams struct {
+	ChannelID string `json:"channel_id"`
+	Limit     int64  `json:"limit"`
+}
+
+func (q *Queries) ListLatestMessages(ctx context.Context, arg ListLatestMessagesParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, listLatestMessages, arg.ChannelID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Message
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.ChannelID,
+			&i.AuthorID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReplyToID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.PurgedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMessagesAfter = `-- name: ListMessagesAfter :many
+SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at FROM messages
+WHERE channel_id = ? AND deleted_at IS NULL
+  AND (created_at > ? OR (created_at = ? AND message_id > ?))
+ORDER BY created_at ASC, message_id ASC
 LIMIT ?
 `
 
-type GetMessagesParams struct {
-	ChannelID   string      `json:"channel_id"`
-	CreatedAt   int64       `json:"created_at"`
-	Column3     interface{} `json:"column_3"`
-	CreatedAt_2 int64       `json:"created_at_2"`
-	Column5     interface{} `json:"column_5"`
-	Limit       int64       `json:"limit"`
+type ListMessagesAfterParams struct {
+	ChannelID   string `json:"channel_id"`
+	CreatedAt   int64  `json:"created_at"`
+	CreatedAt_2 int64  `json:"created_at_2"`
+	MessageID   string `json:"message_id"`
+	Limit       int64  `json:"limit"`
 }
 
-func (q *Queries) GetMessages(ctx context.Context, arg GetMessagesParams) ([]Message, error) {
-	rows, err := q.db.QueryContext(ctx, getMessages,
+func (q *Queries) ListMessagesAfter(ctx context.Context, arg ListMessagesAfterParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, listMessagesAfter,
 		arg.ChannelID,
 		arg.CreatedAt,
-		arg.Column3,
 		arg.CreatedAt_2,
-		arg.Column5,
+		arg.MessageID,
 		arg.Limit,
 	)
 	if err != nil {
@@ -121,6 +302,9 @@ func (q *Queries) GetMessages(ctx context.Context, arg GetMessagesParams) ([]Mes
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ReplyToID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.PurgedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -135,21 +319,76 @@ func (q *Queries) GetMessages(ctx context.Context, arg GetMessagesParams) ([]Mes
 	return items, nil
 }
 
-const getMessagesByChannelId = `-- name: GetMessagesByChannelId :many
-SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id FROM messages
-WHERE channel_id = ?
-ORDER BY created_at DESC
-LIMIT ? OFFSET ?
+const listMessagesBefore = `-- name: ListMessagesBefore :many
+SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at FROM messages
+WHERE channel_id = ? AND deleted_at IS NULL
+  AND (created_at < ? OR (created_at = ? AND message_id < ?))
+ORDER BY created_at DESC, message_id DESC
+LIMIT ?
 `
 
-type GetMessagesByChannelIdParams struct {
-	ChannelID string `json:"channel_id"`
-	Limit     int64  `json:"limit"`
-	Offset    int64  `json:"offset"`
+type ListMessagesBeforeParams struct {
+	ChannelID   string `json:"channel_id"`
+	CreatedAt   int64  `json:"created_at"`
+	CreatedAt_2 int64  `json:"created_at_2"`
+	MessageID   string `json:"message_id"`
+	Limit       int64  `json:"limit"`
 }
 
-func (q *Queries) GetMessagesByChannelId(ctx context.Context, arg GetMessagesByChannelIdParams) ([]Message, error) {
-	rows, err := q.db.QueryContext(ctx, getMessagesByChannelId, arg.ChannelID, arg.Limit, arg.Offset)
+func (q *Queries) ListMessagesBefore(ctx context.Context, arg ListMessagesBeforeParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, listMessagesBefore,
+		arg.ChannelID,
+		arg.CreatedAt,
+		arg.CreatedAt_2,
+		arg.MessageID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Message
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.ChannelID,
+			&i.AuthorID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReplyToID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.PurgedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableMessages = `-- name: ListPurgeableMessages :many
+SELECT message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at FROM messages
+WHERE deleted_at <= ? AND purged_at IS NULL
+ORDER BY deleted_at, message_id
+LIMIT ?
+`
+
+type ListPurgeableMessagesParams struct {
+	DeletedAt sql.NullInt64 `json:"deleted_at"`
+	Limit     int64         `json:"limit"`
+}
+
+func (q *Queries) ListPurgeableMessages(ctx context.Context, arg ListPurgeableMessagesParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, listPurgeableMessages, arg.DeletedAt, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +404,121 @@ func (q *Queries) GetMessagesByChannelId(ctx context.Context, arg GetMessagesByC
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ReplyToID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.PurgedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeMessage = `-- name: PurgeMessage :exec
+UPDATE messages
+SET content = '', purged_at = ?
+WHERE message_id = ?
+`
+
+type PurgeMessageParams struct {
+	PurgedAt  sql.NullInt64 `json:"purged_at"`
+	MessageID string        `json:"message_id"`
+}
+
+func (q *Queries) PurgeMessage(ctx context.Context, arg PurgeMessageParams) error {
+	_, err := q.db.ExecContext(ctx, purgeMessage, arg.PurgedAt, arg.MessageID)
+	return err
+}
+
+const searchMessages = `-- name: SearchMessages :many
+SELECT messages.message_id, messages.channel_id, messages.author_id, messages.content, messages.created_at, messages.updated_at, messages.reply_to_id, messages.deleted_at, messages.deleted_by, messages.purged_at,
+  CAST(snippet(messages_fts, 0, ?, ?, '…', 16) AS TEXT) AS snippet,
+  CAST(bm25(messages_fts) AS REAL) AS rank
+FROM messages_fts
+JOIN messages ON messages.rowid = messages_fts.rowid
+WHERE messages_fts MATCH ?
+  AND messages.channel_id IN (/*SLICE:channel_ids*/?)
+  AND messages.deleted_at IS NULL
+  AND (CAST(? AS BOOLEAN) OR messages.author_id = ?)
+  AND messages.created_at >= ?
+  AND messages.created_at < ?
+  AND (NOT CAST(? AS BOOLEAN) OR EXISTS (SELECT 1 FROM attachments WHERE attachments.message_id = messages.message_id))
+  AND (NOT CAST(? AS BOOLEAN) OR EXISTS (SELECT 1 FROM embeds WHERE embeds.message_id = messages.message_id))
+ORDER BY rank, messages.message_id
+LIMIT ? OFFSET ?
+`
+
+type SearchMessagesParams struct {
+	HighlightStart interface{} `json:"highlight_start"`
+	HighlightEnd   interface{} `json:"highlight_end"`
+	Query          string      `json:"query"`
+	ChannelIds     []string    `json:"channel_ids"`
+	AnyAuthor      bool        `json:"any_author"`
+	AuthorID       string      `json:"author_id"`
+	CreatedAfter   int64       `json:"created_after"`
+	CreatedBefore  int64       `json:"created_before"`
+	HasAttachment  bool        `json:"has_attachment"`
+	HasEmbed       bool        `json:"has_embed"`
+	Limit          int64       `json:"limit"`
+	Offset         int64       `json:"offset"`
+}
+
+type SearchMessagesRow struct {
+	Message Message `json:"message"`
+	Snippet string  `json:"snippet"`
+	Rank    float64 `json:"rank"`
+}
+
+func (q *Queries) SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]SearchMessagesRow, error) {
+	query := searchMessages
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.HighlightStart)
+	queryParams = append(queryParams, arg.HighlightEnd)
+	queryParams = append(queryParams, arg.Query)
+	if len(arg.ChannelIds) > 0 {
+		for _, v := range arg.ChannelIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:channel_ids*/?", strings.Repeat(",?", len(arg.ChannelIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:channel_ids*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.AnyAuthor)
+	queryParams = append(queryParams, arg.AuthorID)
+	queryParams = append(queryParams, arg.CreatedAfter)
+	queryParams = append(queryParams, arg.CreatedBefore)
+	queryParams = append(queryParams, arg.HasAttachment)
+	queryParams = append(queryParams, arg.HasEmbed)
+	queryParams = append(queryParams, arg.Limit)
+	queryParams = append(queryParams, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchMessagesRow
+	for rows.Next() {
+		var i SearchMessagesRow
+		if err := rows.Scan(
+			&i.Message.MessageID,
+			&i.Message.ChannelID,
+			&i.Message.AuthorID,
+			&i.Message.Content,
+			&i.Message.CreatedAt,
+			&i.Message.UpdatedAt,
+			&i.Message.ReplyToID,
+			&i.Message.DeletedAt,
+			&i.Message.DeletedBy,
+			&i.Message.PurgedAt,
+			&i.Snippet,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -179,11 +533,31 @@ func (q *Queries) GetMessagesByChannelId(ctx context.Context, arg GetMessagesByC
 	return items, nil
 }
 
+const tombstoneMessage = `-- name: TombstoneMessage :execrows
+UPDATE messages
+SET deleted_at = ?, deleted_by = ?
+WHERE message_id = ? AND deleted_at IS NULL
+`
+
+type TombstoneMessageParams struct {
+	DeletedAt sql.NullInt64  `json:"deleted_at"`
+	DeletedBy sql.NullString `json:"deleted_by"`
+	MessageID string         `json:"message_id"`
+}
+
+func (q *Queries) TombstoneMessage(ctx context.Context, arg TombstoneMessageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, tombstoneMessage, arg.DeletedAt, arg.DeletedBy, arg.MessageID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateMessage = `-- name: UpdateMessage :one
 UPDATE messages
 SET content = ?, updated_at = ?
 WHERE message_id = ?
-RETURNING message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id
+RETURNING message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at
 `
 
 type UpdateMessageParams struct {
@@ -203,6 +577,9 @@ func (q *Queries) UpdateMessage(ctx context.Context, arg UpdateMessageParams) (M
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ReplyToID,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.PurgedAt,
 	)
 	return i, err
 }
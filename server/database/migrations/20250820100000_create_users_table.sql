@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE users (
+  user_id TEXT NOT NULL PRIMARY KEY,
+  username TEXT NOT NULL COLLATE NOCASE,
+  display_name TEXT,
+  password_hash TEXT NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+
+CREATE UNIQUE INDEX idx_users_username ON users(username);
+
+-- +goose Down
+DROP TABLE users;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE sessions (
+  session_id TEXT NOT NULL PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  refresh_token_hash TEXT NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  expires_at INTEGER NOT NULL,
+  revoked_at INTEGER,
+  replaced_by TEXT -- session_id issued when this refresh token was rotated
+);
+
+CREATE UNIQUE INDEX idx_sessions_refresh_token_hash ON sessions(refresh_token_hash);
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
+
+-- +goose Down
+DROP TABLE sessions;
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE roles (
+  role_id TEXT NOT NULL PRIMARY KEY,
+  server_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  permissions INTEGER NOT NULL DEFAULT 0, -- Bitmask of Permission values
+  position INTEGER NOT NULL DEFAULT 0,
+  is_default INTEGER NOT NULL DEFAULT 0, -- Boolean as INTEGER (0/1), the @everyone role
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+
+CREATE INDEX idx_roles_server_id ON roles(server_id);
+
+CREATE TABLE role_members (
+  role_id TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  server_id TEXT NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  PRIMARY KEY (role_id, user_id)
+);
+
+CREATE INDEX idx_role_members_server_user ON role_members(server_id, user_id);
+
+-- +goose Down
+DROP TABLE role_members;
+DROP TABLE roles;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE permission_overwrites (
+  channel_id TEXT NOT NULL,
+  target_type INTEGER NOT NULL, -- OverwriteTargetType enum as INTEGER
+  target_id TEXT NOT NULL,
+  allow INTEGER NOT NULL DEFAULT 0,
+  deny INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (channel_id, target_type, target_id)
+);
+
+-- +goose Down
+DROP TABLE permission_overwrites;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Lives in the primary database and maps channels and messages to the
+-- per-server database file that stores them
+CREATE TABLE database_routes (
+  resource_id TEXT NOT NULL PRIMARY KEY,
+  server_id TEXT NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+
+CREATE INDEX idx_database_routes_server_id ON database_routes(server_id);
+
+-- +goose Down
+DROP TABLE database_routes;
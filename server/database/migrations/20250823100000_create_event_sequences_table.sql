@@ -0,0 +1,32 @@
+-- +goose Up
+-- Per-scope counter so sequence numbers are allocated atomically with the event insert
+CREATE TABLE event_sequences (
+  scope TEXT NOT NULL PRIMARY KEY,
+  last_sequence INTEGER NOT NULL
+);
+
+-- Concurrent publishes could previously share a sequence; renumber affected scopes
+CREATE TEMP TABLE event_renumbering AS
+SELECT event_id, ROW_NUMBER() OVER (PARTITION BY scope ORDER BY sequence, timestamp, rowid) AS sequence
+FROM events
+WHERE scope IN (
+  SELECT scope FROM events GROUP BY scope, sequence HAVING COUNT(*) > 1
+);
+
+UPDATE events
+SET sequence = (SELECT r.sequence FROM event_renumbering r WHERE r.event_id = events.event_id)
+WHERE event_id IN (SELECT event_id FROM event_renumbering);
+
+DROP TABLE event_renumbering;
+
+INSERT INTO event_sequences (scope, last_sequence)
+SELECT scope, MAX(sequence) FROM events GROUP BY scope;
+
+DROP INDEX idx_events_scope_sequence;
+CREATE UNIQUE INDEX idx_events_scope_sequence ON events(scope, sequence);
+
+-- +goose Down
+DROP INDEX idx_events_scope_sequence;
+CREATE INDEX idx_events_scope_sequence ON events(scope, sequence);
+
+DROP TABLE event_sequences;
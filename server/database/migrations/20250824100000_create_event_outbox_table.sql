@@ -0,0 +1,10 @@
+-- +goose Up
+-- Events committed but not yet fanned out to live subscribers, in commit order
+CREATE TABLE event_outbox (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  event_id TEXT NOT NULL,
+  created_at INTEGER NOT NULL
+);
+
+-- +goose Down
+DROP TABLE event_outbox;
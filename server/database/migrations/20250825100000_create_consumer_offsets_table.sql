@@ -0,0 +1,13 @@
+-- +goose Up
+-- Position of each durable consumer group: every event of the scope up to
+-- acked_sequence has been acknowledged
+CREATE TABLE consumer_offsets (
+  group_name TEXT NOT NULL,
+  scope TEXT NOT NULL,
+  acked_sequence INTEGER NOT NULL DEFAULT 0,
+  updated_at INTEGER NOT NULL,
+  PRIMARY KEY (group_name, scope)
+);
+
+-- +goose Down
+DROP TABLE consumer_offsets;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Latest state snapshot per scope, so clients can bootstrap from it and
+-- replay only the events after sequence
+CREATE TABLE event_snapshots (
+  scope TEXT NOT NULL PRIMARY KEY,
+  sequence INTEGER NOT NULL,
+  state TEXT NOT NULL, -- ScopeSnapshot as protojson
+  created_at INTEGER NOT NULL
+);
+
+-- +goose Down
+DROP TABLE event_snapshots;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Channel history pages are ordered by created_at and then message_id
+CREATE INDEX idx_messages_channel_history ON messages(channel_id, created_at, message_id);
+
+-- +goose Down
+DROP INDEX idx_messages_channel_history;
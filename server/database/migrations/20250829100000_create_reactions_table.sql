@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE reactions (
+  message_id TEXT NOT NULL,
+  emoji TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  PRIMARY KEY (message_id, emoji, user_id)
+);
+
+-- +goose Down
+DROP TABLE reactions;
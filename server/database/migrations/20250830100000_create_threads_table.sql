@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE threads (
+  channel_id TEXT NOT NULL PRIMARY KEY,
+  parent_id TEXT NOT NULL,
+  message_id TEXT NOT NULL,
+  owner_id TEXT NOT NULL,
+  archived INTEGER NOT NULL DEFAULT 0, -- Boolean as INTEGER (0/1)
+  locked INTEGER NOT NULL DEFAULT 0, -- Boolean as INTEGER (0/1)
+  auto_archive_duration INTEGER NOT NULL, -- Minutes without activity until the thread counts as archived
+  message_count INTEGER NOT NULL DEFAULT 0,
+  last_activity_at INTEGER NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+
+CREATE UNIQUE INDEX idx_threads_message_id ON threads(message_id);
+CREATE INDEX idx_threads_parent_activity ON threads(parent_id, last_activity_at);
+
+CREATE TABLE thread_members (
+  channel_id TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  joined_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  PRIMARY KEY (channel_id, user_id)
+);
+
+-- +goose Down
+DROP TABLE thread_members;
+DROP TABLE threads;
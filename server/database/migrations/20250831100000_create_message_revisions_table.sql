@@ -0,0 +1,30 @@
+-- +goose Up
+-- Deleted messages are kept as tombstones until the purge job erases their
+-- content, which sets purged_at
+ALTER TABLE messages ADD COLUMN deleted_at INTEGER;
+ALTER TABLE messages ADD COLUMN deleted_by TEXT;
+ALTER TABLE messages ADD COLUMN purged_at INTEGER;
+
+CREATE INDEX idx_messages_deleted_at ON messages(deleted_at);
+
+-- Every version of a message replaced by an edit. embeds holds the version's
+-- embeds as a JSON array, created_at when the version was written and
+-- edited_at when editor_id replaced it
+CREATE TABLE message_revisions (
+  revision_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  message_id TEXT NOT NULL,
+  content TEXT NOT NULL,
+  embeds TEXT NOT NULL DEFAULT '[]',
+  editor_id TEXT NOT NULL,
+  created_at INTEGER NOT NULL,
+  edited_at INTEGER NOT NULL
+);
+
+CREATE INDEX idx_message_revisions_message_id ON message_revisions(message_id, revision_id);
+
+-- +goose Down
+DROP TABLE message_revisions;
+DROP INDEX idx_messages_deleted_at;
+ALTER TABLE messages DROP COLUMN purged_at;
+ALTER TABLE messages DROP COLUMN deleted_by;
+ALTER TABLE messages DROP COLUMN deleted_at;
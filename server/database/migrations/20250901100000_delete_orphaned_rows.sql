@@ -0,0 +1,23 @@
+-- +goose Up
+-- Deleting a channel used to leave its messages, threads and subchannels
+-- behind, and the children of messages were never checked against them.
+-- Remove the rows whose parent is gone so the foreign keys added next hold
+-- for existing data. Threads (type 4) of deleted channels go, subchannels
+-- move to the top level
+DELETE FROM channels
+WHERE type = 4 AND (parent_id IS NULL OR parent_id NOT IN (SELECT channel_id FROM channels));
+UPDATE channels SET parent_id = NULL
+WHERE parent_id = '' OR parent_id NOT IN (SELECT channel_id FROM channels);
+DELETE FROM messages WHERE channel_id NOT IN (SELECT channel_id FROM channels);
+DELETE FROM attachments WHERE message_id NOT IN (SELECT message_id FROM messages);
+DELETE FROM embeds WHERE message_id NOT IN (SELECT message_id FROM messages);
+DELETE FROM embed_fields WHERE embed_id NOT IN (SELECT embed_id FROM embeds);
+DELETE FROM reactions WHERE message_id NOT IN (SELECT message_id FROM messages);
+DELETE FROM message_revisions WHERE message_id NOT IN (SELECT message_id FROM messages);
+DELETE FROM threads
+WHERE channel_id NOT IN (SELECT channel_id FROM channels) OR parent_id NOT IN (SELECT channel_id FROM channels);
+DELETE FROM thread_members WHERE channel_id NOT IN (SELECT channel_id FROM threads);
+
+-- +goose Down
+-- The removed rows can't be restored; take them from the backup made before
+-- the migration if they are needed
@@ -0,0 +1,352 @@
+-- +goose Up
+-- Give the tables of messages and their children foreign keys that delete
+-- them along with their parent, and keep channels from being deleted while
+-- subchannels or threads point at them. SQLite can't add foreign keys to a
+-- table, so each is rebuilt with them, parents first; migrations run with
+-- foreign key enforcement off. Tables without an INTEGER PRIMARY KEY keep
+-- their rowids, which messages_fts and reaction cursors rely on
+CREATE TABLE channels_new (
+  channel_id TEXT NOT NULL PRIMARY KEY,
+  name TEXT NOT NULL,
+  type INTEGER NOT NULL,
+  server_id TEXT,
+  parent_id TEXT REFERENCES channels(channel_id) ON DELETE RESTRICT,
+  metadata TEXT, -- JSON as TEXT
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+INSERT INTO channels_new (rowid, channel_id, name, type, server_id, parent_id, metadata, created_at, updated_at)
+SELECT rowid, channel_id, name, type, server_id, parent_id, metadata, created_at, updated_at FROM channels;
+DROP TABLE channels;
+ALTER TABLE channels_new RENAME TO channels;
+
+CREATE INDEX idx_channels_server_id ON channels(server_id);
+CREATE INDEX idx_channels_parent_id ON channels(parent_id);
+
+CREATE TABLE messages_new (
+  message_id TEXT NOT NULL PRIMARY KEY,
+  channel_id TEXT NOT NULL REFERENCES channels(channel_id) ON DELETE CASCADE,
+  author_id TEXT NOT NULL,
+  content TEXT NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  reply_to_id TEXT,
+  deleted_at INTEGER,
+  deleted_by TEXT,
+  purged_at INTEGER
+);
+INSERT INTO messages_new (rowid, message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at)
+SELECT rowid, message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at FROM messages;
+DROP TABLE messages;
+ALTER TABLE messages_new RENAME TO messages;
+
+CREATE INDEX idx_messages_channel_id ON messages(channel_id);
+CREATE INDEX idx_messages_author_id ON messages(author_id);
+CREATE INDEX idx_messages_created_at ON messages(created_at);
+CREATE INDEX idx_messages_reply_to_id ON messages(reply_to_id);
+CREATE INDEX idx_messages_channel_history ON messages(channel_id, created_at, message_id);
+CREATE INDEX idx_messages_deleted_at ON messages(deleted_at);
+
+-- Dropping the old table dropped the triggers that keep messages_fts in
+-- sync; rowids are copied, so the index itself still matches
+-- +goose StatementBegin
+CREATE TRIGGER messages_fts_insert AFTER INSERT ON messages BEGIN
+  INSERT INTO messages_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER messages_fts_delete AFTER DELETE ON messages BEGIN
+  INSERT INTO messages_fts (messages_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER messages_fts_update AFTER UPDATE OF content ON messages BEGIN
+  INSERT INTO messages_fts (messages_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+  INSERT INTO messages_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+-- +goose StatementEnd
+
+CREATE TABLE attachments_new (
+  attachment_id TEXT NOT NULL PRIMARY KEY,
+  message_id TEXT NOT NULL REFERENCES messages(message_id) ON DELETE CASCADE,
+  channel_id TEXT NOT NULL,
+  author_id TEXT NOT NULL,
+  filename TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size INTEGER NOT NULL,
+  url TEXT NOT NULL
+);
+INSERT INTO attachments_new (attachment_id, message_id, channel_id, author_id, filename, content_type, size, url)
+SELECT attachment_id, message_id, channel_id, author_id, filename, content_type, size, url FROM attachments;
+DROP TABLE attachments;
+ALTER TABLE attachments_new RENAME TO attachments;
+
+CREATE INDEX idx_attachments_message_id ON attachments(message_id);
+CREATE INDEX idx_attachments_channel_id ON attachments(channel_id);
+CREATE INDEX idx_attachments_author_id ON attachments(author_id);
+
+CREATE TABLE embeds_new (
+  embed_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  message_id TEXT NOT NULL REFERENCES messages(message_id) ON DELETE CASCADE,
+  title TEXT,
+  description TEXT,
+  url TEXT,
+  color INTEGER,
+  thumbnail_url TEXT,
+  image_url TEXT
+);
+INSERT INTO embeds_new (embed_id, message_id, title, description, url, color, thumbnail_url, image_url)
+SELECT embed_id, message_id, title, description, url, color, thumbnail_url, image_url FROM embeds;
+DROP TABLE embeds;
+ALTER TABLE embeds_new RENAME TO embeds;
+
+CREATE INDEX idx_embeds_message_id ON embeds(message_id);
+
+CREATE TABLE embed_fields_new (
+  field_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  embed_id INTEGER NOT NULL REFERENCES embeds(embed_id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  value TEXT NOT NULL,
+  inline INTEGER NOT NULL DEFAULT 0
+);
+INSERT INTO embed_fields_new (field_id, embed_id, name, value, inline)
+SELECT field_id, embed_id, name, value, inline FROM embed_fields;
+DROP TABLE embed_fields;
+ALTER TABLE embed_fields_new RENAME TO embed_fields;
+
+CREATE INDEX idx_embed_fields_embed_id ON embed_fields(embed_id);
+
+CREATE TABLE reactions_new (
+  message_id TEXT NOT NULL REFERENCES messages(message_id) ON DELETE CASCADE,
+  emoji TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  PRIMARY KEY (message_id, emoji, user_id)
+);
+INSERT INTO reactions_new (rowid, message_id, emoji, user_id, created_at)
+SELECT rowid, message_id, emoji, user_id, created_at FROM reactions;
+DROP TABLE reactions;
+ALTER TABLE reactions_new RENAME TO reactions;
+
+CREATE TABLE message_revisions_new (
+  revision_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  message_id TEXT NOT NULL REFERENCES messages(message_id) ON DELETE CASCADE,
+  content TEXT NOT NULL,
+  embeds TEXT NOT NULL DEFAULT '[]',
+  editor_id TEXT NOT NULL,
+  created_at INTEGER NOT NULL,
+  edited_at INTEGER NOT NULL
+);
+INSERT INTO message_revisions_new (revision_id, message_id, content, embeds, editor_id, created_at, edited_at)
+SELECT revision_id, message_id, content, embeds, editor_id, created_at, edited_at FROM message_revisions;
+DROP TABLE message_revisions;
+ALTER TABLE message_revisions_new RENAME TO message_revisions;
+
+CREATE INDEX idx_message_revisions_message_id ON message_revisions(message_id, revision_id);
+
+CREATE TABLE threads_new (
+  channel_id TEXT NOT NULL PRIMARY KEY REFERENCES channels(channel_id) ON DELETE CASCADE,
+  parent_id TEXT NOT NULL REFERENCES channels(channel_id) ON DELETE RESTRICT,
+  message_id TEXT NOT NULL,
+  owner_id TEXT NOT NULL,
+  archived INTEGER NOT NULL DEFAULT 0, -- Boolean as INTEGER (0/1)
+  locked INTEGER NOT NULL DEFAULT 0, -- Boolean as INTEGER (0/1)
+  auto_archive_duration INTEGER NOT NULL, -- Minutes without activity until the thread counts as archived
+  message_count INTEGER NOT NULL DEFAULT 0,
+  last_activity_at INTEGER NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+INSERT INTO threads_new (channel_id, parent_id, message_id, owner_id, archived, locked, auto_archive_duration, message_count, last_activity_at, created_at)
+SELECT channel_id, parent_id, message_id, owner_id, archived, locked, auto_archive_duration, message_count, last_activity_at, created_at FROM threads;
+DROP TABLE threads;
+ALTER TABLE threads_new RENAME TO threads;
+
+CREATE UNIQUE INDEX idx_threads_message_id ON threads(message_id);
+CREATE INDEX idx_threads_parent_activity ON threads(parent_id, last_activity_at);
+
+CREATE TABLE thread_members_new (
+  channel_id TEXT NOT NULL REFERENCES threads(channel_id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL,
+  joined_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  PRIMARY KEY (channel_id, user_id)
+);
+INSERT INTO thread_members_new (rowid, channel_id, user_id, joined_at)
+SELECT rowid, channel_id, user_id, joined_at FROM thread_members;
+DROP TABLE thread_members;
+ALTER TABLE thread_members_new RENAME TO thread_members;
+
+-- +goose Down
+-- Rebuild the tables without foreign keys, children first
+CREATE TABLE thread_members_new (
+  channel_id TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  joined_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  PRIMARY KEY (channel_id, user_id)
+);
+INSERT INTO thread_members_new (rowid, channel_id, user_id, joined_at)
+SELECT rowid, channel_id, user_id, joined_at FROM thread_members;
+DROP TABLE thread_members;
+ALTER TABLE thread_members_new RENAME TO thread_members;
+
+CREATE TABLE threads_new (
+  channel_id TEXT NOT NULL PRIMARY KEY,
+  parent_id TEXT NOT NULL,
+  message_id TEXT NOT NULL,
+  owner_id TEXT NOT NULL,
+  archived INTEGER NOT NULL DEFAULT 0, -- Boolean as INTEGER (0/1)
+  locked INTEGER NOT NULL DEFAULT 0, -- Boolean as INTEGER (0/1)
+  auto_archive_duration INTEGER NOT NULL, -- Minutes without activity until the thread counts as archived
+  message_count INTEGER NOT NULL DEFAULT 0,
+  last_activity_at INTEGER NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+INSERT INTO threads_new (channel_id, parent_id, message_id, owner_id, archived, locked, auto_archive_duration, message_count, last_activity_at, created_at)
+SELECT channel_id, parent_id, message_id, owner_id, archived, locked, auto_archive_duration, message_count, last_activity_at, created_at FROM threads;
+DROP TABLE threads;
+ALTER TABLE threads_new RENAME TO threads;
+
+CREATE UNIQUE INDEX idx_threads_message_id ON threads(message_id);
+CREATE INDEX idx_threads_parent_activity ON threads(parent_id, last_activity_at);
+
+CREATE TABLE message_revisions_new (
+  revision_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  message_id TEXT NOT NULL,
+  content TEXT NOT NULL,
+  embeds TEXT NOT NULL DEFAULT '[]',
+  editor_id TEXT NOT NULL,
+  created_at INTEGER NOT NULL,
+  edited_at INTEGER NOT NULL
+);
+INSERT INTO message_revisions_new (revision_id, message_id, content, embeds, editor_id, created_at, edited_at)
+SELECT revision_id, message_id, content, embeds, editor_id, created_at, edited_at FROM message_revisions;
+DROP TABLE message_revisions;
+ALTER TABLE message_revisions_new RENAME TO message_revisions;
+
+CREATE INDEX idx_message_revisions_message_id ON message_revisions(message_id, revision_id);
+
+CREATE TABLE reactions_new (
+  message_id TEXT NOT NULL,
+  emoji TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  PRIMARY KEY (message_id, emoji, user_id)
+);
+INSERT INTO reactions_new (rowid, message_id, emoji, user_id, created_at)
+SELECT rowid, message_id, emoji, user_id, created_at FROM reactions;
+DROP TABLE reactions;
+ALTER TABLE reactions_new RENAME TO reactions;
+
+CREATE TABLE embed_fields_new (
+  field_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  embed_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  value TEXT NOT NULL,
+  inline INTEGER NOT NULL DEFAULT 0
+);
+INSERT INTO embed_fields_new (field_id, embed_id, name, value, inline)
+SELECT field_id, embed_id, name, value, inline FROM embed_fields;
+DROP TABLE embed_fields;
+ALTER TABLE embed_fields_new RENAME TO embed_fields;
+
+CREATE INDEX idx_embed_fields_embed_id ON embed_fields(embed_id);
+
+CREATE TABLE embeds_new (
+  embed_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  message_id TEXT NOT NULL,
+  title TEXT,
+  description TEXT,
+  url TEXT,
+  color INTEGER,
+  thumbnail_url TEXT,
+  image_url TEXT
+);
+INSERT INTO embeds_new (embed_id, message_id, title, description, url, color, thumbnail_url, image_url)
+SELECT embed_id, message_id, title, description, url, color, thumbnail_url, image_url FROM embeds;
+DROP TABLE embeds;
+ALTER TABLE embeds_new RENAME TO embeds;
+
+CREATE INDEX idx_embeds_message_id ON embeds(message_id);
+
+CREATE TABLE attachments_new (
+  attachment_id TEXT NOT NULL PRIMARY KEY,
+  message_id TEXT NOT NULL,
+  channel_id TEXT NOT NULL,
+  author_id TEXT NOT NULL,
+  filename TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size INTEGER NOT NULL,
+  url TEXT NOT NULL
+);
+INSERT INTO attachments_new (attachment_id, message_id, channel_id, author_id, filename, content_type, size, url)
+SELECT attachment_id, message_id, channel_id, author_id, filename, content_type, size, url FROM attachments;
+DROP TABLE attachments;
+ALTER TABLE attachments_new RENAME TO attachments;
+
+CREATE INDEX idx_attachments_message_id ON attachments(message_id);
+CREATE INDEX idx_attachments_channel_id ON attachments(channel_id);
+CREATE INDEX idx_attachments_author_id ON attachments(author_id);
+
+CREATE TABLE messages_new (
+  message_id TEXT NOT NULL PRIMARY KEY,
+  channel_id TEXT NOT NULL,
+  author_id TEXT NOT NULL,
+  content TEXT NOT NULL,
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  reply_to_id TEXT,
+  deleted_at INTEGER,
+  deleted_by TEXT,
+  purged_at INTEGER
+);
+INSERT INTO messages_new (rowid, message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at)
+SELECT rowid, message_id, channel_id, author_id, content, created_at, updated_at, reply_to_id, deleted_at, deleted_by, purged_at FROM messages;
+DROP TABLE messages;
+ALTER TABLE messages_new RENAME TO messages;
+
+CREATE INDEX idx_messages_channel_id ON messages(channel_id);
+CREATE INDEX idx_messages_author_id ON messages(author_id);
+CREATE INDEX idx_messages_created_at ON messages(created_at);
+CREATE INDEX idx_messages_reply_to_id ON messages(reply_to_id);
+CREATE INDEX idx_messages_channel_history ON messages(channel_id, created_at, message_id);
+CREATE INDEX idx_messages_deleted_at ON messages(deleted_at);
+
+-- Dropping the old table dropped the triggers that keep messages_fts in
+-- sync; rowids are copied, so the index itself still matches
+-- +goose StatementBegin
+CREATE TRIGGER messages_fts_insert AFTER INSERT ON messages BEGIN
+  INSERT INTO messages_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER messages_fts_delete AFTER DELETE ON messages BEGIN
+  INSERT INTO messages_fts (messages_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER messages_fts_update AFTER UPDATE OF content ON messages BEGIN
+  INSERT INTO messages_fts (messages_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+  INSERT INTO messages_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+-- +goose StatementEnd
+
+CREATE TABLE channels_new (
+  channel_id TEXT NOT NULL PRIMARY KEY,
+  name TEXT NOT NULL,
+  type INTEGER NOT NULL,
+  server_id TEXT,
+  parent_id TEXT,
+  metadata TEXT, -- JSON as TEXT
+  created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+INSERT INTO channels_new (rowid, channel_id, name, type, server_id, parent_id, metadata, created_at, updated_at)
+SELECT rowid, channel_id, name, type, server_id, parent_id, metadata, created_at, updated_at FROM channels;
+DROP TABLE channels;
+ALTER TABLE channels_new RENAME TO channels;
+
+CREATE INDEX idx_channels_server_id ON channels(server_id);
+CREATE INDEX idx_channels_parent_id ON channels(parent_id);
@@ -41,6 +41,19 @@ type ConfigValue struct {
 	UpdatedAt   int64          `json:"updated_at"`
 }
 
+type ConsumerOffset struct {
+	GroupName     string `json:"group_name"`
+	Scope         string `json:"scope"`
+	AckedSequence int64  `json:"acked_sequence"`
+	UpdatedAt     int64  `json:"updated_at"`
+}
+
+type DatabaseRoute struct {
+	ResourceID string `json:"resource_id"`
+	ServerID   string `json:"server_id"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
 type Embed struct {
 	EmbedID      int64          `json:"embed_id"`
 	MessageID    string         `json:"message_id"`
@@ -71,6 +84,24 @@ type Event struct {
 	Sequence  int64          `json:"sequence"`
 }
 
+type EventOutbox struct {
+	ID        int64  `json:"id"`
+	EventID   string `json:"event_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type EventSequence struct {
+	Scope        string `json:"scope"`
+	LastSequence int64  `json:"last_sequence"`
+}
+
+type EventSnapshot struct {
+	Scope     string `json:"scope"`
+	Sequence  int64  `json:"sequence"`
+	State     string `json:"state"`
+	CreatedAt int64  `json:"created_at"`
+}
+
 type Message struct {
 	MessageID string         `json:"message_id"`
 	ChannelID string         `json:"channel_id"`
@@ -79,4 +110,88 @@ type Message struct {
 	CreatedAt int64          `json:"created_at"`
 	UpdatedAt int64          `json:"updated_at"`
 	ReplyToID sql.NullString `json:"reply_to_id"`
+	DeletedAt sql.NullInt64  `json:"deleted_at"`
+	DeletedBy sql.NullString `json:"deleted_by"`
+	PurgedAt  sql.NullInt64  `json:"purged_at"`
+}
+
+type MessageRevision struct {
+	RevisionID int64  `json:"revision_id"`
+	MessageID  string `json:"message_id"`
+	Content    string `json:"content"`
+	Embeds     string `json:"embeds"`
+	EditorID   string `json:"editor_id"`
+	CreatedAt  int64  `json:"created_at"`
+	EditedAt   int64  `json:"edited_at"`
+}
+
+type PermissionOverwrite struct {
+	ChannelID  string `json:"channel_id"`
+	TargetType int64  `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	Allow      int64  `json:"allow"`
+	Deny       int64  `json:"deny"`
+}
+
+type Reaction struct {
+	MessageID string `json:"message_id"`
+	Emoji     string `json:"emoji"`
+	UserID    string `json:"user_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type Role struct {
+	RoleID      string `json:"role_id"`
+	ServerID    string `json:"server_id"`
+	Name        string `json:"name"`
+	Permissions int64  `json:"permissions"`
+	Position    int64  `json:"position"`
+	IsDefault   int64  `json:"is_default"`
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+}
+
+type RoleMember struct {
+	RoleID    string `json:"role_id"`
+	UserID    string `json:"user_id"`
+	ServerID  string `json:"server_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type Session struct {
+	SessionID        string         `json:"session_id"`
+	UserID           string         `json:"user_id"`
+	RefreshTokenHash string         `json:"refresh_token_hash"`
+	CreatedAt        int64          `json:"created_at"`
+	ExpiresAt        int64          `json:"expires_at"`
+	RevokedAt        sql.NullInt64  `json:"revoked_at"`
+	ReplacedBy       sql.NullString `json:"replaced_by"`
+}
+
+type Thread struct {
+	ChannelID           string `json:"channel_id"`
+	ParentID            string `json:"parent_id"`
+	MessageID           string `json:"message_id"`
+	OwnerID             string `json:"owner_id"`
+	Archived            int64  `json:"archived"`
+	Locked              int64  `json:"locked"`
+	AutoArchiveDuration int64  `json:"auto_archive_duration"`
+	MessageCount        int64  `json:"message_count"`
+	LastActivityAt      int64  `json:"last_activity_at"`
+	CreatedAt           int64  `json:"created_at"`
+}
+
+type ThreadMember struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	JoinedAt  int64  `json:"joined_at"`
+}
+
+type User struct {
+	UserID       string         `json:"user_id"`
+	Username     string         `json:"username"`
+	DisplayName  sql.NullString `json:"display_name"`
+	PasswordHash string         `json:"password_hash"`
+	CreatedAt    int64          `json:"created_at"`
+	UpdatedAt    int64          `json:"updated_at"`
 }
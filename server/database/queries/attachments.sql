@@ -1,6 +1,6 @@
 -- name: CreateAttachment :one
-INSERT INTO attachments (attachment_id, message_id, filename, content_type, size, url)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO attachments (attachment_id, message_id, channel_id, author_id, filename, content_type, size, url)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetAttachment :one
@@ -11,6 +11,22 @@ WHERE attachment_id = ?;
 SELECT * FROM attachments
 WHERE message_id = ?;
 
+-- name: ListAttachmentsByMessageIds :many
+SELECT * FROM attachments
+WHERE message_id IN (sqlc.slice('message_ids'))
+ORDER BY message_id, rowid;
+
 -- name: DeleteAttachment :exec
 DELETE FROM attachments
-WHERE attachment_id = ?;
\ No newline at end of file
+WHERE attachment_id = ?;
+
+-- name: DeleteAttachmentsByMessageId :exec
+DELETE FROM attachments
+WHERE message_id = ?;
+
+-- name: DeleteAttachmentsByChannelId :exec
+DELETE FROM attachments
+WHERE message_id IN (SELECT message_id FROM messages WHERE channel_id = ?);
+
+-- name: DeleteAllAttachments :exec
+DELETE FROM attachments;
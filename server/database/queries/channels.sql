@@ -16,7 +16,7 @@ LIMIT ? OFFSET ?;
 
 -- name: UpdateChannel :one
 UPDATE channels 
-SET name = ?, metadata = ?, updated_at = ?
+SET name = ?, parent_id = ?, metadata = ?, updated_at = ?
 WHERE channel_id = ?
 RETURNING *;
 
@@ -28,3 +28,24 @@ WHERE channel_id = ?;
 SELECT * FROM channels
 WHERE server_id = ?
 ORDER BY created_at DESC;
+
+-- name: ListChildChannels :many
+SELECT * FROM channels
+WHERE parent_id = ?
+ORDER BY created_at, channel_id;
+
+-- name: DetachChildChannels :exec
+UPDATE channels
+SET parent_id = NULL
+WHERE parent_id = ?;
+
+-- name: CountChannelsByServerId :one
+SELECT COUNT(*) FROM channels
+WHERE server_id = ?;
+
+-- name: ListAllChannels :many
+SELECT * FROM channels
+ORDER BY channel_id;
+
+-- name: DeleteAllChannels :exec
+DELETE FROM channels;
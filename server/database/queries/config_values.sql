@@ -26,4 +26,11 @@ RETURNING *;
 -- name: ListConfigKeys :many
 SELECT * FROM config_values
 WHERE scope = ?
-  AND (key LIKE ? || '%' OR ? = '');
\ No newline at end of file
+  AND (key LIKE ? || '%' OR ? = '');
+-- name: ListAllConfigs :many
+SELECT * FROM config_values
+ORDER BY scope, key;
+
+-- name: DeleteNonSensitiveConfigs :exec
+DELETE FROM config_values
+WHERE is_sensitive = 0;
@@ -0,0 +1,10 @@
+-- name: GetConsumerOffset :one
+SELECT acked_sequence FROM consumer_offsets
+WHERE group_name = ? AND scope = ?;
+
+-- name: SetConsumerOffset :exec
+INSERT INTO consumer_offsets (group_name, scope, acked_sequence, updated_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (group_name, scope) DO UPDATE SET
+  acked_sequence = excluded.acked_sequence,
+  updated_at = excluded.updated_at;
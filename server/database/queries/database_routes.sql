@@ -0,0 +1,12 @@
+-- name: CreateDatabaseRoute :exec
+INSERT INTO database_routes (resource_id, server_id, created_at)
+VALUES (?, ?, ?)
+ON CONFLICT(resource_id) DO NOTHING;
+
+-- name: GetDatabaseRoute :one
+SELECT server_id FROM database_routes
+WHERE resource_id = ?;
+
+-- name: DeleteDatabaseRoute :exec
+DELETE FROM database_routes
+WHERE resource_id = ?;
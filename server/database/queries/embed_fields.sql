@@ -1,12 +1,32 @@
 -- name: CreateEmbedField :one
-INSERT INTO embed_fields (field_id, embed_id, name, value, inline)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO embed_fields (embed_id, name, value, inline)
+VALUES (?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetEmbedFieldsByEmbedId :many
 SELECT * FROM embed_fields
 WHERE embed_id = ?;
 
+-- name: ListEmbedFieldsByMessageIds :many
+SELECT embed_fields.* FROM embed_fields
+JOIN embeds ON embeds.embed_id = embed_fields.embed_id
+WHERE embeds.message_id IN (sqlc.slice('message_ids'))
+ORDER BY embed_fields.embed_id, embed_fields.field_id;
+
 -- name: DeleteEmbedField :exec
 DELETE FROM embed_fields
-WHERE field_id = ?;
\ No newline at end of file
+WHERE field_id = ?;
+
+-- name: DeleteEmbedFieldsByMessageId :exec
+DELETE FROM embed_fields
+WHERE embed_id IN (SELECT embed_id FROM embeds WHERE message_id = ?);
+
+-- name: DeleteEmbedFieldsByChannelId :exec
+DELETE FROM embed_fields
+WHERE embed_id IN (
+  SELECT embed_id FROM embeds
+  WHERE message_id IN (SELECT message_id FROM messages WHERE channel_id = ?)
+);
+
+-- name: DeleteAllEmbedFields :exec
+DELETE FROM embed_fields;
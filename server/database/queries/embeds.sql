@@ -1,6 +1,6 @@
 -- name: CreateEmbed :one
-INSERT INTO embeds (embed_id, message_id, title, description, url, color, thumbnail_url, image_url)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO embeds (message_id, title, description, url, color, thumbnail_url, image_url)
+VALUES (?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetEmbed :one
@@ -11,6 +11,22 @@ WHERE embed_id = ?;
 SELECT * FROM embeds
 WHERE message_id = ?;
 
+-- name: ListEmbedsByMessageIds :many
+SELECT * FROM embeds
+WHERE message_id IN (sqlc.slice('message_ids'))
+ORDER BY message_id, embed_id;
+
 -- name: DeleteEmbed :exec
 DELETE FROM embeds
-WHERE embed_id = ?;
\ No newline at end of file
+WHERE embed_id = ?;
+
+-- name: DeleteEmbedsByMessageId :exec
+DELETE FROM embeds
+WHERE message_id = ?;
+
+-- name: DeleteEmbedsByChannelId :exec
+DELETE FROM embeds
+WHERE message_id IN (SELECT message_id FROM messages WHERE channel_id = ?);
+
+-- name: DeleteAllEmbeds :exec
+DELETE FROM embeds;
@@ -0,0 +1,15 @@
+-- name: CreateOutboxEntry :exec
+INSERT INTO event_outbox (event_id, created_at)
+VALUES (?, ?);
+
+-- name: ListOutboxEvents :many
+SELECT event_outbox.id, sqlc.embed(events)
+FROM event_outbox
+JOIN events ON events.event_id = event_outbox.event_id
+WHERE event_outbox.id > ?
+ORDER BY event_outbox.id ASC
+LIMIT ?;
+
+-- name: DeleteOutboxEntries :exec
+DELETE FROM event_outbox
+WHERE id <= ?;
@@ -0,0 +1,11 @@
+-- name: GetEventSnapshot :one
+SELECT * FROM event_snapshots
+WHERE scope = ?;
+
+-- name: SetEventSnapshot :exec
+INSERT INTO event_snapshots (scope, sequence, state, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (scope) DO UPDATE SET
+  sequence = excluded.sequence,
+  state = excluded.state,
+  created_at = excluded.created_at;
@@ -9,12 +9,12 @@ WHERE event_id = ?;
 
 -- name: GetEvents :many
 SELECT * FROM events
-WHERE scope = ?
-  AND (event_type IN (sqlc.slice('event_types')) OR sqlc.slice('event_types') IS NULL)
-  AND sequence >= ?
-  AND sequence <= ?
+WHERE scope = sqlc.arg(scope)
+  AND (CAST(sqlc.arg(all_event_types) AS BOOLEAN) OR event_type IN (sqlc.slice('event_types')))
+  AND sequence >= sqlc.arg(from_sequence)
+  AND sequence <= sqlc.arg(to_sequence)
 ORDER BY sequence ASC
-LIMIT ?;
+LIMIT sqlc.arg(limit);
 
 -- name: GetEventsByScope :many
 SELECT * FROM events
@@ -31,4 +31,80 @@ WHERE scope = ?;
 SELECT * FROM events
 WHERE event_type = ? AND scope = ?
 ORDER BY sequence DESC
-LIMIT ?;
\ No newline at end of file
+LIMIT ?;;
+
+-- name: NextEventSequence :one
+INSERT INTO event_sequences (scope, last_sequence)
+VALUES (?, 1)
+ON CONFLICT (scope) DO UPDATE SET last_sequence = last_sequence + 1
+RETURNING last_sequence;
+-- name: GetLastEventSequence :one
+SELECT last_sequence FROM event_sequences
+WHERE scope = ?;
+
+-- name: ListEventScopes :many
+SELECT DISTINCT scope FROM events
+ORDER BY scope;
+
+-- name: ListEventTypesByScope :many
+SELECT DISTINCT event_type FROM events
+WHERE scope = ?
+ORDER BY event_type;
+
+-- name: DeleteEventsBefore :execrows
+DELETE FROM events
+WHERE scope = ? AND event_type = ? AND timestamp < ?
+  AND event_id NOT IN (SELECT event_id FROM event_outbox)
+  AND sequence <= COALESCE((SELECT MIN(acked_sequence) FROM consumer_offsets WHERE consumer_offsets.scope = events.scope), sequence);
+
+-- name: GetNthNewestEventSequence :one
+SELECT sequence FROM events
+WHERE scope = ? AND event_type = ?
+ORDER BY sequence DESC
+LIMIT 1 OFFSET ?;
+
+-- name: DeleteEventsUpToSequence :execrows
+DELETE FROM events
+WHERE scope = ? AND event_type = ? AND sequence <= ?
+  AND event_id NOT IN (SELECT event_id FROM event_outbox)
+  AND sequence <= COALESCE((SELECT MIN(acked_sequence) FROM consumer_offsets WHERE consumer_offsets.scope = events.scope), sequence);
+
+-- name: CompactConfigEvents :execrows
+DELETE FROM events
+WHERE scope = ? AND event_type IN ('config.updated', 'config.deleted')
+  AND event_id NOT IN (SELECT event_id FROM event_outbox)
+  AND sequence <= COALESCE((SELECT MIN(acked_sequence) FROM consumer_offsets WHERE consumer_offsets.scope = events.scope), sequence)
+  AND EXISTS (
+    SELECT 1 FROM events AS newer
+    WHERE newer.scope = events.scope
+      AND newer.event_type IN ('config.updated', 'config.deleted')
+      AND json_extract(newer.metadata, '$.config_key') = json_extract(events.metadata, '$.config_key')
+      AND newer.sequence > events.sequence
+  );
+
+-- name: CountEvents :one
+SELECT COUNT(*) FROM events;
+
+-- name: ListEventsForReplay :many
+SELECT events.rowid, sqlc.embed(events)
+FROM events
+WHERE events.rowid > ?
+ORDER BY events.rowid ASC
+LIMIT ?;
+
+-- name: ListMessageEvents :many
+SELECT * FROM events
+WHERE scope = sqlc.arg(scope)
+  AND json_extract(metadata, '$.message_id') = CAST(sqlc.arg(message_id) AS TEXT)
+ORDER BY sequence;
+
+-- name: ListScopeMessageEvents :many
+SELECT * FROM events
+WHERE scope = ?
+  AND event_type IN ('message.sent', 'message.updated')
+ORDER BY sequence;
+
+-- name: UpdateEventPayload :exec
+UPDATE events
+SET payload = ?
+WHERE event_id = ?;
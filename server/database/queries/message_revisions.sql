@@ -0,0 +1,19 @@
+-- name: CreateMessageRevision :exec
+INSERT INTO message_revisions (message_id, content, embeds, editor_id, created_at, edited_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListMessageRevisions :many
+SELECT * FROM message_revisions
+WHERE message_id = ?
+ORDER BY revision_id;
+
+-- name: DeleteMessageRevisionsByMessageId :exec
+DELETE FROM message_revisions
+WHERE message_id = ?;
+
+-- name: DeleteMessageRevisionsByChannelId :exec
+DELETE FROM message_revisions
+WHERE message_id IN (SELECT message_id FROM messages WHERE channel_id = ?);
+
+-- name: DeleteAllMessageRevisions :exec
+DELETE FROM message_revisions;
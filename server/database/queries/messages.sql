@@ -5,14 +5,30 @@ RETURNING *;
 
 -- name: GetMessage :one
 SELECT * FROM messages
+WHERE message_id = ? AND deleted_at IS NULL;
+
+-- name: GetMessageIncludingDeleted :one
+SELECT * FROM messages
 WHERE message_id = ?;
 
--- name: GetMessages :many
+-- name: ListLatestMessages :many
 SELECT * FROM messages
-WHERE channel_id = ?
-  AND (created_at < ? OR ? = 0)
-  AND (created_at > ? OR ? = 0)
-ORDER BY created_at DESC
+WHERE channel_id = ? AND deleted_at IS NULL
+ORDER BY created_at DESC, message_id DESC
+LIMIT ?;
+
+-- name: ListMessagesBefore :many
+SELECT * FROM messages
+WHERE channel_id = ? AND deleted_at IS NULL
+  AND (created_at < ? OR (created_at = ? AND message_id < ?))
+ORDER BY created_at DESC, message_id DESC
+LIMIT ?;
+
+-- name: ListMessagesAfter :many
+SELECT * FROM messages
+WHERE channel_id = ? AND deleted_at IS NULL
+  AND (created_at > ? OR (created_at = ? AND message_id > ?))
+ORDER BY created_at ASC, message_id ASC
 LIMIT ?;
 
 -- name: UpdateMessage :one
@@ -25,8 +41,52 @@ RETURNING *;
 DELETE FROM messages
 WHERE message_id = ?;
 
+-- name: DeleteMessagesByChannelId :execrows
+DELETE FROM messages
+WHERE channel_id = ?;
+
+-- name: TombstoneMessage :execrows
+UPDATE messages
+SET deleted_at = ?, deleted_by = ?
+WHERE message_id = ? AND deleted_at IS NULL;
+
+-- name: ListPurgeableMessages :many
+SELECT * FROM messages
+WHERE deleted_at <= ? AND purged_at IS NULL
+ORDER BY deleted_at, message_id
+LIMIT ?;
+
+-- name: PurgeMessage :exec
+UPDATE messages
+SET content = '', purged_at = ?
+WHERE message_id = ?;
+
 -- name: GetMessagesByChannelId :many
 SELECT * FROM messages
-WHERE channel_id = ?
+WHERE channel_id = ? AND deleted_at IS NULL
 ORDER BY created_at DESC
-LIMIT ? OFFSET ?;
\ No newline at end of file
+LIMIT ? OFFSET ?;
+
+-- name: ListAllMessages :many
+SELECT * FROM messages
+ORDER BY message_id;
+
+-- name: DeleteAllMessages :exec
+DELETE FROM messages;
+
+-- name: SearchMessages :many
+SELECT sqlc.embed(messages),
+  CAST(snippet(messages_fts, 0, sqlc.arg(highlight_start), sqlc.arg(highlight_end), '…', 16) AS TEXT) AS snippet,
+  CAST(bm25(messages_fts) AS REAL) AS rank
+FROM messages_fts
+JOIN messages ON messages.rowid = messages_fts.rowid
+WHERE messages_fts MATCH sqlc.arg(query)
+  AND messages.channel_id IN (sqlc.slice('channel_ids'))
+  AND messages.deleted_at IS NULL
+  AND (CAST(sqlc.arg(any_author) AS BOOLEAN) OR messages.author_id = sqlc.arg(author_id))
+  AND messages.created_at >= sqlc.arg(created_after)
+  AND messages.created_at < sqlc.arg(created_before)
+  AND (NOT CAST(sqlc.arg(has_attachment) AS BOOLEAN) OR EXISTS (SELECT 1 FROM attachments WHERE attachments.message_id = messages.message_id))
+  AND (NOT CAST(sqlc.arg(has_embed) AS BOOLEAN) OR EXISTS (SELECT 1 FROM embeds WHERE embeds.message_id = messages.message_id))
+ORDER BY rank, messages.message_id
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
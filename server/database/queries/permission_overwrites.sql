@@ -0,0 +1,23 @@
+-- name: SetPermissionOverwrite :one
+INSERT INTO permission_overwrites (channel_id, target_type, target_id, allow, deny)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(channel_id, target_type, target_id) DO UPDATE SET
+  allow = excluded.allow,
+  deny = excluded.deny
+RETURNING *;
+
+-- name: GetPermissionOverwritesByChannelId :many
+SELECT * FROM permission_overwrites
+WHERE channel_id = ?;
+
+-- name: DeletePermissionOverwrite :execrows
+DELETE FROM permission_overwrites
+WHERE channel_id = ? AND target_type = ? AND target_id = ?;
+
+-- name: DeletePermissionOverwritesByTargetId :exec
+DELETE FROM permission_overwrites
+WHERE target_type = ? AND target_id = ?;
+
+-- name: DeletePermissionOverwritesByChannelId :exec
+DELETE FROM permission_overwrites
+WHERE channel_id = ?;
@@ -0,0 +1,37 @@
+-- name: AddReaction :execrows
+INSERT INTO reactions (message_id, emoji, user_id, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(message_id, emoji, user_id) DO NOTHING;
+
+-- name: RemoveReaction :execrows
+DELETE FROM reactions
+WHERE message_id = ? AND emoji = ? AND user_id = ?;
+
+-- name: ListReactionCounts :many
+SELECT message_id, emoji,
+  COUNT(*) AS count,
+  CAST(MAX(user_id = sqlc.arg(user_id)) AS BOOLEAN) AS me
+FROM reactions
+WHERE message_id IN (sqlc.slice('message_ids'))
+GROUP BY message_id, emoji
+ORDER BY message_id, MIN(rowid);
+
+-- name: ListReactions :many
+SELECT reactions.rowid, sqlc.embed(reactions)
+FROM reactions
+WHERE message_id = sqlc.arg(message_id)
+  AND (CAST(sqlc.arg(any_emoji) AS BOOLEAN) OR emoji = sqlc.arg(emoji))
+  AND reactions.rowid > sqlc.arg(after_rowid)
+ORDER BY reactions.rowid
+LIMIT sqlc.arg(limit);
+
+-- name: DeleteReactionsByMessageId :exec
+DELETE FROM reactions
+WHERE message_id = ?;
+
+-- name: DeleteReactionsByChannelId :exec
+DELETE FROM reactions
+WHERE message_id IN (SELECT message_id FROM messages WHERE channel_id = ?);
+
+-- name: DeleteAllReactions :exec
+DELETE FROM reactions;
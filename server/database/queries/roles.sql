@@ -0,0 +1,47 @@
+-- name: CreateRole :one
+INSERT INTO roles (role_id, server_id, name, permissions, position, is_default, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetRole :one
+SELECT * FROM roles
+WHERE role_id = ?;
+
+-- name: ListRolesByServerId :many
+SELECT * FROM roles
+WHERE server_id = ?
+ORDER BY position DESC, created_at ASC;
+
+-- name: CountRolesByServerId :one
+SELECT COUNT(*) FROM roles
+WHERE server_id = ?;
+
+-- name: UpdateRole :one
+UPDATE roles
+SET name = ?, permissions = ?, position = ?, updated_at = ?
+WHERE role_id = ?
+RETURNING *;
+
+-- name: DeleteRole :exec
+DELETE FROM roles
+WHERE role_id = ?;
+
+-- name: AddRoleMember :exec
+INSERT INTO role_members (role_id, user_id, server_id, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(role_id, user_id) DO NOTHING;
+
+-- name: RemoveRoleMember :execrows
+DELETE FROM role_members
+WHERE role_id = ? AND user_id = ?;
+
+-- name: DeleteRoleMembersByRoleId :exec
+DELETE FROM role_members
+WHERE role_id = ?;
+
+-- name: GetMemberRoles :many
+SELECT roles.* FROM roles
+WHERE roles.server_id = ?
+  AND (roles.is_default = 1
+    OR roles.role_id IN (SELECT role_members.role_id FROM role_members WHERE role_members.server_id = roles.server_id AND role_members.user_id = ?))
+ORDER BY roles.position DESC;
@@ -0,0 +1,27 @@
+-- name: CreateSession :one
+INSERT INTO sessions (session_id, user_id, refresh_token_hash, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetSession :one
+SELECT * FROM sessions
+WHERE session_id = ?;
+
+-- name: GetSessionByRefreshTokenHash :one
+SELECT * FROM sessions
+WHERE refresh_token_hash = ?;
+
+-- name: RotateSession :execrows
+UPDATE sessions
+SET revoked_at = ?, replaced_by = ?
+WHERE session_id = ? AND revoked_at IS NULL;
+
+-- name: RevokeSession :execrows
+UPDATE sessions
+SET revoked_at = ?
+WHERE session_id = ? AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :execrows
+UPDATE sessions
+SET revoked_at = ?
+WHERE user_id = ? AND revoked_at IS NULL;
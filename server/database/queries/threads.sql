@@ -0,0 +1,79 @@
+-- name: CreateThread :one
+INSERT INTO threads (channel_id, parent_id, message_id, owner_id, auto_archive_duration, last_activity_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetThread :one
+SELECT * FROM threads
+WHERE channel_id = ?;
+
+-- name: GetThreadByMessageId :one
+SELECT * FROM threads
+WHERE message_id = ?;
+
+-- name: ListThreadsByChannelIds :many
+SELECT * FROM threads
+WHERE channel_id IN (sqlc.slice('channel_ids'));
+
+-- name: ListThreadsByMessageIds :many
+SELECT * FROM threads
+WHERE message_id IN (sqlc.slice('message_ids'));
+
+-- name: ListThreadsByParentId :many
+SELECT * FROM threads
+WHERE parent_id = ?
+ORDER BY channel_id;
+
+-- name: ListActiveThreads :many
+SELECT channels.* FROM threads
+JOIN channels ON channels.channel_id = threads.channel_id
+WHERE threads.parent_id = sqlc.arg(parent_id)
+  AND threads.archived = 0
+  AND threads.last_activity_at + threads.auto_archive_duration * 60 > CAST(sqlc.arg(now) AS INTEGER)
+ORDER BY threads.last_activity_at DESC, threads.channel_id;
+
+-- name: UpdateThread :one
+UPDATE threads
+SET archived = ?, locked = ?, auto_archive_duration = ?, last_activity_at = ?
+WHERE channel_id = ?
+RETURNING *;
+
+-- name: RecordThreadMessage :exec
+UPDATE threads
+SET message_count = message_count + 1,
+  last_activity_at = MAX(last_activity_at, sqlc.arg(last_activity_at)),
+  archived = 0
+WHERE channel_id = sqlc.arg(channel_id);
+
+-- name: RemoveThreadMessage :exec
+UPDATE threads
+SET message_count = MAX(message_count - 1, 0)
+WHERE channel_id = ?;
+
+-- name: DeleteThread :exec
+DELETE FROM threads
+WHERE channel_id = ?;
+
+-- name: DeleteAllThreads :exec
+DELETE FROM threads;
+
+-- name: AddThreadMember :execrows
+INSERT INTO thread_members (channel_id, user_id, joined_at)
+VALUES (?, ?, ?)
+ON CONFLICT(channel_id, user_id) DO NOTHING;
+
+-- name: RemoveThreadMember :execrows
+DELETE FROM thread_members
+WHERE channel_id = ? AND user_id = ?;
+
+-- name: ListThreadMembers :many
+SELECT * FROM thread_members
+WHERE channel_id IN (sqlc.slice('channel_ids'))
+ORDER BY channel_id, joined_at, user_id;
+
+-- name: DeleteThreadMembersByChannelId :exec
+DELETE FROM thread_members
+WHERE channel_id = ?;
+
+-- name: DeleteAllThreadMembers :exec
+DELETE FROM thread_members;
@@ -0,0 +1,12 @@
+-- name: CreateUser :one
+INSERT INTO users (user_id, username, display_name, password_hash, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE user_id = ?;
+
+-- name: GetUserByUsername :one
+SELECT * FROM users
+WHERE username = ?;
@@ -1,36 +1,80 @@
 package server
 
 import (
+	"context"
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/pressly/goose/v3"
 	"github.com/tursodatabase/go-libsql"
 	_ "github.com/tursodatabase/go-libsql"
+	"google.golang.org/grpc"
+
 	"github.com/waifu-devs/fuwa/server/database"
 )
 
 //go:embed database/migrations/*.sql
 var embedMigrations embed.FS
 
+// PrimaryDatabaseName is the database holding instance-wide data such as
+// users, sessions and the routes to per-server databases.
+const PrimaryDatabaseName = "fuwa"
+
+var (
+	ErrTooManyDatabases    = errors.New("too many open databases")
+	ErrDatabaseUnavailable = errors.New("database is being dropped or archived")
+	ErrDatabaseClosed      = errors.New("database manager is closed")
+	ErrDatabaseNotFound    = errors.New("database does not exist")
+	ErrNoDatabaseLeases    = errors.New("database used outside a lease set (see WithDatabaseLeases)")
+)
+
+// managedDatabase is one database file and its handle. refs counts leases
+// held by in-flight requests; the handle is only closed once it drops to zero.
+type managedDatabase struct {
+	name     string
+	path     string
+	db       *sql.DB
+	queries  *database.Queries
+	refs     int
+	lastUsed time.Time
+	pinned   bool
+
+	// ready is closed once opening finished, with err set on failure
+	ready chan struct{}
+	err   error
+
+	// retiring blocks new leases; drained is closed when refs reaches zero
+	retiring bool
+	drained  chan struct{}
+}
+
 type MultiDatabaseManager struct {
-	connections map[string]*sql.DB
-	queries     map[string]*database.Queries
+	mu          sync.Mutex
+	databases   map[string]*managedDatabase
 	dataPath    string
 	config      *Config
+	maxOpen     int
+	idleTimeout time.Duration
+	closed      bool
+	stopEvict   chan struct{}
+	evictDone   chan struct{}
 }
 
 func NewMultiDatabaseManager(config *Config) *MultiDatabaseManager {
 	return &MultiDatabaseManager{
-		connections: make(map[string]*sql.DB),
-		queries:     make(map[string]*database.Queries),
+		databases:   make(map[string]*managedDatabase),
 		dataPath:    config.DataPath,
 		config:      config,
+		maxOpen:     config.DBMaxOpen,
+		idleTimeout: config.DBIdleTimeout,
 	}
 }
 
@@ -46,36 +90,53 @@ func (mdm *MultiDatabaseManager) ReadAllDatabases() error {
 		return fmt.Errorf("failed to scan for database files in %s: %w", mdm.dataPath, err)
 	}
 
-	if len(matches) == 0 {
-		log.Printf("No database files found in %s, creating default database 'fuwa'", mdm.dataPath)
-		// Create a default database named 'fuwa' if none exist
-		if err := mdm.CreateDatabase("fuwa"); err != nil {
-			return fmt.Errorf("failed to create default database: %w", err)
-		}
-		return nil
-	}
-
 	log.Printf("Found %d database files in %s", len(matches), mdm.dataPath)
 
+	// The primary database is pinned and never counts against eviction
+	if err := mdm.openPrimary(); err != nil {
+		return err
+	}
+
 	for _, dbPath := range matches {
 		dbName := strings.TrimSuffix(filepath.Base(dbPath), ".db")
+		if dbName == PrimaryDatabaseName {
+			continue
+		}
 
-		if err := mdm.openDatabase(dbName, dbPath); err != nil {
+		lease, err := mdm.acquire(dbName, acquireExisting)
+		if err != nil {
+			if errors.Is(err, ErrTooManyDatabases) {
+				// Still bring the schema up to date so the file is ready on first use
+				if _, err := mdm.MigrateUp(dbName); err != nil {
+					log.Printf("Warning: Failed to migrate database %s: %v", dbPath, err)
+				}
+				continue
+			}
 			log.Printf("Warning: Failed to open database %s: %v", dbPath, err)
 			continue
 		}
+		lease.Release()
 
 		log.Printf("Successfully connected to database: %s", dbName)
 	}
 
-	if len(mdm.connections) == 0 {
-		log.Printf("Warning: No database connections established, server will run without databases")
+	return nil
+}
+
+func (mdm *MultiDatabaseManager) openPrimary() error {
+	lease, err := mdm.acquire(PrimaryDatabaseName, acquireCreate)
+	if err != nil {
+		return fmt.Errorf("failed to open primary database: %w", err)
 	}
+	defer lease.Release()
 
+	mdm.mu.Lock()
+	mdm.databases[PrimaryDatabaseName].pinned = true
+	mdm.mu.Unlock()
 	return nil
 }
 
-func (mdm *MultiDatabaseManager) openDatabase(name, path string) error {
+func (mdm *MultiDatabaseManager) openDatabase(name, path string) (*sql.DB, error) {
 	var db *sql.DB
 	var err error
 
@@ -90,7 +151,7 @@ func (mdm *MultiDatabaseManager) openDatabase(name, path string) error {
 
 		connector, err := libsql.NewEmbeddedReplicaConnector(path, mdm.config.TursoURL, options...)
 		if err != nil {
-			return fmt.Errorf("failed to create embedded replica connector for %s: %w", path, err)
+			return nil, fmt.Errorf("failed to create embedded replica connector for %s: %w", path, err)
 		}
 		db = sql.OpenDB(connector)
 	} else {
@@ -103,149 +164,592 @@ func (mdm *MultiDatabaseManager) openDatabase(name, path string) error {
 
 		db, err = sql.Open("libsql", dsn)
 		if err != nil {
-			return fmt.Errorf("failed to open database %s: %w", path, err)
+			return nil, fmt.Errorf("failed to open database %s: %w", path, err)
 		}
 	}
 
+	// SQLite allows a single writer and libsql fails with "database is locked"
+	// instead of waiting, so queue statements on one connection per file
+	db.SetMaxOpenConns(1)
+
 	if err := db.Ping(); err != nil {
 		db.Close()
-		return fmt.Errorf("failed to ping database %s: %w", path, err)
+		return nil, fmt.Errorf("failed to ping database %s: %w", path, err)
 	}
 
-	mdm.connections[name] = db
-	mdm.queries[name] = database.New(db)
+	// SQLite leaves foreign keys unenforced unless each connection asks;
+	// the pool keeps its single connection, so asking once is enough
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to enable foreign keys on database %s: %w", path, err)
+	}
 
-	return nil
+	return db, nil
 }
 
-func (mdm *MultiDatabaseManager) GetDatabase(name string) (*sql.DB, error) {
-	db, exists := mdm.connections[name]
-	if !exists {
-		// Try to create the database if it doesn't exist
-		if err := mdm.CreateDatabase(name); err != nil {
-			return nil, fmt.Errorf("database %s not found and failed to create: %w", name, err)
+// DatabaseLease keeps a database open until released.
+type DatabaseLease struct {
+	DB      *sql.DB
+	Queries *database.Queries
+
+	release    sync.Once
+	manager    *MultiDatabaseManager
+	entry      *managedDatabase
+	background bool
+}
+
+// Release returns the lease; it is safe to call more than once.
+func (l *DatabaseLease) Release() {
+	l.release.Do(func() {
+		l.manager.releaseEntry(l.entry, !l.background)
+	})
+}
+
+// acquireMode says how acquire treats a database.
+type acquireMode int
+
+const (
+	// acquireExisting opens an existing database
+	acquireExisting acquireMode = iota
+	// acquireCreate creates and migrates the database if it doesn't exist
+	acquireCreate
+	// acquireBackground opens an existing database without counting as a
+	// use, so databases only background jobs touch still close when idle
+	acquireBackground
+)
+
+// Acquire opens an existing database, migrating it if needed, and holds it
+// open until the lease is released. It fails with ErrDatabaseNotFound if the
+// file doesn't exist; only CreateDatabase and DatabaseRouter.CreateServer
+// create databases.
+func (mdm *MultiDatabaseManager) Acquire(name string) (*DatabaseLease, error) {
+	return mdm.acquire(name, acquireExisting)
+}
+
+// AcquireBackground is Acquire for background jobs that visit every database
+// file. Their leases don't keep a database open: one they open is the first
+// to be evicted, and one already open keeps the time a request last used it.
+func (mdm *MultiDatabaseManager) AcquireBackground(name string) (*DatabaseLease, error) {
+	return mdm.acquire(name, acquireBackground)
+}
+
+func (mdm *MultiDatabaseManager) acquire(name string, mode acquireMode) (*DatabaseLease, error) {
+	background := mode == acquireBackground
+
+	mdm.mu.Lock()
+	if mdm.closed {
+		mdm.mu.Unlock()
+		return nil, ErrDatabaseClosed
+	}
+
+	if entry, exists := mdm.databases[name]; exists {
+		if entry.retiring {
+			mdm.mu.Unlock()
+			return nil, ErrDatabaseUnavailable
 		}
-		db, exists = mdm.connections[name]
-		if !exists {
-			return nil, fmt.Errorf("database %s not found even after creation", name)
+		entry.refs++
+		if !background {
+			entry.lastUsed = time.Now()
 		}
+		mdm.mu.Unlock()
+
+		<-entry.ready
+		if entry.err != nil {
+			mdm.releaseEntry(entry, !background)
+			// The failed entry is gone by now, so a caller that may create
+			// the file doesn't inherit another caller's "does not exist"
+			if mode == acquireCreate && errors.Is(entry.err, ErrDatabaseNotFound) {
+				return mdm.acquire(name, mode)
+			}
+			return nil, entry.err
+		}
+		return mdm.newLease(entry, background), nil
 	}
-	return db, nil
+
+	toClose, err := mdm.reserveSlotLocked()
+	if err != nil {
+		mdm.mu.Unlock()
+		return nil, err
+	}
+
+	entry := &managedDatabase{
+		name:  name,
+		path:  filepath.Join(mdm.dataPath, name+".db"),
+		refs:  1,
+		ready: make(chan struct{}),
+	}
+	if !background {
+		entry.lastUsed = time.Now()
+	}
+	mdm.databases[name] = entry
+	mdm.mu.Unlock()
+
+	closeDatabases(toClose)
+
+	// Open outside the lock so slow files don't block other databases
+	db, err := mdm.openAndMigrate(entry, mode == acquireCreate)
+
+	mdm.mu.Lock()
+	if err != nil {
+		entry.err = err
+		delete(mdm.databases, name)
+	} else {
+		entry.db = db
+		entry.queries = database.New(db)
+	}
+	close(entry.ready)
+	mdm.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return mdm.newLease(entry, background), nil
 }
 
-func (mdm *MultiDatabaseManager) GetQueries(name string) (*database.Queries, error) {
-	queries, exists := mdm.queries[name]
-	if !exists {
-		// Try to create the database if it doesn't exist
-		if err := mdm.CreateDatabase(name); err != nil {
-			return nil, fmt.Errorf("queries for database %s not found and failed to create: %w", name, err)
+func (mdm *MultiDatabaseManager) newLease(entry *managedDatabase, background bool) *DatabaseLease {
+	return &DatabaseLease{
+		DB:         entry.db,
+		Queries:    entry.queries,
+		manager:    mdm,
+		entry:      entry,
+		background: background,
+	}
+}
+
+// releaseEntry drops a reference; touch records it as a use for eviction.
+func (mdm *MultiDatabaseManager) releaseEntry(entry *managedDatabase, touch bool) {
+	mdm.mu.Lock()
+	defer mdm.mu.Unlock()
+
+	entry.refs--
+	if touch {
+		entry.lastUsed = time.Now()
+	}
+	if entry.refs == 0 && entry.drained != nil {
+		close(entry.drained)
+		entry.drained = nil
+	}
+}
+
+// reserveSlotLocked makes room for one more handle by evicting the least
+// recently used idle database. The returned handles must be closed by the
+// caller after unlocking.
+func (mdm *MultiDatabaseManager) reserveSlotLocked() ([]*sql.DB, error) {
+	if mdm.maxOpen <= 0 || mdm.openCountLocked() < mdm.maxOpen {
+		return nil, nil
+	}
+
+	var victim *managedDatabase
+	for _, entry := range mdm.databases {
+		if !mdm.evictableLocked(entry) {
+			continue
 		}
-		queries, exists = mdm.queries[name]
-		if !exists {
-			return nil, fmt.Errorf("queries for database %s not found even after creation", name)
+		if victim == nil || entry.lastUsed.Before(victim.lastUsed) {
+			victim = entry
 		}
 	}
-	return queries, nil
+	if victim == nil {
+		return nil, ErrTooManyDatabases
+	}
+
+	delete(mdm.databases, victim.name)
+	log.Printf("Closing least recently used database %s to stay under %d open handles", victim.name, mdm.maxOpen)
+	return []*sql.DB{victim.db}, nil
 }
 
-func (mdm *MultiDatabaseManager) GetPrimaryQueries() (*database.Queries, error) {
-	if len(mdm.queries) == 0 {
-		return nil, nil
+func (mdm *MultiDatabaseManager) openCountLocked() int {
+	count := 0
+	for _, entry := range mdm.databases {
+		if !entry.pinned {
+			count++
+		}
+	}
+	return count
+}
+
+func (mdm *MultiDatabaseManager) evictableLocked(entry *managedDatabase) bool {
+	if entry.pinned || entry.retiring || entry.refs > 0 {
+		return false
 	}
+	select {
+	case <-entry.ready:
+		return entry.err == nil
+	default:
+		return false
+	}
+}
 
-	if queries, exists := mdm.queries["fuwa"]; exists {
-		return queries, nil
+func closeDatabases(dbs []*sql.DB) {
+	for _, db := range dbs {
+		if err := db.Close(); err != nil {
+			log.Printf("Failed to close database: %v", err)
+		}
 	}
+}
 
-	for _, queries := range mdm.queries {
-		return queries, nil
+func (mdm *MultiDatabaseManager) openAndMigrate(entry *managedDatabase, create bool) (*sql.DB, error) {
+	_, statErr := os.Stat(entry.path)
+	isNew := os.IsNotExist(statErr)
+	if isNew && !create {
+		return nil, fmt.Errorf("%w: %s", ErrDatabaseNotFound, entry.name)
+	}
+
+	if isNew {
+		// Ensure data path directory exists
+		if err := os.MkdirAll(mdm.dataPath, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create data directory %s: %w", mdm.dataPath, err)
+		}
+	}
+
+	db, err := mdm.openDatabase(entry.name, entry.path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Existing files receive migrations added since they were created
+	if _, err := mdm.runMigrations(entry.name, entry.path, db, isNew); err != nil {
+		db.Close()
+		if isNew {
+			os.Remove(entry.path)
+		}
+		return nil, fmt.Errorf("failed to run migrations on database %s: %w", entry.name, err)
+	}
+
+	if isNew {
+		log.Printf("Successfully created and initialized database: %s", entry.name)
+	}
+	return db, nil
+}
+
+// QueriesForContext acquires an existing database for the lifetime of the
+// request in ctx (see WithDatabaseLeases) and fails with ErrNoDatabaseLeases
+// without one, since nothing would keep the database open. Inside
+// DatabaseRouter.InScopeTx it returns the open transaction.
+func (mdm *MultiDatabaseManager) QueriesForContext(ctx context.Context, name string) (*database.Queries, error) {
+	if tx, ok := ctx.Value(txKey{name}).(*database.Queries); ok {
+		return tx, nil
+	}
+
+	lease, err := mdm.leaseForContext(ctx, name, acquireExisting)
+	if err != nil {
+		return nil, fmt.Errorf("queries for database %s not available: %w", name, err)
+	}
+	return lease.Queries, nil
+}
+
+func (mdm *MultiDatabaseManager) leaseForContext(ctx context.Context, name string, mode acquireMode) (*DatabaseLease, error) {
+	set, ok := ctx.Value(leaseSetKey{}).(*leaseSet)
+	if !ok {
+		return nil, ErrNoDatabaseLeases
+	}
+
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	if lease, exists := set.leases[name]; exists {
+		return lease, nil
+	}
+
+	lease, err := mdm.acquire(name, mode)
+	if err != nil {
+		return nil, err
+	}
+	set.leases[name] = lease
+	return lease, nil
+}
+
+func (mdm *MultiDatabaseManager) GetPrimaryQueries() (*database.Queries, error) {
+	mdm.mu.Lock()
+	defer mdm.mu.Unlock()
+
+	if entry, exists := mdm.databases[PrimaryDatabaseName]; exists && entry.queries != nil {
+		return entry.queries, nil
 	}
 
 	return nil, nil
 }
 
+// GetPrimaryDatabase returns the connection of the instance-wide database, or
+// nil when it isn't open.
+func (mdm *MultiDatabaseManager) GetPrimaryDatabase() (*sql.DB, error) {
+	mdm.mu.Lock()
+	defer mdm.mu.Unlock()
+
+	if entry, exists := mdm.databases[PrimaryDatabaseName]; exists && entry.db != nil {
+		return entry.db, nil
+	}
+
+	return nil, nil
+}
+
+// ListDatabases returns the names of the currently open databases.
 func (mdm *MultiDatabaseManager) ListDatabases() []string {
+	mdm.mu.Lock()
+	defer mdm.mu.Unlock()
+
 	var names []string
-	for name := range mdm.connections {
-		names = append(names, name)
+	for name, entry := range mdm.databases {
+		if entry.queries != nil {
+			names = append(names, name)
+		}
 	}
+	sort.Strings(names)
 	return names
 }
 
 // CreateDatabase creates a new database file with the given name and runs migrations
 func (mdm *MultiDatabaseManager) CreateDatabase(name string) error {
 	// Check if database already exists
-	if _, exists := mdm.connections[name]; exists {
+	if _, err := os.Stat(filepath.Join(mdm.dataPath, name+".db")); err == nil {
 		return fmt.Errorf("database %s already exists", name)
 	}
 
-	// Ensure data path directory exists
-	if err := os.MkdirAll(mdm.dataPath, 0755); err != nil {
-		return fmt.Errorf("failed to create data directory %s: %w", mdm.dataPath, err)
+	lease, err := mdm.acquire(name, acquireCreate)
+	if err != nil {
+		return fmt.Errorf("failed to create database %s: %w", name, err)
 	}
+	lease.Release()
+	return nil
+}
 
-	dbPath := filepath.Join(mdm.dataPath, name+".db")
+// CloseDatabase closes an idle database; it is reopened on next use.
+func (mdm *MultiDatabaseManager) CloseDatabase(name string) error {
+	mdm.mu.Lock()
+	entry, exists := mdm.databases[name]
+	if !exists {
+		mdm.mu.Unlock()
+		return nil
+	}
+	if !mdm.evictableLocked(entry) {
+		mdm.mu.Unlock()
+		return fmt.Errorf("database %s is in use", name)
+	}
+	delete(mdm.databases, name)
+	mdm.mu.Unlock()
 
-	// Create and open the database
-	if err := mdm.openDatabase(name, dbPath); err != nil {
-		return fmt.Errorf("failed to create database %s: %w", name, err)
+	return entry.db.Close()
+}
+
+// DropDatabase waits for in-flight requests to finish, then closes the
+// database and deletes its files.
+func (mdm *MultiDatabaseManager) DropDatabase(ctx context.Context, name string) error {
+	path, err := mdm.retire(ctx, name)
+	if err != nil {
+		return err
 	}
 
-	// Run migrations on the newly created database
-	if err := mdm.runMigrations(name); err != nil {
-		// Clean up the failed database connection
-		if db, exists := mdm.connections[name]; exists {
-			db.Close()
-			delete(mdm.connections, name)
-			delete(mdm.queries, name)
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path+suffix, err)
 		}
-		// Remove the database file
-		os.Remove(dbPath)
-		return fmt.Errorf("failed to run migrations on database %s: %w", name, err)
 	}
 
-	log.Printf("Successfully created and initialized database: %s", name)
+	log.Printf("Dropped database %s", name)
 	return nil
 }
 
-// runMigrations applies database migrations using goose
-func (mdm *MultiDatabaseManager) runMigrations(name string) error {
-	db, exists := mdm.connections[name]
+// ArchiveDatabase waits for in-flight requests to finish, then closes the
+// database and moves its file into the "archive" directory of the data path.
+func (mdm *MultiDatabaseManager) ArchiveDatabase(ctx context.Context, name string) (string, error) {
+	path, err := mdm.retire(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	archiveDir := filepath.Join(mdm.dataPath, "archive")
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory %s: %w", archiveDir, err)
+	}
+
+	archivePath := filepath.Join(archiveDir, fmt.Sprintf("%s-%s.db", name, time.Now().UTC().Format("20060102150405")))
+	if err := os.Rename(path, archivePath); err != nil {
+		return "", fmt.Errorf("failed to archive database %s: %w", name, err)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		os.Remove(path + suffix)
+	}
+
+	log.Printf("Archived database %s to %s", name, archivePath)
+	return archivePath, nil
+}
+
+// retire blocks new leases on a database, waits until existing ones are
+// released and closes it. It returns the path of the database file.
+func (mdm *MultiDatabaseManager) retire(ctx context.Context, name string) (string, error) {
+	if name == PrimaryDatabaseName {
+		return "", fmt.Errorf("the primary database cannot be dropped or archived")
+	}
+
+	path := filepath.Join(mdm.dataPath, name+".db")
+
+	mdm.mu.Lock()
+	entry, exists := mdm.databases[name]
 	if !exists {
-		return fmt.Errorf("database connection %s not found", name)
+		mdm.mu.Unlock()
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("database %s not found: %w", name, err)
+		}
+		return path, nil
+	}
+	if entry.retiring {
+		mdm.mu.Unlock()
+		return "", ErrDatabaseUnavailable
+	}
+	entry.retiring = true
+	var drained chan struct{}
+	if entry.refs > 0 {
+		drained = make(chan struct{})
+		entry.drained = drained
+	}
+	mdm.mu.Unlock()
+
+	if drained != nil {
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			mdm.mu.Lock()
+			entry.retiring = false
+			entry.drained = nil
+			mdm.mu.Unlock()
+			return "", fmt.Errorf("database %s still in use: %w", name, ctx.Err())
+		}
 	}
 
-	// Set up goose with embedded migrations
-	goose.SetBaseFS(embedMigrations)
+	<-entry.ready
 
-	// Set dialect for SQLite
-	if err := goose.SetDialect("sqlite3"); err != nil {
-		return fmt.Errorf("failed to set goose dialect: %w", err)
+	mdm.mu.Lock()
+	delete(mdm.databases, name)
+	mdm.mu.Unlock()
+
+	if entry.db != nil {
+		if err := entry.db.Close(); err != nil {
+			return "", fmt.Errorf("failed to close database %s: %w", name, err)
+		}
 	}
+	return path, nil
+}
 
-	// Apply all migrations
-	if err := goose.Up(db, "database/migrations"); err != nil {
-		return fmt.Errorf("failed to apply migrations: %w", err)
+// StartIdleEviction periodically closes databases that have not been used
+// for the configured idle timeout. Close stops it.
+func (mdm *MultiDatabaseManager) StartIdleEviction(interval time.Duration) {
+	if mdm.idleTimeout <= 0 {
+		return
 	}
 
-	return nil
+	mdm.mu.Lock()
+	if mdm.stopEvict != nil || mdm.closed {
+		mdm.mu.Unlock()
+		return
+	}
+	mdm.stopEvict = make(chan struct{})
+	mdm.evictDone = make(chan struct{})
+	stop, done := mdm.stopEvict, mdm.evictDone
+	mdm.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				mdm.evictIdle()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (mdm *MultiDatabaseManager) evictIdle() {
+	cutoff := time.Now().Add(-mdm.idleTimeout)
+
+	mdm.mu.Lock()
+	var toClose []*sql.DB
+	for name, entry := range mdm.databases {
+		if mdm.evictableLocked(entry) && entry.lastUsed.Before(cutoff) {
+			delete(mdm.databases, name)
+			toClose = append(toClose, entry.db)
+			log.Printf("Closing idle database %s", name)
+		}
+	}
+	mdm.mu.Unlock()
+
+	closeDatabases(toClose)
 }
 
 func (mdm *MultiDatabaseManager) Close() error {
+	mdm.mu.Lock()
+	mdm.closed = true
+	stop, done := mdm.stopEvict, mdm.evictDone
+	mdm.stopEvict = nil
+	databases := mdm.databases
+	mdm.databases = make(map[string]*managedDatabase)
+	mdm.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+
 	var errors []string
 
-	for name, db := range mdm.connections {
-		if err := db.Close(); err != nil {
+	for name, entry := range databases {
+		<-entry.ready
+		if entry.db == nil {
+			continue
+		}
+		if err := entry.db.Close(); err != nil {
 			errors = append(errors, fmt.Sprintf("failed to close database %s: %v", name, err))
 		}
 	}
 
-	mdm.connections = make(map[string]*sql.DB)
-	mdm.queries = make(map[string]*database.Queries)
-
 	if len(errors) > 0 {
 		return fmt.Errorf("errors closing databases: %s", strings.Join(errors, "; "))
 	}
 
 	return nil
 }
+
+type leaseSetKey struct{}
+
+// leaseSet collects the database leases taken while serving one request.
+type leaseSet struct {
+	mu     sync.Mutex
+	leases map[string]*DatabaseLease
+}
+
+// WithDatabaseLeases returns a context in which databases acquired through
+// QueriesForContext stay open until the returned release func is called.
+// Background jobs use it the way the interceptors do for requests.
+func WithDatabaseLeases(ctx context.Context) (context.Context, func()) {
+	set := &leaseSet{leases: make(map[string]*DatabaseLease)}
+	release := func() {
+		set.mu.Lock()
+		defer set.mu.Unlock()
+		for name, lease := range set.leases {
+			lease.Release()
+			delete(set.leases, name)
+		}
+	}
+	return context.WithValue(ctx, leaseSetKey{}, set), release
+}
+
+// UnaryInterceptor holds every database a request touches open until it returns.
+func (mdm *MultiDatabaseManager) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, release := WithDatabaseLeases(ctx)
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor holds every database a stream touches open until it ends.
+func (mdm *MultiDatabaseManager) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, release := WithDatabaseLeases(stream.Context())
+		defer release()
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
@@ -0,0 +1,237 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T, maxOpen int, databases ...string) *MultiDatabaseManager {
+	t.Helper()
+
+	s := newTestServer(t, map[string]string{"FUWA_DB_MAX_OPEN": fmt.Sprint(maxOpen)})
+	for _, name := range databases {
+		if err := s.manager.CreateDatabase(name); err != nil {
+			t.Fatalf("CreateDatabase: %v", err)
+		}
+	}
+	return s.manager
+}
+
+func isOpen(m *MultiDatabaseManager, name string) bool {
+	return slices.Contains(m.ListDatabases(), name)
+}
+
+func mustDatabaseFiles(t *testing.T, m *MultiDatabaseManager) []string {
+	t.Helper()
+	names, err := m.DatabaseFiles()
+	if err != nil {
+		t.Fatalf("DatabaseFiles: %v", err)
+	}
+	return names
+}
+
+func TestLeaseBlocksIdleEviction(t *testing.T) {
+	m := newTestManager(t, 8, "srv1")
+	m.idleTimeout = time.Nanosecond
+
+	lease, err := m.Acquire("srv1")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	m.evictIdle()
+	if !isOpen(m, "srv1") {
+		t.Fatal("leased database was evicted")
+	}
+	if err := lease.DB.Ping(); err != nil {
+		t.Fatalf("leased handle unusable: %v", err)
+	}
+
+	lease.Release()
+	lease.Release() // releasing twice must not drop another lease's reference
+	time.Sleep(time.Millisecond)
+	m.evictIdle()
+	if isOpen(m, "srv1") {
+		t.Fatal("idle database was not evicted")
+	}
+	if !isOpen(m, PrimaryDatabaseName) {
+		t.Fatal("primary database was evicted")
+	}
+}
+
+func TestMaxOpenEvictsLeastRecentlyUsed(t *testing.T) {
+	m := newTestManager(t, 2, "srv1", "srv2", "srv3")
+
+	for _, name := range []string{"srv1", "srv2"} {
+		lease, err := m.Acquire(name)
+		if err != nil {
+			t.Fatalf("Acquire %s: %v", name, err)
+		}
+		lease.Release()
+		time.Sleep(time.Millisecond)
+	}
+
+	// srv1 was used longest ago
+	lease, err := m.Acquire("srv3")
+	if err != nil {
+		t.Fatalf("Acquire srv3: %v", err)
+	}
+	defer lease.Release()
+	if isOpen(m, "srv1") || !isOpen(m, "srv2") {
+		t.Fatalf("open databases %v, want srv1 evicted", m.ListDatabases())
+	}
+
+	// With every slot leased there is nothing to evict
+	held, err := m.Acquire("srv2")
+	if err != nil {
+		t.Fatalf("Acquire srv2: %v", err)
+	}
+	defer held.Release()
+	if _, err := m.Acquire("srv1"); !errors.Is(err, ErrTooManyDatabases) {
+		t.Fatalf("Acquire with every slot leased: got %v, want ErrTooManyDatabases", err)
+	}
+}
+
+func TestBackgroundLeaseDoesNotKeepDatabaseOpen(t *testing.T) {
+	m := newTestManager(t, 8, "srv1")
+	m.idleTimeout = time.Hour
+
+	if err := m.CloseDatabase("srv1"); err != nil {
+		t.Fatalf("CloseDatabase: %v", err)
+	}
+	lease, err := m.AcquireBackground("srv1")
+	if err != nil {
+		t.Fatalf("AcquireBackground: %v", err)
+	}
+	lease.Release()
+
+	// A database only a background job opened counts as idle right away
+	m.evictIdle()
+	if isOpen(m, "srv1") {
+		t.Fatal("database opened in the background was not evicted")
+	}
+}
+
+func TestAcquireUnknownDatabase(t *testing.T) {
+	m := newTestManager(t, 8)
+
+	if _, err := m.Acquire("missing"); !errors.Is(err, ErrDatabaseNotFound) {
+		t.Fatalf("Acquire: got %v, want ErrDatabaseNotFound", err)
+	}
+	if slices.Contains(mustDatabaseFiles(t, m), "missing") {
+		t.Fatal("Acquire created the database")
+	}
+
+	if _, err := m.QueriesForContext(context.Background(), PrimaryDatabaseName); !errors.Is(err, ErrNoDatabaseLeases) {
+		t.Fatalf("QueriesForContext without leases: got %v, want ErrNoDatabaseLeases", err)
+	}
+}
+
+func TestDropDatabaseWaitsForLeases(t *testing.T) {
+	m := newTestManager(t, 8, "srv1")
+
+	lease, err := m.Acquire("srv1")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	dropped := make(chan error, 1)
+	go func() { dropped <- m.DropDatabase(context.Background(), "srv1") }()
+
+	// New leases are refused while the drop waits for the old one
+	deadline := time.Now().Add(time.Second)
+	for {
+		other, err := m.Acquire("srv1")
+		if errors.Is(err, ErrDatabaseUnavailable) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Acquire: %v", err)
+		}
+		// Taken before the drop started
+		other.Release()
+		if time.Now().After(deadline) {
+			t.Fatal("drop never started")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case err := <-dropped:
+		t.Fatalf("drop finished while leased: %v", err)
+	default:
+	}
+	if err := lease.DB.Ping(); err != nil {
+		t.Fatalf("leased handle closed during drop: %v", err)
+	}
+
+	lease.Release()
+	if err := <-dropped; err != nil {
+		t.Fatalf("DropDatabase: %v", err)
+	}
+	if slices.Contains(mustDatabaseFiles(t, m), "srv1") {
+		t.Fatal("dropped database still has a file")
+	}
+}
+
+func TestConcurrentLeasesAndEviction(t *testing.T) {
+	names := []string{"srv1", "srv2", "srv3", "srv4"}
+	m := newTestManager(t, 2, names...)
+	m.idleTimeout = time.Nanosecond
+
+	stop := make(chan struct{})
+	evicted := make(chan struct{})
+	go func() {
+		defer close(evicted)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				m.evictIdle()
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for worker := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				name := names[(worker+i)%len(names)]
+				lease, err := m.Acquire(name)
+				if errors.Is(err, ErrTooManyDatabases) {
+					continue
+				}
+				if err != nil {
+					t.Errorf("Acquire %s: %v", name, err)
+					return
+				}
+				var one int
+				if err := lease.DB.QueryRow("SELECT 1").Scan(&one); err != nil {
+					t.Errorf("query on leased %s: %v", name, err)
+				}
+				lease.Release()
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	<-evicted
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, entry := range m.databases {
+		if entry.refs != 0 {
+			t.Errorf("%s has %d references after every lease was released", name, entry.refs)
+		}
+	}
+	if open := m.openCountLocked(); open > 2 {
+		t.Errorf("%d databases open, want at most 2", open)
+	}
+}
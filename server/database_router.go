@@ -0,0 +1,257 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/waifu-devs/fuwa/server/database"
+)
+
+var (
+	ErrInvalidServerID  = errors.New("server_id must be 1-64 characters of letters, digits, '_' or '-', other than " + PrimaryDatabaseName)
+	ErrNoDatabase       = errors.New("database not available")
+	ErrResourceNotFound = errors.New("resource not found")
+)
+
+// Server IDs double as database file names, so keep them filesystem safe
+var serverIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// validServerID reports whether a server ID can name its database file. The
+// primary database's name is reserved, compared case-insensitively since
+// file names are on Windows and macOS.
+func validServerID(serverID string) bool {
+	return serverIDPattern.MatchString(serverID) && !strings.EqualFold(serverID, PrimaryDatabaseName)
+}
+
+// DatabaseRouter resolves the per-server database a request operates on.
+// Each server lives in its own "<server_id>.db"; the primary database keeps
+// the routes from channel and message IDs to their server.
+type DatabaseRouter struct {
+	manager *MultiDatabaseManager
+}
+
+func NewDatabaseRouter(manager *MultiDatabaseManager) *DatabaseRouter {
+	return &DatabaseRouter{
+		manager: manager,
+	}
+}
+
+// Primary returns the instance-wide database, or the transaction open on it in ctx.
+func (r *DatabaseRouter) Primary(ctx context.Context) (*database.Queries, error) {
+	if r == nil || r.manager == nil {
+		return nil, ErrNoDatabase
+	}
+	if tx, ok := ctx.Value(txKey{PrimaryDatabaseName}).(*database.Queries); ok {
+		return tx, nil
+	}
+	queries, err := r.manager.GetPrimaryQueries()
+	if err != nil {
+		return nil, err
+	}
+	if queries == nil {
+		return nil, ErrNoDatabase
+	}
+	return queries, nil
+}
+
+// ForServer returns the database of an existing server, failing with
+// ErrDatabaseNotFound for servers that have none. The database stays open
+// until the request in ctx finishes.
+func (r *DatabaseRouter) ForServer(ctx context.Context, serverID string) (*database.Queries, error) {
+	if !validServerID(serverID) {
+		return nil, ErrInvalidServerID
+	}
+	if r == nil || r.manager == nil {
+		return nil, ErrNoDatabase
+	}
+	return r.manager.QueriesForContext(ctx, serverID)
+}
+
+// CreateServer is ForServer for creating a server's first channel: it creates
+// and migrates the database if the server has none yet. Reads never create
+// databases, so unknown servers stay NotFound everywhere else.
+func (r *DatabaseRouter) CreateServer(ctx context.Context, serverID string) (*database.Queries, error) {
+	if !validServerID(serverID) {
+		return nil, ErrInvalidServerID
+	}
+	if r == nil || r.manager == nil {
+		return nil, ErrNoDatabase
+	}
+	if tx, ok := ctx.Value(txKey{serverID}).(*database.Queries); ok {
+		return tx, nil
+	}
+
+	lease, err := r.manager.leaseForContext(ctx, serverID, acquireCreate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database %s: %w", serverID, err)
+	}
+	return lease.Queries, nil
+}
+
+// ServerForResource returns the server that owns a channel or message.
+func (r *DatabaseRouter) ServerForResource(ctx context.Context, resourceID string) (string, error) {
+	primary, err := r.Primary(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	serverID, err := primary.GetDatabaseRoute(ctx, resourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrResourceNotFound
+		}
+		return "", fmt.Errorf("failed to get database route: %w", err)
+	}
+	return serverID, nil
+}
+
+// ForResource returns the database of the server owning a channel or message.
+func (r *DatabaseRouter) ForResource(ctx context.Context, resourceID string) (*database.Queries, string, error) {
+	serverID, err := r.ServerForResource(ctx, resourceID)
+	if err != nil {
+		return nil, "", err
+	}
+	queries, err := r.ForServer(ctx, serverID)
+	if err != nil {
+		return nil, "", err
+	}
+	return queries, serverID, nil
+}
+
+// ForScope maps an event or config scope to a database: "server:<id>" and
+// "channel:<id>" live with their server, everything else in the primary.
+func (r *DatabaseRouter) ForScope(ctx context.Context, scope string) (*database.Queries, error) {
+	name, err := r.databaseForScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	if name == PrimaryDatabaseName {
+		return r.Primary(ctx)
+	}
+	return r.manager.QueriesForContext(ctx, name)
+}
+
+type txKey struct {
+	database string
+}
+
+// InScopeTx runs fn in a transaction on the database a scope lives in,
+// committing if fn returns nil. Within fn, ctx routes every lookup on that
+// database to the transaction; a nested call joins the outer transaction.
+func (r *DatabaseRouter) InScopeTx(ctx context.Context, scope string, fn func(ctx context.Context, tx *database.Queries) error) error {
+	if r == nil || r.manager == nil {
+		return ErrNoDatabase
+	}
+	name, err := r.databaseForScope(ctx, scope)
+	if err != nil {
+		return err
+	}
+	if tx, ok := ctx.Value(txKey{name}).(*database.Queries); ok {
+		return fn(ctx, tx)
+	}
+
+	// Outside a request, hold the databases the transaction touches until it ends
+	if _, ok := ctx.Value(leaseSetKey{}).(*leaseSet); !ok {
+		var release func()
+		ctx, release = WithDatabaseLeases(ctx)
+		defer release()
+	}
+
+	lease, err := r.manager.leaseForContext(ctx, name, acquireExisting)
+	if err != nil {
+		return err
+	}
+
+	tx, err := lease.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	txQueries := lease.Queries.WithTx(tx)
+	if err := fn(context.WithValue(ctx, txKey{name}, txQueries), txQueries); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *DatabaseRouter) databaseForScope(ctx context.Context, scope string) (string, error) {
+	kind, id, _ := strings.Cut(scope, ":")
+	switch {
+	case kind == "server" && id != "":
+		if !validServerID(id) {
+			return "", ErrInvalidServerID
+		}
+		return id, nil
+	case kind == "channel" && id != "":
+		serverID, err := r.ServerForResource(ctx, id)
+		if err == ErrResourceNotFound {
+			return PrimaryDatabaseName, nil
+		}
+		return serverID, err
+	}
+	return PrimaryDatabaseName, nil
+}
+
+// AddRoute records which server a newly created channel or message belongs to.
+func (r *DatabaseRouter) AddRoute(ctx context.Context, resourceID, serverID string) error {
+	primary, err := r.Primary(ctx)
+	if err != nil {
+		return err
+	}
+	return primary.CreateDatabaseRoute(ctx, database.CreateDatabaseRouteParams{
+		ResourceID: resourceID,
+		ServerID:   serverID,
+		CreatedAt:  time.Now().Unix(),
+	})
+}
+
+// RemoveRoute forgets a deleted resource.
+func (r *DatabaseRouter) RemoveRoute(ctx context.Context, resourceID string) error {
+	primary, err := r.Primary(ctx)
+	if err != nil {
+		return err
+	}
+	return primary.DeleteDatabaseRoute(ctx, resourceID)
+}
+
+// txError converts the error of an InScopeTx call to a gRPC status error;
+// status errors returned by the transaction body pass through unchanged.
+func txError(err error, action string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, ErrResourceNotFound), errors.Is(err, ErrDatabaseNotFound), errors.Is(err, ErrInvalidServerID), errors.Is(err, ErrNoDatabase),
+		errors.Is(err, ErrDatabaseUnavailable), errors.Is(err, ErrDatabaseClosed), errors.Is(err, ErrTooManyDatabases):
+		return routeError(err, "scope not found")
+	}
+	return status.Errorf(codes.Internal, "failed to %s: %v", action, err)
+}
+
+// routeError converts routing failures to gRPC status errors; notFound is
+// the message used when the resource has no route.
+func routeError(err error, notFound string) error {
+	switch {
+	case errors.Is(err, ErrResourceNotFound), errors.Is(err, ErrDatabaseNotFound):
+		return status.Error(codes.NotFound, notFound)
+	case errors.Is(err, ErrInvalidServerID):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrNoDatabase), errors.Is(err, ErrDatabaseUnavailable), errors.Is(err, ErrDatabaseClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, ErrTooManyDatabases):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to resolve database: %v", err)
+}
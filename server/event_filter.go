@@ -0,0 +1,137 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// filterCostLimit bounds the work a single filter evaluation may do, so a
+// subscriber can't make broadcasting expensive with a pathological filter.
+const filterCostLimit = 10000
+
+// Event filters are CEL expressions (https://cel.dev) evaluated against each
+// event. They can reference:
+//
+//	event_type string               event type, e.g. "message.sent"
+//	scope      string               scope the event was published in
+//	actor      string               user ID of the actor
+//	sequence   int                  sequence number within the scope
+//	timestamp  google.protobuf.Timestamp
+//	metadata   map(string, string)
+//	payload    the decoded payload, e.g. payload.message.content for
+//	           message.sent; an empty map for events without payload
+//	caller     string               user ID of the subscriber, "" if anonymous
+//
+// Examples:
+//
+//	event_type == "message.sent" && payload.message.content.contains("@" + caller)
+//	event_type.startsWith("config.") && payload.key.startsWith("appearance.")
+var (
+	filterEnvOnce sync.Once
+	filterEnv     *cel.Env
+	filterEnvErr  error
+)
+
+func eventFilterEnv() (*cel.Env, error) {
+	filterEnvOnce.Do(func() {
+		filterEnv, filterEnvErr = cel.NewEnv(
+			cel.TypeDescs(pb.File_types_proto),
+			cel.Variable("event_type", cel.StringType),
+			cel.Variable("scope", cel.StringType),
+			cel.Variable("actor", cel.StringType),
+			cel.Variable("sequence", cel.IntType),
+			cel.Variable("timestamp", cel.TimestampType),
+			cel.Variable("metadata", cel.MapType(cel.StringType, cel.StringType)),
+			cel.Variable("payload", cel.DynType),
+			cel.Variable("caller", cel.StringType),
+		)
+	})
+	return filterEnv, filterEnvErr
+}
+
+// eventFilter is a compiled filter expression, bound to the caller it is
+// evaluated for.
+type eventFilter struct {
+	program cel.Program
+	caller  string
+}
+
+// compileEventFilter type-checks a filter expression once so it can be
+// evaluated for many events. An empty expression yields a nil filter, which
+// matches everything.
+func compileEventFilter(expr, caller string) (*eventFilter, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	env, err := eventFilterEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up filter environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid filter: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("invalid filter: must evaluate to a bool, got %s", ast.OutputType())
+	}
+
+	program, err := env.Program(ast, cel.CostLimit(filterCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	return &eventFilter{program: program, caller: caller}, nil
+}
+
+// matches evaluates the filter for an event. Evaluation errors, such as a
+// missing payload field, count as no match.
+func (f *eventFilter) matches(event *pb.Event) bool {
+	if f == nil {
+		return true
+	}
+
+	var payload any = map[string]any{}
+	if event.Payload != nil {
+		if message, err := event.Payload.UnmarshalNew(); err == nil {
+			payload = message
+		}
+	}
+
+	metadata := event.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	timestamp := event.Timestamp
+	if timestamp == nil {
+		timestamp = &timestamppb.Timestamp{}
+	}
+
+	out, _, err := f.program.Eval(map[string]any{
+		"event_type": event.EventType,
+		"scope":      event.Scope,
+		"actor":      event.ActorId,
+		"sequence":   event.Sequence,
+		"timestamp":  timestamp,
+		"metadata":   metadata,
+		"payload":    payload,
+		"caller":     f.caller,
+	})
+	if err != nil {
+		return false
+	}
+
+	matched, ok := out.Value().(bool)
+	if !ok {
+		log.Printf("Event filter returned %T instead of bool", out.Value())
+		return false
+	}
+	return matched
+}
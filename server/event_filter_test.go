@@ -0,0 +1,89 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+func TestEventFilterMatches(t *testing.T) {
+	sent := &pb.Event{
+		EventType: "message.sent",
+		Scope:     "channel:ch_1",
+		ActorId:   "alice",
+		Sequence:  7,
+		Timestamp: timestamppb.New(time.Unix(1700000000, 0)),
+		Metadata:  map[string]string{"channel_id": "ch_1"},
+		Payload:   eventPayload(&pb.MessageSentPayload{Message: &pb.Message{MessageId: "msg_1", Content: "hi @bob"}}),
+	}
+	bare := &pb.Event{EventType: "app.ping", Scope: "server:srv1"}
+
+	tests := []struct {
+		name  string
+		expr  string
+		event *pb.Event
+		want  bool
+	}{
+		{name: "empty matches everything", event: bare, want: true},
+		{name: "event type", expr: `event_type == "message.sent"`, event: sent, want: true},
+		{name: "scope and actor", expr: `scope.startsWith("channel:") && actor == "alice"`, event: sent, want: true},
+		{name: "sequence", expr: `sequence > 7`, event: sent},
+		{name: "timestamp", expr: `timestamp < timestamp("2024-01-01T00:00:00Z")`, event: sent, want: true},
+		{name: "metadata", expr: `metadata.channel_id == "ch_1"`, event: sent, want: true},
+		{name: "payload field", expr: `payload.message.content.contains("@" + caller)`, event: sent, want: true},
+		{name: "caller", expr: `caller == "bob"`, event: bare, want: true},
+		// Evaluation errors count as no match
+		{name: "missing payload field", expr: `payload.message.content == "hi"`, event: bare},
+		{name: "missing metadata key", expr: `metadata.channel_id == "ch_1"`, event: bare},
+		{name: "nil timestamp and metadata", expr: `timestamp == timestamp(0) && size(metadata) == 0`, event: bare, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := compileEventFilter(tt.expr, "bob")
+			if err != nil {
+				t.Fatalf("compileEventFilter: %v", err)
+			}
+			if got := filter.matches(tt.event); got != tt.want {
+				t.Errorf("matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileEventFilterRejects(t *testing.T) {
+	for _, expr := range []string{
+		`event_type ==`,               // syntax error
+		`event_type`,                  // not a bool
+		`unknown_variable == "x"`,     // undeclared
+		`sequence == "7"`,             // type mismatch
+		`metadata.channel_id + 1 > 0`, // type mismatch
+	} {
+		if _, err := compileEventFilter(expr, ""); err == nil {
+			t.Errorf("compileEventFilter(%q) accepted an invalid filter", expr)
+		}
+	}
+}
+
+func TestEventFilterCostLimit(t *testing.T) {
+	const list = `[1,2,3,4,5,6,7,8,9,10]`
+	cheap, err := compileEventFilter(list+`.all(x, `+list+`.all(y, x + y > 0))`, "")
+	if err != nil {
+		t.Fatalf("compileEventFilter: %v", err)
+	}
+	if !cheap.matches(&pb.Event{EventType: "app.ping"}) {
+		t.Error("filter within the cost limit didn't match")
+	}
+
+	// Nesting the same loop twice more goes over the limit
+	costly, err := compileEventFilter(list+`.all(x, `+list+`.all(y, `+list+`.all(z, `+list+`.all(w, x + y + z + w > 0))))`, "")
+	if err != nil {
+		t.Fatalf("compileEventFilter: %v", err)
+	}
+	if costly.matches(&pb.Event{EventType: "app.ping"}) {
+		t.Error("filter over the cost limit matched")
+	}
+}
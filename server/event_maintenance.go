@@ -0,0 +1,319 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/waifu-devs/fuwa/server/database"
+)
+
+// Retention rules are set with FUWA_EVENT_RETENTION as ";" separated rules
+// of space separated key=value fields:
+//
+//	scope=<scope pattern>     scopes the rule applies to (default "*")
+//	type=<event type pattern> event types it applies to (default all)
+//	max_age=<duration>        delete events older than this
+//	max_count=<n>             keep the newest n events of each type per scope
+//
+// e.g. "scope=channel:* type=presence.* max_age=2160h; max_count=100000".
+// Every matching rule applies, so the strictest limit wins. Events still
+// waiting in the outbox are never deleted, and neither are the event types a
+// projection handles: a replay rebuilds the read models from them, so
+// deleting any would make it silently lose channels, messages or config.
+// Neither retention nor config compaction deletes events past the position
+// of a consumer group of the scope, so groups never skip unacked events; a
+// group that stops consuming holds its scope's log back until it resumes.
+type retentionRule struct {
+	scope     pattern
+	eventType pattern
+	maxAge    time.Duration
+	maxCount  int64
+}
+
+func parseRetentionRules(raw string) ([]retentionRule, error) {
+	var rules []retentionRule
+	for _, rawRule := range strings.Split(raw, ";") {
+		fields := strings.Fields(rawRule)
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule := retentionRule{scope: pattern{"*"}}
+		for _, field := range fields {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid retention field %q, expected key=value", field)
+			}
+
+			var err error
+			switch key {
+			case "scope":
+				rule.scope, err = parseScopePattern(value)
+			case "type":
+				rule.eventType, err = parseEventTypePattern(value)
+				if err == nil && !rule.eventType.hasWildcard() && isProjectedEventType(value) {
+					err = fmt.Errorf("%s events are kept for replay and can't be deleted by retention", value)
+				}
+			case "max_age":
+				rule.maxAge, err = time.ParseDuration(value)
+				if err == nil && rule.maxAge <= 0 {
+					err = fmt.Errorf("max_age must be positive, got %s", value)
+				}
+			case "max_count":
+				rule.maxCount, err = strconv.ParseInt(value, 10, 64)
+				if err == nil && rule.maxCount <= 0 {
+					err = fmt.Errorf("max_count must be positive, got %s", value)
+				}
+			default:
+				err = fmt.Errorf("unknown retention field %q", key)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if rule.maxAge == 0 && rule.maxCount == 0 {
+			return nil, fmt.Errorf("retention rule %q needs max_age or max_count", strings.TrimSpace(rawRule))
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// eventMaintenance is the background job that keeps the event log bounded:
+// it applies the retention rules, compacts config events down to the latest
+// per key, and refreshes the state snapshots of server and channel scopes.
+type eventMaintenance struct {
+	events        *eventServiceServer
+	router        *DatabaseRouter
+	rules         []retentionRule
+	snapshotEvery int64
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewEventMaintenance(config *Config, router *DatabaseRouter, eventService *eventServiceServer) (*eventMaintenance, error) {
+	rules, err := parseRetentionRules(config.EventRetention)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventMaintenance{
+		events:        eventService,
+		router:        router,
+		rules:         rules,
+		snapshotEvery: int64(config.SnapshotEvery),
+	}, nil
+}
+
+// Start runs the job every interval until Stop is called.
+func (m *eventMaintenance) Start(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	if m.stop != nil {
+		m.mu.Unlock()
+		return
+	}
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+	stop, done := m.stop, m.done
+	m.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.Run(context.Background())
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the job started by Start.
+func (m *eventMaintenance) Stop() {
+	m.mu.Lock()
+	stop, done := m.stop, m.done
+	m.stop = nil
+	m.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Run maintains the event log of every database file once.
+func (m *eventMaintenance) Run(ctx context.Context) {
+	if m.router == nil || m.router.manager == nil {
+		return
+	}
+
+	names, err := m.router.manager.DatabaseFiles()
+	if err != nil {
+		log.Printf("Failed to list databases to maintain: %v", err)
+		return
+	}
+
+	for _, name := range names {
+		if err := m.maintainDatabase(ctx, name); err != nil {
+			log.Printf("Failed to maintain events of database %s: %v", name, err)
+		}
+	}
+}
+
+func (m *eventMaintenance) maintainDatabase(ctx context.Context, name string) error {
+	lease, err := m.router.manager.AcquireBackground(name)
+	if err != nil {
+		return err
+	}
+	defer lease.Release()
+
+	scopes, err := lease.Queries.ListEventScopes(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deleted, compacted int64
+	for _, scope := range scopes {
+		n, err := m.applyRetention(ctx, lease.Queries, scope)
+		if err != nil {
+			return fmt.Errorf("retention of %s: %w", scope, err)
+		}
+		deleted += n
+
+		n, err = lease.Queries.CompactConfigEvents(ctx, scope)
+		if err != nil {
+			return fmt.Errorf("compaction of %s: %w", scope, err)
+		}
+		compacted += n
+
+		if err := m.refreshSnapshot(ctx, lease.Queries, scope); err != nil {
+			log.Printf("Failed to snapshot %s: %v", scope, err)
+		}
+	}
+
+	if deleted > 0 || compacted > 0 {
+		log.Printf("Event maintenance of database %s deleted %d expired and %d compacted events", name, deleted, compacted)
+	}
+	return nil
+}
+
+func (m *eventMaintenance) applyRetention(ctx context.Context, db *database.Queries, scope string) (int64, error) {
+	if len(m.rules) == 0 {
+		return 0, nil
+	}
+
+	path := m.events.scopePath(scope)
+	eventTypes, err := db.ListEventTypesByScope(ctx, scope)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, rule := range m.rules {
+		if !rule.scope.matches(path) {
+			continue
+		}
+		for _, eventType := range eventTypes {
+			if isProjectedEventType(eventType) {
+				continue
+			}
+			if rule.eventType != nil && !rule.eventType.matches(strings.Split(eventType, ".")) {
+				continue
+			}
+
+			if rule.maxAge > 0 {
+				n, err := db.DeleteEventsBefore(ctx, database.DeleteEventsBeforeParams{
+					Scope:     scope,
+					EventType: eventType,
+					Timestamp: time.Now().Add(-rule.maxAge).Unix(),
+				})
+				if err != nil {
+					return deleted, err
+				}
+				deleted += n
+			}
+
+			if rule.maxCount > 0 {
+				// Everything up to the first event past the newest maxCount goes
+				oldest, err := db.GetNthNewestEventSequence(ctx, database.GetNthNewestEventSequenceParams{
+					Scope:     scope,
+					EventType: eventType,
+					Offset:    rule.maxCount,
+				})
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				if err != nil {
+					return deleted, err
+				}
+				n, err := db.DeleteEventsUpToSequence(ctx, database.DeleteEventsUpToSequenceParams{
+					Scope:     scope,
+					EventType: eventType,
+					Sequence:  oldest,
+				})
+				if err != nil {
+					return deleted, err
+				}
+				deleted += n
+			}
+		}
+	}
+	return deleted, nil
+}
+
+// refreshSnapshot takes a new snapshot of a scope once snapshotEvery events
+// were appended since the last one.
+func (m *eventMaintenance) refreshSnapshot(ctx context.Context, db *database.Queries, scope string) error {
+	if m.snapshotEvery <= 0 || !snapshotScope(scope) {
+		return nil
+	}
+
+	latest, err := db.GetLastEventSequence(ctx, scope)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	var snapshotted int64
+	snapshot, err := db.GetEventSnapshot(ctx, scope)
+	switch {
+	case err == nil:
+		snapshotted = snapshot.Sequence
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+	if latest-snapshotted < m.snapshotEvery {
+		return nil
+	}
+
+	_, err = m.events.takeSnapshot(ctx, scope)
+	if status.Code(err) == codes.NotFound {
+		// The channel was deleted; its events remain until retention
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+func TestRetentionKeepsUnackedEvents(t *testing.T) {
+	s := newTestServer(t, map[string]string{"FUWA_EVENT_RETENTION": "type=app.* max_count=1"})
+	alice := s.as(t, "alice")
+	channel, err := s.channels.CreateChannel(alice, &pb.CreateChannelRequest{Name: "general", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1"})
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	publish := func(scope string) []int64 {
+		t.Helper()
+		var sequences []int64
+		for range 4 {
+			resp, err := s.events.Publish(alice, &pb.PublishRequest{Event: &pb.Event{Scope: scope, EventType: "app.ping"}})
+			if err != nil {
+				t.Fatalf("Publish: %v", err)
+			}
+			sequences = append(sequences, resp.Sequence)
+		}
+		return sequences
+	}
+	remaining := func(scope string) []int64 {
+		t.Helper()
+		var sequences []int64
+		for _, event := range storedEvents(t, s, alice, scope) {
+			if event.EventType == "app.ping" {
+				sequences = append(sequences, event.Sequence)
+			}
+		}
+		return sequences
+	}
+
+	// A group on the server scope has acked the first ping only
+	consumed := publish("server:srv1")
+	free := publish("channel:" + channel.Channel.ChannelId)
+	lease, err := s.manager.Acquire("srv1")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	err = lease.Queries.SetConsumerOffset(context.Background(), database.SetConsumerOffsetParams{
+		GroupName:     "workers",
+		Scope:         "server:srv1",
+		AckedSequence: consumed[0],
+		UpdatedAt:     time.Now().Unix(),
+	})
+	lease.Release()
+	if err != nil {
+		t.Fatalf("SetConsumerOffset: %v", err)
+	}
+
+	s.events.dispatchOutbox(true)
+	maintenance, err := NewEventMaintenance(s.config, s.router, s.events)
+	if err != nil {
+		t.Fatalf("NewEventMaintenance: %v", err)
+	}
+	maintenance.Run(context.Background())
+
+	if got := remaining("server:srv1"); !slices.Equal(got, consumed[1:]) {
+		t.Errorf("server scope kept pings %v, want the unacked %v", got, consumed[1:])
+	}
+	if got := remaining("channel:" + channel.Channel.ChannelId); !slices.Equal(got, free[3:]) {
+		t.Errorf("channel scope kept pings %v, want only the newest %v", got, free[3:])
+	}
+}
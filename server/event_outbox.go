@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// outboxBatchSize bounds how many events the dispatcher loads at once.
+const outboxBatchSize = 100
+
+// outboxSweepEvery is how many dispatcher ticks pass between visits to every
+// database file rather than just the open ones. Opening every file each tick
+// would keep them all open; events are only queued in closed databases when
+// one is closed before the dispatcher got to it, or by a previous run.
+const outboxSweepEvery = 60
+
+// appendEvent stores an event in the transaction of the write it describes
+// and queues it in the outbox. Live subscribers only see it once the
+// transaction commits and the dispatcher picks it up, so the event log and
+// the tables never disagree. Callers call notify after committing.
+func (s *eventServiceServer) appendEvent(ctx context.Context, tx *database.Queries, event *pb.Event) error {
+	if s == nil {
+		return nil
+	}
+
+	if event.EventId == "" {
+		event.EventId = id.New(id.Event)
+	}
+	if event.Timestamp == nil {
+		event.Timestamp = timestamppb.Now()
+	}
+
+	payloadJSON, err := encodeEventPayload(event.Payload)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid payload: %v", err)
+	}
+
+	// Convert metadata to JSON
+	var metadataJSON string
+	if len(event.Metadata) > 0 {
+		metadataBytes, err := json.Marshal(event.Metadata)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to marshal metadata: %v", err)
+		}
+		metadataJSON = string(metadataBytes)
+	}
+
+	// The sequence is allocated in the same transaction as the insert, so
+	// sequences are unique per scope and become visible in order
+	sequence, err := tx.NextEventSequence(ctx, event.Scope)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get next sequence: %v", err)
+	}
+	event.Sequence = sequence
+
+	_, err = tx.CreateEvent(ctx, database.CreateEventParams{
+		EventID:   event.EventId,
+		EventType: event.EventType,
+		Scope:     event.Scope,
+		ActorID:   event.ActorId,
+		Timestamp: event.Timestamp.AsTime().Unix(),
+		Payload:   sql.NullString{String: payloadJSON, Valid: payloadJSON != ""},
+		Metadata:  sql.NullString{String: metadataJSON, Valid: metadataJSON != ""},
+		Sequence:  event.Sequence,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to store event: %v", err)
+	}
+
+	err = tx.CreateOutboxEntry(ctx, database.CreateOutboxEntryParams{
+		EventID:   event.EventId,
+		CreatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to queue event: %v", err)
+	}
+
+	return nil
+}
+
+// notify wakes the dispatcher after a transaction with events committed.
+func (s *eventServiceServer) notify() {
+	if s == nil {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// StartDispatcher fans committed events out to live subscribers. It runs
+// whenever notify is called and every interval. The first run and every
+// outboxSweepEvery-th tick visit every database file, which picks up events
+// left in the outbox of databases that aren't open.
+func (s *eventServiceServer) StartDispatcher(interval time.Duration) {
+	s.mu.Lock()
+	if s.stopDispatch != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.stopDispatch = make(chan struct{})
+	s.dispatchDone = make(chan struct{})
+	stop, done := s.stopDispatch, s.dispatchDone
+	s.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		sweep := true
+		ticks := 0
+		for {
+			s.dispatchOutbox(sweep)
+			sweep = false
+
+			select {
+			case <-s.wake:
+			case <-ticker.C:
+				ticks++
+				sweep = ticks%outboxSweepEvery == 0
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopDispatcher stops the dispatcher started by StartDispatcher.
+func (s *eventServiceServer) StopDispatcher() {
+	s.mu.Lock()
+	stop, done := s.stopDispatch, s.dispatchDone
+	s.stopDispatch = nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// dispatchOutbox broadcasts the queued events of every open database, or of
+// every database file if sweep is set, in commit order and removes them from
+// the outbox.
+func (s *eventServiceServer) dispatchOutbox(sweep bool) {
+	if s.router == nil || s.router.manager == nil {
+		return
+	}
+
+	names := s.router.manager.ListDatabases()
+	if sweep {
+		var err error
+		if names, err = s.router.manager.DatabaseFiles(); err != nil {
+			log.Printf("Failed to list databases to dispatch: %v", err)
+			return
+		}
+	}
+
+	for _, name := range names {
+		if err := s.dispatchDatabase(name); err != nil {
+			log.Printf("Failed to dispatch events of database %s: %v", name, err)
+		}
+	}
+}
+
+func (s *eventServiceServer) dispatchDatabase(name string) error {
+	lease, err := s.router.manager.AcquireBackground(name)
+	if err != nil {
+		return err
+	}
+	defer lease.Release()
+
+	ctx := context.Background()
+	var lastID int64
+	for {
+		rows, err := lease.Queries.ListOutboxEvents(ctx, database.ListOutboxEventsParams{
+			ID:    lastID,
+			Limit: outboxBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			s.broadcastEvent(dbEventToProto(&row.Event))
+			lastID = row.ID
+		}
+
+		if len(rows) > 0 {
+			if err := lease.Queries.DeleteOutboxEntries(ctx, lastID); err != nil {
+				return err
+			}
+		}
+		if len(rows) < outboxBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// eventStream collects the events a subscriber is sent.
+type eventStream struct {
+	grpc.ServerStream
+	ctx context.Context
+
+	mu       sync.Mutex
+	events   []*pb.Event
+	received chan struct{}
+}
+
+func newEventStream(ctx context.Context) *eventStream {
+	return &eventStream{ctx: ctx, received: make(chan struct{}, 1)}
+}
+
+func (s *eventStream) Context() context.Context { return s.ctx }
+
+func (s *eventStream) Send(event *pb.Event) error {
+	s.mu.Lock()
+	s.events = append(s.events, event)
+	s.mu.Unlock()
+	select {
+	case s.received <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// waitFor returns the first n events sent, failing the test if they don't
+// arrive in time.
+func (s *eventStream) waitFor(t *testing.T, n int) []*pb.Event {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		s.mu.Lock()
+		if len(s.events) >= n {
+			events := s.events[:n]
+			s.mu.Unlock()
+			return events
+		}
+		got := len(s.events)
+		s.mu.Unlock()
+
+		select {
+		case <-s.received:
+		case <-timeout:
+			t.Fatalf("received %d events, want %d", got, n)
+		}
+	}
+}
+
+// subscribe runs Subscribe until the test ends and waits for it to register.
+func subscribe(t *testing.T, s *testServer, ctx context.Context, req *pb.SubscribeRequest) *eventStream {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream := newEventStream(ctx)
+	done := make(chan error, 1)
+	go func() { done <- s.events.Subscribe(req, stream) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s.events.mu.Lock()
+		registered := len(s.events.subscribers)
+		s.events.mu.Unlock()
+		if registered > 0 {
+			return stream
+		}
+		select {
+		case err := <-done:
+			t.Fatalf("Subscribe: %v", err)
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("subscriber never registered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// storedEvents pages through every stored event of a scope.
+func storedEvents(t *testing.T, s *testServer, ctx context.Context, scope string) []*pb.Event {
+	t.Helper()
+
+	var events []*pb.Event
+	from := int64(1)
+	for {
+		page, err := s.events.GetEvents(ctx, &pb.GetEventsRequest{Scope: scope, FromSequence: from, Limit: 100})
+		if err != nil {
+			t.Fatalf("GetEvents: %v", err)
+		}
+		events = append(events, page.Events...)
+		if !page.HasMore {
+			return events
+		}
+		from = page.NextSequence
+	}
+}
+
+func outboxLength(t *testing.T, s *testServer, name string) int {
+	t.Helper()
+
+	lease, err := s.manager.AcquireBackground(name)
+	if err != nil {
+		t.Fatalf("AcquireBackground: %v", err)
+	}
+	defer lease.Release()
+
+	rows, err := lease.Queries.ListOutboxEvents(context.Background(), database.ListOutboxEventsParams{ID: 0, Limit: 1000})
+	if err != nil {
+		t.Fatalf("ListOutboxEvents: %v", err)
+	}
+	return len(rows)
+}
+
+func requireContiguous(t *testing.T, events []*pb.Event) {
+	t.Helper()
+
+	last := make(map[string]int64)
+	for _, event := range events {
+		if want := last[event.Scope] + 1; event.Sequence != want {
+			t.Fatalf("%s: got sequence %d after %d, want %d", event.Scope, event.Sequence, last[event.Scope], want)
+		}
+		last[event.Scope] = event.Sequence
+	}
+}
+
+func newTestServerWithChannel(t *testing.T) (*testServer, string) {
+	t.Helper()
+
+	s := newTestServer(t, nil)
+	channel, err := s.channels.CreateChannel(s.as(t, "alice"), &pb.CreateChannelRequest{Name: "general", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1"})
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+	return s, channel.Channel.ChannelId
+}
+
+func TestConcurrentPublishesGetContiguousSequences(t *testing.T) {
+	s, channelID := newTestServerWithChannel(t)
+	alice := s.as(t, "alice")
+	scopes := []string{"server:srv1", "channel:" + channelID}
+
+	const workers, perWorker = 8, 20
+	var wg sync.WaitGroup
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWorker {
+				_, err := s.events.Publish(alice, &pb.PublishRequest{Event: &pb.Event{Scope: scopes[worker%len(scopes)], EventType: "app.ping"}})
+				if err != nil {
+					t.Errorf("Publish: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var published int
+	for _, scope := range scopes {
+		events := storedEvents(t, s, alice, scope)
+		requireContiguous(t, events)
+		for _, event := range events {
+			if event.EventType == "app.ping" {
+				published++
+			}
+		}
+	}
+	if published != workers*perWorker {
+		t.Fatalf("stored %d published events, want %d", published, workers*perWorker)
+	}
+}
+
+func TestRolledBackEventsAreNeitherStoredNorQueued(t *testing.T) {
+	s, _ := newTestServerWithChannel(t)
+	alice := s.as(t, "alice")
+	queued := outboxLength(t, s, "srv1")
+
+	errRollback := errors.New("rollback")
+	err := s.router.InScopeTx(alice, "server:srv1", func(ctx context.Context, tx *database.Queries) error {
+		if err := s.events.appendEvent(ctx, tx, &pb.Event{Scope: "server:srv1", EventType: "app.lost"}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("InScopeTx: %v", err)
+	}
+	if got := outboxLength(t, s, "srv1"); got != queued {
+		t.Fatalf("outbox holds %d events after rollback, want %d", got, queued)
+	}
+
+	// The rolled back sequence is reused, so the log has no gap
+	if _, err := s.events.Publish(alice, &pb.PublishRequest{Event: &pb.Event{Scope: "server:srv1", EventType: "app.kept"}}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	events := storedEvents(t, s, alice, "server:srv1")
+	requireContiguous(t, events)
+	for _, event := range events {
+		if event.EventType == "app.lost" {
+			t.Fatal("rolled back event was stored")
+		}
+	}
+}
+
+func TestDispatcherDeliversInSequenceOrder(t *testing.T) {
+	s, channelID := newTestServerWithChannel(t)
+	alice := s.as(t, "alice")
+
+	// Drain what creating the channel queued before anyone listens
+	s.events.dispatchOutbox(true)
+	stream := subscribe(t, s, alice, &pb.SubscribeRequest{Scopes: []string{"server:srv1", "channel:" + channelID}})
+
+	s.events.StartDispatcher(10 * time.Millisecond)
+	t.Cleanup(s.events.StopDispatcher)
+
+	const workers, perWorker = 4, 25
+	var wg sync.WaitGroup
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scope := "server:srv1"
+			if worker%2 == 1 {
+				scope = "channel:" + channelID
+			}
+			for range perWorker {
+				if _, err := s.events.Publish(alice, &pb.PublishRequest{Event: &pb.Event{Scope: scope, EventType: "app.ping"}}); err != nil {
+					t.Errorf("Publish: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	events := stream.waitFor(t, workers*perWorker)
+	last := make(map[string]int64)
+	for _, event := range events {
+		if event.Sequence <= last[event.Scope] {
+			t.Fatalf("%s: sequence %d delivered after %d", event.Scope, event.Sequence, last[event.Scope])
+		}
+		last[event.Scope] = event.Sequence
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for outboxLength(t, s, "srv1") > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("dispatched events stayed in the outbox")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDispatcherSweepsClosedDatabases(t *testing.T) {
+	s := newTestServer(t, nil)
+
+	// Release the request's leases so the database can close with events queued
+	ctx, release := WithDatabaseLeases(ContextWithPrincipal(context.Background(), &Principal{UserID: "alice"}))
+	if _, err := s.channels.CreateChannel(ctx, &pb.CreateChannelRequest{Name: "general", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1"}); err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+	release()
+	if err := s.manager.CloseDatabase("srv1"); err != nil {
+		t.Fatalf("CloseDatabase: %v", err)
+	}
+
+	s.events.dispatchOutbox(false)
+	if isOpen(s.manager, "srv1") {
+		t.Fatal("a regular dispatch opened a closed database")
+	}
+
+	s.events.dispatchOutbox(true)
+	if got := outboxLength(t, s, "srv1"); got != 0 {
+		t.Fatalf("outbox holds %d events after a sweep, want 0", got)
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// eventPayload wraps a typed payload such as *pb.ChannelCreatedPayload for
+// an event. Failures are logged and yield an event without payload.
+func eventPayload(payload proto.Message) *anypb.Any {
+	packed, err := anypb.New(payload)
+	if err != nil {
+		log.Printf("Failed to pack %s event payload: %v", payload.ProtoReflect().Descriptor().FullName(), err)
+		return nil
+	}
+	return packed
+}
+
+// unpackEventPayload unwraps the payload of an event into payload, which must
+// be of the type the event carries.
+func unpackEventPayload(event *pb.Event, payload proto.Message) error {
+	if event.Payload == nil {
+		return fmt.Errorf("%s event has no payload", event.EventType)
+	}
+	if err := event.Payload.UnmarshalTo(payload); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", event.EventType, err)
+	}
+	return nil
+}
+
+// rawPayload is the stored form of payloads whose type this server doesn't
+// know; it matches how payloads were stored before they were typed.
+type rawPayload struct {
+	TypeURL string `json:"type_url"`
+	Value   []byte `json:"value"`
+}
+
+// encodeEventPayload converts a payload to the text stored in events.payload.
+// Known types are stored as protojson so they stay readable in the database;
+// payloads of other types, which clients may publish, keep their raw bytes.
+func encodeEventPayload(payload *anypb.Any) (string, error) {
+	if payload == nil {
+		return "", nil
+	}
+
+	if encoded, err := protojson.Marshal(payload); err == nil {
+		return string(encoded), nil
+	}
+
+	encoded, err := json.Marshal(rawPayload{TypeURL: payload.TypeUrl, Value: payload.Value})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode payload: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// decodeEventPayload is the inverse of encodeEventPayload.
+func decodeEventPayload(stored string) (*anypb.Any, error) {
+	if stored == "" {
+		return nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(stored), &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	// protojson stores the type under "@type"
+	if _, ok := fields["@type"]; ok {
+		payload := &anypb.Any{}
+		if err := protojson.Unmarshal([]byte(stored), payload); err != nil {
+			return nil, fmt.Errorf("failed to decode payload: %w", err)
+		}
+		return payload, nil
+	}
+
+	var raw rawPayload
+	if err := json.Unmarshal([]byte(stored), &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+	return &anypb.Any{TypeUrl: raw.TypeURL, Value: raw.Value}, nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
-	"fmt"
 	"log"
+	"regexp"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -14,68 +16,192 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
 	pb "github.com/waifu-devs/fuwa/server/proto"
 )
 
 type eventServiceServer struct {
 	pb.UnimplementedEventServiceServer
-	db          *database.Queries
+	router      *DatabaseRouter
+	permissions *PermissionResolver
 	subscribers map[string]*eventSubscriber
 	mu          sync.RWMutex
+
+	// scopeIndex maps scope patterns to subscribers; channelServers caches
+	// which server a channel belongs to for building event scope paths
+	scopeIndex     *scopeIndex
+	channelServers sync.Map
+
+	// Each subscriber gets a bounded queue; overflowPolicy decides what
+	// happens when a slow client lets it fill up
+	queueSize      int
+	overflowPolicy OverflowPolicy
+	blockTimeout   time.Duration
+	metrics        eventMetrics
+
+	// consumerGroups holds the active consumer groups by scope and name
+	consumerGroups map[string]map[string]*consumerGroup
+	consumerMu     sync.Mutex
+
+	// wake signals the outbox dispatcher that new events were committed
+	wake         chan struct{}
+	stopDispatch chan struct{}
+	dispatchDone chan struct{}
 }
 
 type eventSubscriber struct {
-	eventTypes []string
-	scopes     []string
+	eventTypes []pattern
+	scopes     []pattern
 	filters    map[string]string
+	filter     *eventFilter
 	stream     pb.EventService_SubscribeServer
 	done       chan struct{}
+	visibility *channelVisibilityCache
+
+	// queue buffers live events for the sender; overflowed is closed when
+	// the subscriber must be disconnected
+	queue        chan *pb.Event
+	overflowed   chan struct{}
+	overflowOnce sync.Once
+	sent         atomic.Uint64
+	dropped      atomic.Uint64
+
+	// undelivered holds the first sequence per scope the subscriber missed
+	mu          sync.Mutex
+	undelivered map[string]int64
+
+	// While replaying, live events wait in replayBuffer instead of the queue;
+	// replayLost records that the buffer filled up and events were skipped
+	replaying    bool
+	replayBuffer []*pb.Event
+	replayLost   bool
+}
+
+// visibilityCacheTTL bounds how long a subscriber keeps seeing (or not seeing)
+// a channel after its permissions change.
+const visibilityCacheTTL = 30 * time.Second
+
+type visibilityEntry struct {
+	visible   bool
+	expiresAt time.Time
+}
+
+// channelVisibilityCache remembers per-subscriber channel and scope visibility
+// so broadcasting doesn't resolve permissions for every event.
+type channelVisibilityCache struct {
+	mu      sync.Mutex
+	entries map[string]visibilityEntry
+}
+
+func newChannelVisibilityCache() *channelVisibilityCache {
+	return &channelVisibilityCache{
+		entries: make(map[string]visibilityEntry),
+	}
+}
+
+func (c *channelVisibilityCache) get(channelID string) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[channelID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return false, false
+	}
+	return entry.visible, true
+}
+
+func (c *channelVisibilityCache) set(channelID string, visible bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[channelID] = visibilityEntry{
+		visible:   visible,
+		expiresAt: time.Now().Add(visibilityCacheTTL),
+	}
 }
 
-func NewEventServiceServer(db *database.Queries) *eventServiceServer {
+func NewEventServiceServer(config *Config, router *DatabaseRouter, permissions *PermissionResolver) *eventServiceServer {
 	return &eventServiceServer{
-		db:          db,
-		subscribers: make(map[string]*eventSubscriber),
+		router:         router,
+		permissions:    permissions,
+		subscribers:    make(map[string]*eventSubscriber),
+		scopeIndex:     newScopeIndex(),
+		consumerGroups: make(map[string]map[string]*consumerGroup),
+		queueSize:      config.SubscriberQueueSize,
+		overflowPolicy: config.SubscriberOverflow,
+		blockTimeout:   config.SubscriberBlockTimeout,
+		wake:           make(chan struct{}, 1),
 	}
 }
 
 func (s *eventServiceServer) Subscribe(req *pb.SubscribeRequest, stream pb.EventService_SubscribeServer) error {
-	subscriberID := fmt.Sprintf("subscriber_%d", time.Now().UnixNano())
+	subscriberID := id.New(id.Subscriber)
+
+	eventTypes, scopes, err := parseSubscriptionPatterns(req.EventTypes, req.Scopes)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	filter, err := compileEventFilter(req.Filter, s.permissions.callerID(stream.Context()))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Replays are read scope by scope, so they need scopes to read
+	if req.FromSequence != 0 && len(scopes) == 0 {
+		return status.Error(codes.InvalidArgument, "from_sequence requires scopes")
+	}
+	if err := s.authorizeScopePatterns(stream.Context(), scopes); err != nil {
+		return err
+	}
 
 	subscriber := &eventSubscriber{
-		eventTypes: req.EventTypes,
-		scopes:     req.Scopes,
+		eventTypes: eventTypes,
+		scopes:     scopes,
 		filters:    req.Filters,
+		filter:     filter,
 		stream:     stream,
 		done:       make(chan struct{}),
+		visibility: newChannelVisibilityCache(),
+
+		queue:       make(chan *pb.Event, s.queueSize),
+		overflowed:  make(chan struct{}),
+		undelivered: make(map[string]int64),
+		replaying:   req.FromSequence != 0,
 	}
 
 	s.mu.Lock()
 	s.subscribers[subscriberID] = subscriber
+	for _, scope := range scopes {
+		s.scopeIndex.add(subscriberID, scope)
+	}
 	s.mu.Unlock()
 
 	// Clean up on disconnect
 	defer func() {
 		s.mu.Lock()
 		delete(s.subscribers, subscriberID)
+		for _, scope := range scopes {
+			s.scopeIndex.remove(subscriberID, scope)
+		}
 		s.mu.Unlock()
 		close(subscriber.done)
 	}()
 
 	log.Printf("Client subscribed: %s", subscriberID)
 
-	// If client wants historical events
-	if req.FromSequence != 0 {
-		err := s.sendHistoricalEvents(stream, req)
-		if err != nil {
+	// Replay the backlog first; live events committed meanwhile are held
+	// back and only delivered once they are newer than the replay
+	delivered := make(map[string]int64)
+	if subscriber.replaying {
+		if err := s.replayBacklog(req, subscriber, delivered); err != nil {
 			return err
 		}
 	}
 
-	// Keep connection alive and wait for disconnect
-	<-stream.Context().Done()
+	// Send live events until the client disconnects or falls behind
+	err = s.sendQueued(subscriberID, subscriber, delivered)
 	log.Printf("Client unsubscribed: %s", subscriberID)
-	return nil
+	return err
 }
 
 func (s *eventServiceServer) Publish(ctx context.Context, req *pb.PublishRequest) (*pb.PublishResponse, error) {
@@ -84,57 +210,29 @@ func (s *eventServiceServer) Publish(ctx context.Context, req *pb.PublishRequest
 	}
 
 	event := req.Event
-	if event.EventId == "" {
-		event.EventId = fmt.Sprintf("event_%d", time.Now().UnixNano())
+	if event.Scope == "" {
+		return nil, status.Error(codes.InvalidArgument, "scope is required")
 	}
-	if event.Timestamp == nil {
-		event.Timestamp = timestamppb.Now()
+	if err := validatePublishedEventType(event.EventType); err != nil {
+		return nil, err
 	}
-
-	// Get next sequence number for this scope
-	nextSequence, err := s.getNextSequence(ctx, event.Scope)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get next sequence: %v", err)
+	if err := s.permissions.RequireScopePermission(ctx, event.Scope, PermSendMessages); err != nil {
+		return nil, err
 	}
-	event.Sequence = nextSequence
 
-	// Convert payload to JSON if present
-	var payloadJSON string
-	if event.Payload != nil {
-		payloadBytes, err := json.Marshal(event.Payload)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to marshal payload: %v", err)
-		}
-		payloadJSON = string(payloadBytes)
+	// Authenticated callers cannot publish on behalf of someone else
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		event.ActorId = principal.UserID
 	}
 
-	// Convert metadata to JSON
-	var metadataJSON string
-	if event.Metadata != nil && len(event.Metadata) > 0 {
-		metadataBytes, err := json.Marshal(event.Metadata)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to marshal metadata: %v", err)
-		}
-		metadataJSON = string(metadataBytes)
-	}
-
-	// Store event in database
-	_, err = s.db.CreateEvent(ctx, database.CreateEventParams{
-		EventID:   event.EventId,
-		EventType: event.EventType,
-		Scope:     event.Scope,
-		ActorID:   event.ActorId,
-		Timestamp: event.Timestamp.AsTime().Unix(),
-		Payload:   sql.NullString{String: payloadJSON, Valid: payloadJSON != ""},
-		Metadata:  sql.NullString{String: metadataJSON, Valid: metadataJSON != ""},
-		Sequence:  event.Sequence,
+	// Events are stored alongside the server their scope belongs to
+	err := s.router.InScopeTx(ctx, event.Scope, func(ctx context.Context, tx *database.Queries) error {
+		return s.appendEvent(ctx, tx, event)
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to store event: %v", err)
+		return nil, txError(err, "store event")
 	}
-
-	// Broadcast to subscribers
-	s.broadcastEvent(event)
+	s.notify()
 
 	return &pb.PublishResponse{
 		EventId:  event.EventId,
@@ -143,10 +241,53 @@ func (s *eventServiceServer) Publish(ctx context.Context, req *pb.PublishRequest
 	}, nil
 }
 
+// Event types published by clients are "<namespace>.<name>", with dot
+// separated segments of the grammar subscriptions match them with.
+var publishedEventTypePattern = regexp.MustCompile(`^` + eventTypeSegment + `(\.` + eventTypeSegment + `)+$`)
+
+// reservedEventNamespaces are the namespaces of the events the services
+// record themselves. Projections rebuild tables from them and subscribers
+// trust them, so clients can't publish them.
+var reservedEventNamespaces = map[string]bool{
+	"channel":  true,
+	"config":   true,
+	"consumer": true,
+	"message":  true,
+	"role":     true,
+	"server":   true,
+	"system":   true,
+	"thread":   true,
+	"user":     true,
+}
+
+func validatePublishedEventType(eventType string) error {
+	if !publishedEventTypePattern.MatchString(eventType) {
+		return status.Error(codes.InvalidArgument, `event_type must be namespaced, as in "<namespace>.<name>"`)
+	}
+	namespace, _, _ := strings.Cut(eventType, ".")
+	if reservedEventNamespaces[strings.ToLower(namespace)] {
+		return status.Errorf(codes.InvalidArgument, "event_type namespace %q is reserved", namespace)
+	}
+	return nil
+}
+
 func (s *eventServiceServer) GetEvents(ctx context.Context, req *pb.GetEventsRequest) (*pb.GetEventsResponse, error) {
 	if req.Scope == "" {
 		return nil, status.Error(codes.InvalidArgument, "scope is required")
 	}
+	filter, err := compileEventFilter(req.Filter, s.permissions.callerID(ctx))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.permissions.RequireScopeRead(ctx, req.Scope); err != nil {
+		return nil, err
+	}
+
+	db, err := s.router.ForScope(ctx, req.Scope)
+	if err != nil {
+		return nil, routeError(err, "scope not found")
+	}
 
 	limit := int64(50) // Default limit
 	if req.Limit > 0 && req.Limit <= 100 {
@@ -161,7 +302,7 @@ func (s *eventServiceServer) GetEvents(ctx context.Context, req *pb.GetEventsReq
 	toSeq := req.ToSequence
 	if toSeq <= 0 {
 		// Get latest sequence if not specified
-		latestSeqInterface, err := s.db.GetLatestSequence(ctx, req.Scope)
+		latestSeqInterface, err := db.GetLatestSequence(ctx, req.Scope)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to get latest sequence: %v", err)
 		}
@@ -178,28 +319,33 @@ func (s *eventServiceServer) GetEvents(ctx context.Context, req *pb.GetEventsReq
 	}
 
 	// Get events from database
-	dbEvents, err := s.db.GetEvents(ctx, database.GetEventsParams{
-		Scope:      req.Scope,
-		EventTypes: req.EventTypes,
-		Sequence:   fromSeq,
-		Sequence_2: toSeq,
-		Limit:      limit,
+	dbEvents, err := db.GetEvents(ctx, database.GetEventsParams{
+		Scope:         req.Scope,
+		AllEventTypes: len(req.EventTypes) == 0,
+		EventTypes:    req.EventTypes,
+		FromSequence:  fromSeq,
+		ToSequence:    toSeq,
+		Limit:         limit,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get events: %v", err)
 	}
 
-	// Convert to proto events
-	events := make([]*pb.Event, len(dbEvents))
-	for i, dbEvent := range dbEvents {
-		events[i] = dbEventToProto(&dbEvent)
+	// Convert to proto events, dropping those about channels the caller can't see
+	visibility := newChannelVisibilityCache()
+	events := make([]*pb.Event, 0, len(dbEvents))
+	for _, dbEvent := range dbEvents {
+		event := dbEventToProto(&dbEvent)
+		if filter.matches(event) && s.canSeeEvent(ctx, event, visibility) {
+			events = append(events, event)
+		}
 	}
 
 	// Check if there are more events
-	hasMore := len(events) == int(limit)
+	hasMore := len(dbEvents) == int(limit)
 	var nextSequence int64
-	if hasMore && len(events) > 0 {
-		nextSequence = events[len(events)-1].Sequence + 1
+	if hasMore {
+		nextSequence = dbEvents[len(dbEvents)-1].Sequence + 1
 	}
 
 	return &pb.GetEventsResponse{
@@ -209,87 +355,36 @@ func (s *eventServiceServer) GetEvents(ctx context.Context, req *pb.GetEventsReq
 	}, nil
 }
 
-func (s *eventServiceServer) sendHistoricalEvents(stream pb.EventService_SubscribeServer, req *pb.SubscribeRequest) error {
-	// For each scope the client is interested in
-	scopes := req.Scopes
-	if len(scopes) == 0 {
-		// If no scopes specified, we can't send historical events
-		return nil
-	}
-
-	for _, scope := range scopes {
-		// Get events from the requested sequence
-		events, err := s.GetEvents(stream.Context(), &pb.GetEventsRequest{
-			Scope:        scope,
-			EventTypes:   req.EventTypes,
-			FromSequence: req.FromSequence,
-			Limit:        100, // Reasonable batch size
-		})
-		if err != nil {
-			return err
-		}
+// broadcastEvent hands an event to the queue of every subscriber that may
+// see it and wakes the consumer groups of its scope. Sending happens on each
+// subscriber's own goroutine, so a slow client only affects itself.
+func (s *eventServiceServer) broadcastEvent(event *pb.Event) {
+	path := s.scopePath(event.Scope)
 
-		// Send each event
-		for _, event := range events.Events {
-			if s.eventMatchesFilters(event, req) {
-				if err := stream.Send(event); err != nil {
-					return err
-				}
-			}
+	s.mu.RLock()
+	matched := s.scopeIndex.match(path)
+	subscribers := make([]*eventSubscriber, 0, len(matched))
+	for subscriberID := range matched {
+		if subscriber, ok := s.subscribers[subscriberID]; ok {
+			subscribers = append(subscribers, subscriber)
 		}
 	}
+	s.mu.RUnlock()
 
-	return nil
-}
-
-func (s *eventServiceServer) broadcastEvent(event *pb.Event) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	for subscriberID, subscriber := range s.subscribers {
-		if s.eventMatchesSubscriber(event, subscriber) {
-			select {
-			case <-subscriber.done:
-				// Subscriber is done, skip
-				continue
-			default:
-				// Send event
-				err := subscriber.stream.Send(event)
-				if err != nil {
-					log.Printf("Failed to send event to subscriber %s: %v", subscriberID, err)
-				}
-			}
+	for _, subscriber := range subscribers {
+		if s.eventMatchesSubscriber(event, subscriber) && s.canSeeEvent(subscriber.stream.Context(), event, subscriber.visibility) {
+			subscriber.enqueue(event, s.overflowPolicy, s.blockTimeout, &s.metrics)
 		}
 	}
+
+	s.kickConsumerGroups(event.Scope)
 }
 
+// eventMatchesSubscriber checks the event type and metadata filters of a
+// subscription; scopes are matched by the scope index.
 func (s *eventServiceServer) eventMatchesSubscriber(event *pb.Event, subscriber *eventSubscriber) bool {
-	// Check event types
-	if len(subscriber.eventTypes) > 0 {
-		found := false
-		for _, eventType := range subscriber.eventTypes {
-			if event.EventType == eventType {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
-	}
-
-	// Check scopes
-	if len(subscriber.scopes) > 0 {
-		found := false
-		for _, scope := range subscriber.scopes {
-			if event.Scope == scope {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
+	if !eventTypeMatches(subscriber.eventTypes, event.EventType) {
+		return false
 	}
 
 	// Check additional filters
@@ -299,64 +394,125 @@ func (s *eventServiceServer) eventMatchesSubscriber(event *pb.Event, subscriber
 		}
 	}
 
-	return true
+	return subscriber.filter.matches(event)
 }
 
-func (s *eventServiceServer) eventMatchesFilters(event *pb.Event, req *pb.SubscribeRequest) bool {
-	// Check event types
-	if len(req.EventTypes) > 0 {
-		found := false
-		for _, eventType := range req.EventTypes {
-			if event.EventType == eventType {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
+// canSeeEvent hides events the caller may not read: those in a scope
+// RequireScopeRead rejects, and those about channels the caller may not view.
+// The channel comes from a "channel:<id>" scope or the event's channel_id
+// metadata. The channel.deleted event of a server scope only carries the
+// channel's ID and name, so everyone who can read the server sees it and can
+// drop the channel.
+func (s *eventServiceServer) canSeeEvent(ctx context.Context, event *pb.Event, visibility *channelVisibilityCache) bool {
+	channelID := event.Metadata["channel_id"]
+	if id, ok := strings.CutPrefix(event.Scope, "channel:"); ok {
+		channelID = id
+	} else if !s.canSeeScope(ctx, event.Scope, visibility) {
+		return false
+	} else if event.EventType == "channel.deleted" {
+		return true
 	}
+	if channelID == "" {
+		return true
+	}
+	return s.canSeeChannel(ctx, channelID, visibility)
+}
 
-	// Check scopes
-	if len(req.Scopes) > 0 {
-		found := false
-		for _, scope := range req.Scopes {
-			if event.Scope == scope {
-				found = true
-				break
+// canSeeScope reports whether the caller may read a scope other than a
+// channel. Results share the visibility cache, keyed by the scope itself.
+func (s *eventServiceServer) canSeeScope(ctx context.Context, scope string, visibility *channelVisibilityCache) bool {
+	if s.permissions == nil || s.router == nil {
+		return true
+	}
+	if s.permissions.isUnrestricted(s.permissions.callerID(ctx)) {
+		return true
+	}
+
+	if visible, ok := visibility.get(scope); ok {
+		return visible
+	}
+
+	err := s.permissions.RequireScopeRead(ctx, scope)
+	switch status.Code(err) {
+	case codes.OK:
+		visibility.set(scope, true)
+		return true
+	case codes.PermissionDenied, codes.NotFound:
+		visibility.set(scope, false)
+		return false
+	default:
+		log.Printf("Failed to resolve scope visibility for %s: %v", scope, err)
+		return false
+	}
+}
+
+// authorizeScopePatterns checks every concrete segment of the subscription's
+// scope patterns up front, so subscribing to a scope the caller can't read
+// fails instead of silently delivering nothing. Wildcard segments are
+// checked per event by canSeeEvent.
+func (s *eventServiceServer) authorizeScopePatterns(ctx context.Context, patterns []pattern) error {
+	checked := make(map[string]bool)
+	for _, p := range patterns {
+		for _, segment := range p {
+			if segment == "*" || strings.HasSuffix(segment, ":*") || checked[segment] {
+				continue
+			}
+			checked[segment] = true
+			if err := s.permissions.RequireScopeRead(ctx, segment); err != nil {
+				return err
 			}
-		}
-		if !found {
-			return false
 		}
 	}
+	return nil
+}
 
-	// Check additional filters
-	for key, value := range req.Filters {
-		if event.Metadata[key] != value {
-			return false
-		}
+// canSeeChannel reports whether the caller may view a channel. Once a channel
+// is deleted its overwrites are gone with it, so only callers holding
+// MANAGE_CHANNELS in the server it belonged to still see its events.
+func (s *eventServiceServer) canSeeChannel(ctx context.Context, channelID string, visibility *channelVisibilityCache) bool {
+	if s.permissions == nil || s.router == nil {
+		return true
 	}
 
-	return true
-}
+	userID := s.permissions.callerID(ctx)
+	if s.permissions.isUnrestricted(userID) {
+		return true
+	}
 
-func (s *eventServiceServer) getNextSequence(ctx context.Context, scope string) (int64, error) {
-	latestSeqInterface, err := s.db.GetLatestSequence(ctx, scope)
-	if err != nil && err != sql.ErrNoRows {
-		return 0, err
+	if visible, ok := visibility.get(channelID); ok {
+		return visible
 	}
 
-	var latestSeq int64
-	if latestSeqInterface != nil {
-		if seq, ok := latestSeqInterface.(int64); ok {
-			latestSeq = seq
-		} else {
-			latestSeq = 0
+	db, serverID, err := s.router.ForResource(ctx, channelID)
+	if err != nil {
+		if err != ErrResourceNotFound {
+			log.Printf("Failed to resolve database for channel %s: %v", channelID, err)
+			return false
 		}
+		visibility.set(channelID, false)
+		return false
 	}
 
-	return latestSeq + 1, nil
+	var visible bool
+	channel, err := db.GetChannel(ctx, channelID)
+	switch {
+	case err == sql.ErrNoRows:
+		var perms Permissions
+		perms, err = s.permissions.ServerPermissions(ctx, userID, serverID)
+		visible = perms.Has(PermManageChannels)
+	case err != nil:
+		log.Printf("Failed to get channel %s for event visibility: %v", channelID, err)
+		return false
+	default:
+		visible, err = s.permissions.CanViewChannel(ctx, userID, &channel)
+	}
+	if err != nil {
+		log.Printf("Failed to resolve channel visibility for %s: %v", channelID, err)
+		return false
+	}
+
+	visibility.set(channelID, visible)
+	return visible
 }
 
 // Helper function to convert database event to proto event
@@ -366,14 +522,19 @@ func dbEventToProto(dbEvent *database.Event) *pb.Event {
 		json.Unmarshal([]byte(dbEvent.Metadata.String), &metadata)
 	}
 
+	payload, err := decodeEventPayload(dbEvent.Payload.String)
+	if err != nil {
+		log.Printf("Failed to decode payload of event %s: %v", dbEvent.EventID, err)
+	}
+
 	return &pb.Event{
 		EventId:   dbEvent.EventID,
 		EventType: dbEvent.EventType,
 		Scope:     dbEvent.Scope,
 		ActorId:   dbEvent.ActorID,
 		Timestamp: timestamppb.New(time.Unix(dbEvent.Timestamp, 0)),
-		// Note: Payload would need to be reconstructed from JSON if needed
-		Metadata: metadata,
-		Sequence: dbEvent.Sequence,
+		Payload:   payload,
+		Metadata:  metadata,
+		Sequence:  dbEvent.Sequence,
 	}
 }
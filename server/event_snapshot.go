@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// GetSnapshot returns the stored snapshot of a scope, taking one if the scope
+// has none yet. Channels the caller can't view are left out.
+func (s *eventServiceServer) GetSnapshot(ctx context.Context, req *pb.GetSnapshotRequest) (*pb.ScopeSnapshot, error) {
+	if !snapshotScope(req.Scope) {
+		return nil, status.Error(codes.InvalidArgument, "scope must be server:<id> or channel:<id>")
+	}
+	if err := s.permissions.RequireScopeRead(ctx, req.Scope); err != nil {
+		return nil, err
+	}
+
+	db, err := s.router.ForScope(ctx, req.Scope)
+	if err != nil {
+		return nil, routeError(err, "scope not found")
+	}
+
+	var snapshot *pb.ScopeSnapshot
+	row, err := db.GetEventSnapshot(ctx, req.Scope)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		snapshot, err = s.takeSnapshot(ctx, req.Scope)
+		if err != nil {
+			return nil, txError(err, "take snapshot")
+		}
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to get snapshot: %v", err)
+	default:
+		snapshot = &pb.ScopeSnapshot{}
+		if err := protojson.Unmarshal([]byte(row.State), snapshot); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to decode snapshot: %v", err)
+		}
+	}
+
+	visibility := newChannelVisibilityCache()
+	channels := snapshot.Channels[:0]
+	for _, channel := range snapshot.Channels {
+		if s.canSeeChannel(ctx, channel.ChannelId, visibility) {
+			channels = append(channels, channel)
+		}
+	}
+	snapshot.Channels = channels
+
+	return snapshot, nil
+}
+
+// snapshotScope reports whether state snapshots are kept for a scope.
+func snapshotScope(scope string) bool {
+	kind, id, ok := strings.Cut(scope, ":")
+	return ok && id != "" && !strings.ContainsAny(id, "/*") && (kind == "server" || kind == "channel")
+}
+
+// takeSnapshot captures and stores the state of a scope. Reading the state
+// and the scope's last sequence in one transaction keeps them consistent,
+// since every write commits together with its event.
+func (s *eventServiceServer) takeSnapshot(ctx context.Context, scope string) (*pb.ScopeSnapshot, error) {
+	var snapshot *pb.ScopeSnapshot
+	err := s.router.InScopeTx(ctx, scope, func(ctx context.Context, tx *database.Queries) error {
+		var err error
+		snapshot, err = buildSnapshot(ctx, tx, scope)
+		if err != nil {
+			return err
+		}
+
+		state, err := protojson.Marshal(snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to encode snapshot: %w", err)
+		}
+		return tx.SetEventSnapshot(ctx, database.SetEventSnapshotParams{
+			Scope:     scope,
+			Sequence:  snapshot.Sequence,
+			State:     string(state),
+			CreatedAt: snapshot.CreatedAt.AsTime().Unix(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func buildSnapshot(ctx context.Context, tx *database.Queries, scope string) (*pb.ScopeSnapshot, error) {
+	sequence, err := tx.GetLastEventSequence(ctx, scope)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get last sequence: %w", err)
+	}
+
+	snapshot := &pb.ScopeSnapshot{
+		Scope:     scope,
+		Sequence:  sequence,
+		CreatedAt: timestamppb.New(time.Now()),
+		Configs:   make(map[string]*pb.ConfigValue),
+	}
+
+	kind, id, _ := strings.Cut(scope, ":")
+	switch kind {
+	case "server":
+		channels, err := tx.ListChannelsByServerId(ctx, sql.NullString{String: id, Valid: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list channels: %w", err)
+		}
+		for i := range channels {
+			snapshot.Channels = append(snapshot.Channels, dbChannelToProto(&channels[i]))
+		}
+		if err := loadThreadMetadata(ctx, tx, snapshot.Channels); err != nil {
+			return nil, fmt.Errorf("failed to list threads: %w", err)
+		}
+
+		roles, err := tx.ListRolesByServerId(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", err)
+		}
+		for i := range roles {
+			snapshot.Roles = append(snapshot.Roles, dbRoleToProto(&roles[i]))
+		}
+
+	case "channel":
+		channel, err := tx.GetChannel(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "channel %s not found", id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get channel: %w", err)
+		}
+		snapshot.Channels = append(snapshot.Channels, dbChannelToProto(&channel))
+		if err := loadThreadMetadata(ctx, tx, snapshot.Channels); err != nil {
+			return nil, fmt.Errorf("failed to get thread: %w", err)
+		}
+
+		overwrites, err := tx.GetPermissionOverwritesByChannelId(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list permission overwrites: %w", err)
+		}
+		for i := range overwrites {
+			snapshot.PermissionOverwrites = append(snapshot.PermissionOverwrites, dbOverwriteToProto(&overwrites[i]))
+		}
+	}
+
+	configs, err := tx.ListConfigKeys(ctx, database.ListConfigKeysParams{
+		Scope:   scope,
+		Column2: sql.NullString{Valid: true},
+		Column3: "",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configs: %w", err)
+	}
+	for i := range configs {
+		value, err := dbConfigToProto(&configs[i])
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Configs[configs[i].Key] = redactSensitiveValue(value)
+	}
+
+	return snapshot, nil
+}
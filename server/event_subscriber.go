@@ -0,0 +1,431 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// OverflowPolicy decides what happens to a subscriber whose queue is full.
+type OverflowPolicy string
+
+const (
+	// OverflowDropOldest discards the oldest queued event to make room.
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowDisconnect ends the subscription with ResourceExhausted and
+	// tells the client where to resume each scope from.
+	OverflowDisconnect OverflowPolicy = "disconnect"
+	// OverflowBlock waits for room up to the block timeout, then disconnects
+	// like OverflowDisconnect.
+	OverflowBlock OverflowPolicy = "block"
+)
+
+func (p OverflowPolicy) valid() bool {
+	switch p {
+	case OverflowDropOldest, OverflowDisconnect, OverflowBlock:
+		return true
+	}
+	return false
+}
+
+// resumeTrailer is the trailer key listing, for a disconnected subscriber,
+// the sequence to resume each scope from as "<scope>=<sequence>".
+const resumeTrailer = "fuwa-resume-from"
+
+// enqueue queues an event for the subscriber's sender without blocking the
+// dispatcher longer than the overflow policy allows.
+func (sub *eventSubscriber) enqueue(event *pb.Event, policy OverflowPolicy, blockTimeout time.Duration, metrics *eventMetrics) {
+	select {
+	case <-sub.overflowed:
+		sub.missed(event)
+		return
+	case <-sub.done:
+		return
+	default:
+	}
+
+	sub.mu.Lock()
+	if sub.replaying {
+		if len(sub.replayBuffer) < cap(sub.queue) {
+			sub.replayBuffer = append(sub.replayBuffer, event)
+		} else {
+			sub.replayLost = true
+		}
+		sub.mu.Unlock()
+		return
+	}
+	sub.mu.Unlock()
+
+	select {
+	case sub.queue <- event:
+		return
+	default:
+	}
+
+	switch policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case <-sub.queue:
+				sub.dropped.Add(1)
+				metrics.dropped.Add(1)
+			default:
+			}
+			select {
+			case sub.queue <- event:
+				return
+			default:
+			}
+		}
+	case OverflowBlock:
+		timer := time.NewTimer(blockTimeout)
+		defer timer.Stop()
+		select {
+		case sub.queue <- event:
+			return
+		case <-sub.done:
+			return
+		case <-timer.C:
+		}
+	}
+
+	sub.overflow(event, metrics)
+}
+
+// overflow marks the subscriber for disconnection; its sender then ends the
+// stream with the positions to resume from.
+func (sub *eventSubscriber) overflow(event *pb.Event, metrics *eventMetrics) {
+	sub.missed(event)
+	sub.overflowOnce.Do(func() {
+		metrics.disconnected.Add(1)
+		close(sub.overflowed)
+	})
+}
+
+// missed remembers the first undelivered sequence of an event's scope.
+func (sub *eventSubscriber) missed(event *pb.Event) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if seq, ok := sub.undelivered[event.Scope]; !ok || event.Sequence < seq {
+		sub.undelivered[event.Scope] = event.Sequence
+	}
+}
+
+// resumeFrom returns, per scope, the first sequence the subscriber has not
+// received: the oldest undelivered event, or the one after the last sent.
+func (sub *eventSubscriber) resumeFrom(delivered map[string]int64) map[string]int64 {
+	for drained := false; !drained; {
+		select {
+		case event := <-sub.queue:
+			sub.missed(event)
+		default:
+			drained = true
+		}
+	}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	resume := make(map[string]int64, len(delivered)+len(sub.undelivered))
+	for scope, seq := range delivered {
+		resume[scope] = seq + 1
+	}
+	for scope, seq := range sub.undelivered {
+		resume[scope] = seq
+	}
+	return resume
+}
+
+// replayPageSize is how many stored events a replay loads per request.
+const replayPageSize = 100
+
+// replayBacklog sends every stored event of the subscribed scopes from
+// req.FromSequence on, page by page. Live events arriving meanwhile are
+// buffered; once the backlog is exhausted they move to the queue, in order,
+// and the sender skips those the replay already covered. If the buffer
+// filled up, the skipped events are in the database, so the replay simply
+// pages on from where it stopped.
+func (s *eventServiceServer) replayBacklog(req *pb.SubscribeRequest, sub *eventSubscriber, delivered map[string]int64) error {
+	from := req.FromSequence
+	if from < 0 {
+		from = 1
+	}
+
+	scopes, err := s.replayScopes(sub.stream.Context(), sub.scopes)
+	if err != nil {
+		return err
+	}
+
+	for {
+		for _, scope := range scopes {
+			next := from
+			if seq, ok := delivered[scope]; ok && seq >= next {
+				next = seq + 1
+			}
+			if err := s.replayScope(sub, scope, next, delivered); err != nil {
+				return err
+			}
+		}
+
+		sub.mu.Lock()
+		if sub.replayLost {
+			sub.replayBuffer = nil
+			sub.replayLost = false
+			sub.mu.Unlock()
+			continue
+		}
+
+		// The queue is empty and the buffer never exceeds its capacity, so
+		// this cannot block; holding the lock keeps newer live events behind
+		for _, event := range sub.replayBuffer {
+			sub.queue <- event
+		}
+		sub.replayBuffer = nil
+		sub.replaying = false
+		sub.mu.Unlock()
+		return nil
+	}
+}
+
+func (s *eventServiceServer) replayScope(sub *eventSubscriber, scope string, from int64, delivered map[string]int64) error {
+	ctx := sub.stream.Context()
+
+	// Let the database filter by event type unless patterns are involved
+	var eventTypes []string
+	for _, p := range sub.eventTypes {
+		if p.hasWildcard() {
+			eventTypes = nil
+			break
+		}
+		eventTypes = append(eventTypes, strings.Join(p, "."))
+	}
+
+	for {
+		page, err := s.GetEvents(ctx, &pb.GetEventsRequest{
+			Scope:        scope,
+			EventTypes:   eventTypes,
+			FromSequence: from,
+			Limit:        replayPageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, event := range page.Events {
+			if !s.eventMatchesSubscriber(event, sub) {
+				continue
+			}
+			if err := sub.stream.Send(event); err != nil {
+				return err
+			}
+			sub.sent.Add(1)
+			delivered[event.Scope] = event.Sequence
+		}
+
+		if !page.HasMore {
+			return nil
+		}
+		from = page.NextSequence
+	}
+}
+
+// replayScopes lists the stored scopes whose events a replay must send.
+// Concrete patterns name their scope directly; wildcards are expanded to
+// the server scope and the channels of the server they are under that pass
+// RequireScopeRead; concrete segments were authorized when subscribing.
+// Patterns that don't start with a concrete server can't be replayed.
+func (s *eventServiceServer) replayScopes(ctx context.Context, patterns []pattern) ([]string, error) {
+	var scopes []string
+	seen := make(map[string]bool)
+	add := func(scope string) {
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	for _, p := range patterns {
+		switch {
+		case !p.hasWildcard():
+			add(p[len(p)-1])
+		case len(p) == 2 && p[0] == "server:*" && !p[1:].hasWildcard():
+			// A flat "channel:<id>" pattern
+			add(p[1])
+		case strings.HasPrefix(p[0], "server:") && !p[:1].hasWildcard():
+			serverScope := p[0]
+			if p.matches([]string{serverScope}) {
+				add(serverScope)
+			}
+
+			serverID := strings.TrimPrefix(serverScope, "server:")
+			db, err := s.router.ForServer(ctx, serverID)
+			if err != nil {
+				return nil, routeError(err, "server not found")
+			}
+			channels, err := db.ListChannelsByServerId(ctx, sql.NullString{String: serverID, Valid: true})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to list channels: %v", err)
+			}
+			for _, channel := range channels {
+				channelScope := "channel:" + channel.ChannelID
+				if !p.matches([]string{serverScope, channelScope}) {
+					continue
+				}
+				if s.permissions.RequireScopeRead(ctx, channelScope) != nil {
+					continue
+				}
+				add(channelScope)
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "cannot replay scope pattern %q: it must start with a concrete server", strings.Join(p, "/"))
+		}
+	}
+	return scopes, nil
+}
+
+// scopePath splits an event's scope into the hierarchical path patterns are
+// matched against, putting channels under their server.
+func (s *eventServiceServer) scopePath(scope string) []string {
+	channelID, ok := strings.CutPrefix(scope, "channel:")
+	if !ok {
+		return strings.Split(scope, "/")
+	}
+
+	if serverID, ok := s.channelServers.Load(channelID); ok {
+		return []string{"server:" + serverID.(string), scope}
+	}
+
+	// Channels without a route, e.g. in the primary database, sit under a
+	// server without ID that only wildcards match
+	var serverID string
+	if s.router != nil {
+		id, err := s.router.ServerForResource(context.Background(), channelID)
+		switch {
+		case err == nil:
+			serverID = id
+			s.channelServers.Store(channelID, id)
+		case err != ErrResourceNotFound:
+			log.Printf("Failed to resolve server of channel %s: %v", channelID, err)
+		}
+	}
+	return []string{"server:" + serverID, scope}
+}
+
+// sendQueued is the subscriber's sender: it writes queued events to the
+// stream until the client goes away or the queue overflows. delivered holds
+// the last sequence sent per scope; older or repeated events are skipped so
+// each scope is delivered in strictly increasing order.
+func (s *eventServiceServer) sendQueued(subscriberID string, sub *eventSubscriber, delivered map[string]int64) error {
+	ctx := sub.stream.Context()
+
+	for {
+		select {
+		case event := <-sub.queue:
+			if seq, ok := delivered[event.Scope]; ok && event.Sequence <= seq {
+				continue
+			}
+			if err := sub.stream.Send(event); err != nil {
+				return err
+			}
+			sub.sent.Add(1)
+			delivered[event.Scope] = event.Sequence
+		case <-sub.overflowed:
+			return s.disconnectOverflowed(subscriberID, sub, delivered)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *eventServiceServer) disconnectOverflowed(subscriberID string, sub *eventSubscriber, delivered map[string]int64) error {
+	resume := sub.resumeFrom(delivered)
+
+	scopes := make([]string, 0, len(resume))
+	for scope := range resume {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+
+	positions := make([]string, len(scopes))
+	for i, scope := range scopes {
+		positions[i] = fmt.Sprintf("%s=%d", scope, resume[scope])
+	}
+	for _, position := range positions {
+		sub.stream.SetTrailer(metadata.Pairs(resumeTrailer, position))
+	}
+
+	log.Printf("Disconnecting subscriber %s: queue of %d events overflowed", subscriberID, cap(sub.queue))
+	return status.Errorf(codes.ResourceExhausted, "subscriber fell behind, resume from %s", strings.Join(positions, ", "))
+}
+
+// eventMetrics counts what happened to events on their way to subscribers.
+type eventMetrics struct {
+	dropped      atomic.Uint64
+	disconnected atomic.Uint64
+}
+
+// SubscriberMetrics describes one subscriber's queue.
+type SubscriberMetrics struct {
+	ID         string   `json:"id"`
+	QueueDepth int      `json:"queue_depth"`
+	QueueSize  int      `json:"queue_size"`
+	Sent       uint64   `json:"sent"`
+	Dropped    uint64   `json:"dropped"`
+	Scopes     []string `json:"scopes,omitempty"`
+}
+
+// EventMetrics is a snapshot of the subscriber queues, suitable for expvar.
+type EventMetrics struct {
+	Subscribers    int                 `json:"subscribers"`
+	QueueDepth     int                 `json:"queue_depth"`
+	Dropped        uint64              `json:"dropped"`
+	Disconnected   uint64              `json:"disconnected"`
+	OverflowPolicy OverflowPolicy      `json:"overflow_policy"`
+	PerSubscriber  []SubscriberMetrics `json:"per_subscriber"`
+}
+
+// Metrics reports queue depths and drops across all subscribers.
+func (s *eventServiceServer) Metrics() EventMetrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	metrics := EventMetrics{
+		Subscribers:    len(s.subscribers),
+		Dropped:        s.metrics.dropped.Load(),
+		Disconnected:   s.metrics.disconnected.Load(),
+		OverflowPolicy: s.overflowPolicy,
+		PerSubscriber:  make([]SubscriberMetrics, 0, len(s.subscribers)),
+	}
+	for id, sub := range s.subscribers {
+		scopes := make([]string, len(sub.scopes))
+		for i, scope := range sub.scopes {
+			scopes[i] = strings.Join(scope, "/")
+		}
+		depth := len(sub.queue)
+		metrics.QueueDepth += depth
+		metrics.PerSubscriber = append(metrics.PerSubscriber, SubscriberMetrics{
+			ID:         id,
+			QueueDepth: depth,
+			QueueSize:  cap(sub.queue),
+			Sent:       sub.sent.Load(),
+			Dropped:    sub.dropped.Load(),
+			Scopes:     scopes,
+		})
+	}
+	sort.Slice(metrics.PerSubscriber, func(i, j int) bool {
+		return metrics.PerSubscriber[i].ID < metrics.PerSubscriber[j].ID
+	})
+	return metrics
+}
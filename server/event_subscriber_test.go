@@ -0,0 +1,21 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+func TestSubscribeReplayNeedsScopes(t *testing.T) {
+	s := newTestServer(t, nil)
+	ctx, cancel := context.WithCancel(s.as(t, "alice"))
+	defer cancel()
+
+	for _, from := range []int64{-1, 5} {
+		err := s.events.Subscribe(&pb.SubscribeRequest{FromSequence: from}, newEventStream(ctx))
+		requireCode(t, err, codes.InvalidArgument)
+	}
+}
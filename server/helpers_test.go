@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"testing"
+)
+
+// testServer wires the services to fresh databases in a temporary data path,
+// the way cmd/main.go does.
+type testServer struct {
+	config      *Config
+	manager     *MultiDatabaseManager
+	router      *DatabaseRouter
+	permissions *PermissionResolver
+	events      *eventServiceServer
+	channels    *channelServiceServer
+	messages    *messageServiceServer
+	roles       *roleServiceServer
+}
+
+// newTestServer starts a server on an empty data path. env sets extra FUWA_*
+// variables before the config is loaded.
+func newTestServer(t *testing.T, env map[string]string) *testServer {
+	t.Helper()
+
+	t.Setenv("FUWA_DATA_PATH", t.TempDir())
+	t.Setenv("FUWA_ENCRYPTION_KEY", "0123456789abcdef0123456789abcdef")
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	manager := NewMultiDatabaseManager(config)
+	t.Cleanup(func() { manager.Close() })
+	if err := manager.ReadAllDatabases(); err != nil {
+		t.Fatalf("ReadAllDatabases: %v", err)
+	}
+
+	router := NewDatabaseRouter(manager)
+	permissions := NewPermissionResolver(router, config)
+	events := NewEventServiceServer(config, router, permissions)
+
+	return &testServer{
+		config:      config,
+		manager:     manager,
+		router:      router,
+		permissions: permissions,
+		events:      events,
+		channels:    NewChannelServiceServer(router, events, permissions),
+		messages:    NewMessageServiceServer(router, events, permissions),
+		roles:       NewRoleServiceServer(router, events, permissions),
+	}
+}
+
+// as returns a request context for userID holding database leases until the
+// test ends, like the gRPC interceptors do. An empty userID is a trusted
+// internal caller.
+func (s *testServer) as(t *testing.T, userID string) context.Context {
+	t.Helper()
+
+	ctx := context.Background()
+	if userID != "" {
+		ctx = ContextWithPrincipal(ctx, &Principal{UserID: userID, Username: userID})
+	}
+	ctx, release := WithDatabaseLeases(ctx)
+	t.Cleanup(release)
+	return ctx
+}
@@ -0,0 +1,204 @@
+// Package id generates the IDs of fuwa resources.
+//
+// An ID is a type prefix and a 26 character Crockford base32 ULID, e.g.
+// "message_01J8ZQ4T6V0B3N9XKQ2M5R7W1C". Its 128 bits are the Unix time in
+// milliseconds (48 bits), the node that generated it (10 bits) and a
+// counter (70 bits) that starts at a random value each millisecond and is
+// incremented for every further ID in that millisecond. IDs therefore sort by
+// creation time, never repeat on one node and can't collide between nodes
+// with different node numbers.
+package id
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefix names the kind of resource an ID belongs to.
+type Prefix string
+
+const (
+	User       Prefix = "user"
+	Session    Prefix = "session"
+	Channel    Prefix = "channel"
+	Message    Prefix = "message"
+	Attachment Prefix = "attachment"
+	Role       Prefix = "role"
+	Event      Prefix = "event"
+	Subscriber Prefix = "subscriber"
+)
+
+// MaxNode is the largest node number.
+const MaxNode = 1<<nodeBits - 1
+
+const (
+	nodeBits   = 10
+	encodedLen = 26
+
+	// Crockford's base32 alphabet, whose order matches the order of the
+	// values it encodes
+	alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// Generator creates IDs for one node. It is safe for concurrent use.
+type Generator struct {
+	node uint16
+
+	mu         sync.Mutex
+	lastMillis int64
+	counter    [9]byte // the low 70 bits are used
+}
+
+// NewGenerator returns a generator for the given node, which must be unique
+// among the servers sharing databases.
+func NewGenerator(node int) (*Generator, error) {
+	if node < 0 || node > MaxNode {
+		return nil, fmt.Errorf("node must be between 0 and %d, got %d", MaxNode, node)
+	}
+	return &Generator{node: uint16(node)}, nil
+}
+
+// New returns a new ID with the given prefix.
+func (g *Generator) New(prefix Prefix) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	millis := time.Now().UnixMilli()
+	if millis > g.lastMillis {
+		g.lastMillis = millis
+		if _, err := rand.Read(g.counter[:]); err != nil {
+			panic(fmt.Sprintf("id: failed to read random bytes: %v", err))
+		}
+		// Start in the lower half so the counter has room to grow
+		g.counter[0] &= 0x1f
+	} else if !g.increment() {
+		// The counter ran out within the millisecond, borrow the next one
+		g.lastMillis++
+		clear(g.counter[:])
+	}
+
+	var raw [16]byte
+	for i := 0; i < 6; i++ {
+		raw[i] = byte(g.lastMillis >> (40 - 8*i))
+	}
+	// The node fills the 10 bits after the time, the counter the rest
+	raw[6] = byte(g.node >> 2)
+	raw[7] = byte(g.node<<6) | g.counter[0]&0x3f
+	copy(raw[8:], g.counter[1:])
+	return string(prefix) + "_" + encode(raw)
+}
+
+// increment adds one to the counter, reporting false when it overflows its
+// 70 bits.
+func (g *Generator) increment() bool {
+	for i := len(g.counter) - 1; i >= 0; i-- {
+		g.counter[i]++
+		if g.counter[i] != 0 {
+			return i > 0 || g.counter[0] <= 0x3f
+		}
+	}
+	return false
+}
+
+var (
+	defaultMu        sync.RWMutex
+	defaultGenerator = &Generator{}
+)
+
+// SetNode sets the node of the generator used by New.
+func SetNode(node int) error {
+	generator, err := NewGenerator(node)
+	if err != nil {
+		return err
+	}
+	defaultMu.Lock()
+	defaultGenerator = generator
+	defaultMu.Unlock()
+	return nil
+}
+
+// New returns a new ID with the given prefix from the default generator,
+// whose node is 0 unless SetNode was called.
+func New(prefix Prefix) string {
+	defaultMu.RLock()
+	generator := defaultGenerator
+	defaultMu.RUnlock()
+	return generator.New(prefix)
+}
+
+// ID is a parsed ID.
+type ID struct {
+	Prefix Prefix
+	// Time the ID was created, with millisecond precision
+	Time time.Time
+	// Node that created the ID; 0 for legacy IDs
+	Node int
+}
+
+// Parse splits an ID into its parts. Legacy IDs, whose suffix is the Unix
+// time in nanoseconds, are accepted as well.
+func Parse(s string) (ID, error) {
+	i := strings.LastIndexByte(s, '_')
+	if i <= 0 {
+		return ID{}, fmt.Errorf("id %q has no prefix", s)
+	}
+	prefix, suffix := Prefix(s[:i]), s[i+1:]
+
+	if nanos, err := strconv.ParseInt(suffix, 10, 64); err == nil {
+		return ID{Prefix: prefix, Time: time.Unix(0, nanos)}, nil
+	}
+
+	raw, err := decode(suffix)
+	if err != nil {
+		return ID{}, fmt.Errorf("id %q: %w", s, err)
+	}
+	var millis int64
+	for i := 0; i < 6; i++ {
+		millis = millis<<8 | int64(raw[i])
+	}
+	node := int(raw[6])<<2 | int(raw[7]>>6)
+	return ID{Prefix: prefix, Time: time.UnixMilli(millis), Node: node}, nil
+}
+
+// encode writes the 128 bits as 26 characters, the first of which only holds
+// 3 bits.
+func encode(raw [16]byte) string {
+	var out [encodedLen]byte
+	for i := range out {
+		var value byte
+		for j := 0; j < 5; j++ {
+			bit := i*5 + j - 2
+			value <<= 1
+			if bit >= 0 && raw[bit/8]&(0x80>>(bit%8)) != 0 {
+				value |= 1
+			}
+		}
+		out[i] = alphabet[value]
+	}
+	return string(out[:])
+}
+
+func decode(s string) ([16]byte, error) {
+	var raw [16]byte
+	if len(s) != encodedLen {
+		return raw, errors.New("malformed ULID")
+	}
+	for i := 0; i < encodedLen; i++ {
+		value := strings.IndexByte(alphabet, s[i])
+		if value < 0 || (i == 0 && value > 7) {
+			return raw, errors.New("malformed ULID")
+		}
+		for j := 0; j < 5; j++ {
+			bit := i*5 + j - 2
+			if bit >= 0 && value&(0x10>>j) != 0 {
+				raw[bit/8] |= 0x80 >> (bit % 8)
+			}
+		}
+	}
+	return raw, nil
+}
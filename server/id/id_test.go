@@ -0,0 +1,214 @@
+package id
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	var max [16]byte
+	for i := range max {
+		max[i] = 0xff
+	}
+	var random [16]byte
+	rand.Read(random[:])
+
+	tests := []struct {
+		name    string
+		raw     [16]byte
+		encoded string
+	}{
+		{name: "zero", encoded: "00000000000000000000000000"},
+		{name: "max", raw: max, encoded: "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+		{name: "lowest bit", raw: [16]byte{15: 1}, encoded: "00000000000000000000000001"},
+		{name: "highest bit", raw: [16]byte{0: 0x80}, encoded: "40000000000000000000000000"},
+		{name: "random", raw: random},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encode(tt.raw)
+			if tt.encoded != "" && encoded != tt.encoded {
+				t.Errorf("encode = %s, want %s", encoded, tt.encoded)
+			}
+			decoded, err := decode(encoded)
+			if err != nil {
+				t.Fatalf("decode(%s): %v", encoded, err)
+			}
+			if decoded != tt.raw {
+				t.Errorf("decode(%s) = %x, want %x", encoded, decoded, tt.raw)
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsMalformed(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"0000000000000000000000000",   // too short
+		"000000000000000000000000000", // too long
+		"80000000000000000000000000",  // more than 128 bits
+		"0000000000000000000000000U",  // not in the alphabet
+		"0000000000000000000000000a",  // lower case
+	} {
+		if _, err := decode(s); err == nil {
+			t.Errorf("decode(%q) accepted a malformed ULID", s)
+		}
+	}
+}
+
+func TestEncodingKeepsOrder(t *testing.T) {
+	// Byte order and string order agree, so IDs sort like their bits
+	var previous string
+	for i := range 512 {
+		var raw [16]byte
+		raw[0], raw[15] = byte(i>>8), byte(i)
+		encoded := encode(raw)
+		if encoded <= previous {
+			t.Fatalf("%x encodes to %s, not after %s", raw, encoded, previous)
+		}
+		previous = encoded
+	}
+}
+
+func TestNewOrdersWithinMillisecond(t *testing.T) {
+	generator, err := NewGenerator(3)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+	// Pin the generator to a millisecond the clock hasn't reached
+	millis := time.Now().Add(time.Hour).UnixMilli()
+	generator.lastMillis = millis
+
+	previous := generator.New(Message)
+	for range 10000 {
+		next := generator.New(Message)
+		if next <= previous {
+			t.Fatalf("%s generated after %s", next, previous)
+		}
+		parsed, err := Parse(next)
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", next, err)
+		}
+		if parsed.Time.UnixMilli() != millis || parsed.Node != 3 {
+			t.Fatalf("Parse(%s) = %+v, want time %d and node 3", next, parsed, millis)
+		}
+		previous = next
+	}
+}
+
+func TestNewCounterOverflow(t *testing.T) {
+	generator, err := NewGenerator(MaxNode)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+	millis := time.Now().Add(time.Hour).UnixMilli()
+	generator.lastMillis = millis
+	// One below the largest 70 bit counter
+	generator.counter = [9]byte{0x3f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe}
+
+	last := generator.New(Event)
+	borrowed := generator.New(Event)
+	if borrowed <= last {
+		t.Fatalf("%s generated after %s", borrowed, last)
+	}
+
+	for _, tt := range []struct {
+		id     string
+		millis int64
+	}{{last, millis}, {borrowed, millis + 1}} {
+		parsed, err := Parse(tt.id)
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", tt.id, err)
+		}
+		// An overflowing counter must not spill into the node bits
+		if parsed.Time.UnixMilli() != tt.millis || parsed.Node != MaxNode {
+			t.Errorf("Parse(%s) = %+v, want time %d and node %d", tt.id, parsed, tt.millis, MaxNode)
+		}
+	}
+	if !strings.HasSuffix(last, "ZZZZZZZZZZZZZZ") {
+		t.Errorf("%s doesn't end in the largest counter", last)
+	}
+}
+
+func TestIncrement(t *testing.T) {
+	tests := []struct {
+		name    string
+		counter [9]byte
+		want    [9]byte
+		ok      bool
+	}{
+		{name: "low byte", counter: [9]byte{8: 1}, want: [9]byte{8: 2}, ok: true},
+		{name: "carry", counter: [9]byte{7: 0x01, 8: 0xff}, want: [9]byte{7: 0x02}, ok: true},
+		{name: "carry into top bits", counter: [9]byte{0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, want: [9]byte{0: 1}, ok: true},
+		{name: "overflow", counter: [9]byte{0x3f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, want: [9]byte{0: 0x40}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Generator{counter: tt.counter}
+			if ok := g.increment(); ok != tt.ok || g.counter != tt.want {
+				t.Errorf("increment = %v with %x, want %v with %x", ok, g.counter, tt.ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeBits(t *testing.T) {
+	for _, node := range []int{0, 1, 2, 255, 256, 511, 512, MaxNode - 1, MaxNode} {
+		generator, err := NewGenerator(node)
+		if err != nil {
+			t.Fatalf("NewGenerator(%d): %v", node, err)
+		}
+		before := time.Now().Truncate(time.Millisecond)
+		generated := generator.New(Channel)
+		parsed, err := Parse(generated)
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", generated, err)
+		}
+		if parsed.Prefix != Channel || parsed.Node != node || parsed.Time.Before(before) || parsed.Time.After(time.Now()) {
+			t.Errorf("Parse(%s) = %+v, want channel from node %d at about %s", generated, parsed, node, before)
+		}
+	}
+
+	for _, node := range []int{-1, MaxNode + 1} {
+		if _, err := NewGenerator(node); err == nil {
+			t.Errorf("NewGenerator(%d) accepted an invalid node", node)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    ID
+		wantErr bool
+	}{
+		{s: "message_1700000000123456789", want: ID{Prefix: Message, Time: time.Unix(0, 1700000000123456789)}},
+		{s: "message_01ARZ3NDEKTSV4RRFFQ69G5FAV", want: ID{Prefix: Message, Time: time.UnixMilli(1469922850259), Node: 857}},
+		{s: "dead_letter_00000000000000000000000000", want: ID{Prefix: "dead_letter", Time: time.UnixMilli(0)}},
+		{s: "01ARZ3NDEKTSV4RRFFQ69G5FAV", wantErr: true},
+		{s: "_01ARZ3NDEKTSV4RRFFQ69G5FAV", wantErr: true},
+		{s: "message_", wantErr: true},
+		{s: "message_01ARZ3NDEKTSV4RRFFQ69G5FA", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.s)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q) = %+v, want error", tt.s, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.s, err)
+			continue
+		}
+		if got.Prefix != tt.want.Prefix || !got.Time.Equal(tt.want.Time) || got.Node != tt.want.Node {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// messageCursor is a position in a channel's history, which is ordered by
+// creation time and then by message ID. The ID only breaks ties between
+// messages created in the same second, so IDs of any shape page correctly.
+type messageCursor struct {
+	createdAt int64
+	messageID string
+}
+
+func cursorForMessage(message *database.Message) messageCursor {
+	return messageCursor{createdAt: message.CreatedAt, messageID: message.MessageID}
+}
+
+// String encodes the cursor as the opaque token clients pass back.
+func (c messageCursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.createdAt, 10) + "/" + c.messageID))
+}
+
+func parseMessageCursor(token string) (messageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return messageCursor{}, errors.New("malformed cursor")
+	}
+	createdAt, messageID, ok := strings.Cut(string(raw), "/")
+	if !ok || messageID == "" {
+		return messageCursor{}, errors.New("malformed cursor")
+	}
+	c := messageCursor{messageID: messageID}
+	if c.createdAt, err = strconv.ParseInt(createdAt, 10, 64); err != nil {
+		return messageCursor{}, errors.New("malformed cursor")
+	}
+	return c, nil
+}
+
+// messagePageMode says which part of the history a GetMessages call reads.
+type messagePageMode int
+
+const (
+	pageLatest messagePageMode = iota
+	pageBefore
+	pageAfter
+	pageAround
+)
+
+// messagePage is one page of a channel's history, newest first.
+type messagePage struct {
+	messages      []database.Message
+	before, after messageCursor
+	hasBefore     bool
+	hasAfter      bool
+}
+
+// readMessagePage reads up to limit messages of a channel relative to the
+// cursor. Around pages hold the cursor's message, if it still exists, with
+// the rest of the page split between both sides of it.
+func readMessagePage(ctx context.Context, db *database.Queries, channelID string, mode messagePageMode, cursor messageCursor, limit int64) (*messagePage, error) {
+	page := &messagePage{before: cursor, after: cursor}
+
+	var err error
+	switch mode {
+	case pageLatest:
+		page.messages, err = db.ListLatestMessages(ctx, database.ListLatestMessagesParams{ChannelID: channelID, Limit: limit})
+	case pageBefore:
+		page.messages, err = listMessagesBefore(ctx, db, channelID, cursor, limit)
+	case pageAfter:
+		page.messages, err = listMessagesAfter(ctx, db, channelID, cursor, limit)
+	case pageAround:
+		page.messages, err = listMessagesAround(ctx, db, channelID, cursor, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page.messages) == 0 {
+		// Nothing past the cursor on the side paged, but its message and
+		// anything beyond it is still on the other side
+		switch mode {
+		case pageBefore:
+			page.hasAfter, err = messageExistsFrom(ctx, db, channelID, cursor, listMessagesAfter)
+		case pageAfter:
+			page.hasBefore, err = messageExistsFrom(ctx, db, channelID, cursor, listMessagesBefore)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return page, nil
+	}
+
+	page.after = cursorForMessage(&page.messages[0])
+	page.before = cursorForMessage(&page.messages[len(page.messages)-1])
+	older, err := listMessagesBefore(ctx, db, channelID, page.before, 1)
+	if err != nil {
+		return nil, err
+	}
+	newer, err := listMessagesAfter(ctx, db, channelID, page.after, 1)
+	if err != nil {
+		return nil, err
+	}
+	page.hasBefore = len(older) > 0
+	page.hasAfter = len(newer) > 0
+	return page, nil
+}
+
+func listMessagesBefore(ctx context.Context, db *database.Queries, channelID string, cursor messageCursor, limit int64) ([]database.Message, error) {
+	return db.ListMessagesBefore(ctx, database.ListMessagesBeforeParams{
+		ChannelID:   channelID,
+		CreatedAt:   cursor.createdAt,
+		CreatedAt_2: cursor.createdAt,
+		MessageID:   cursor.messageID,
+		Limit:       limit,
+	})
+}
+
+// listMessagesAfter returns the messages after the cursor, newest first.
+func listMessagesAfter(ctx context.Context, db *database.Queries, channelID string, cursor messageCursor, limit int64) ([]database.Message, error) {
+	messages, err := db.ListMessagesAfter(ctx, database.ListMessagesAfterParams{
+		ChannelID:   channelID,
+		CreatedAt:   cursor.createdAt,
+		CreatedAt_2: cursor.createdAt,
+		MessageID:   cursor.messageID,
+		Limit:       limit,
+	})
+	slices.Reverse(messages)
+	return messages, err
+}
+
+func listMessagesAround(ctx context.Context, db *database.Queries, channelID string, cursor messageCursor, limit int64) ([]database.Message, error) {
+	var center []database.Message
+	message, err := db.GetMessage(ctx, cursor.messageID)
+	switch {
+	case err == nil && message.ChannelID == channelID && cursorForMessage(&message) == cursor:
+		center = append(center, message)
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	// Newer messages get the larger half, and each side takes what the
+	// other leaves over near either end of the history
+	remaining := limit - int64(len(center))
+	newer, err := listMessagesAfter(ctx, db, channelID, cursor, remaining)
+	if err != nil {
+		return nil, err
+	}
+	older, err := listMessagesBefore(ctx, db, channelID, cursor, remaining)
+	if err != nil {
+		return nil, err
+	}
+	olderCount := min(int64(len(older)), remaining/2)
+	newerCount := min(int64(len(newer)), remaining-olderCount)
+	olderCount = min(int64(len(older)), remaining-newerCount)
+
+	messages := make([]database.Message, 0, limit)
+	messages = append(messages, newer[int64(len(newer))-newerCount:]...)
+	messages = append(messages, center...)
+	return append(messages, older[:olderCount]...), nil
+}
+
+// messageExistsFrom reports whether the cursor's message or any message past
+// it in the direction list reads still exists.
+func messageExistsFrom(ctx context.Context, db *database.Queries, channelID string, cursor messageCursor, list func(context.Context, *database.Queries, string, messageCursor, int64) ([]database.Message, error)) (bool, error) {
+	message, err := db.GetMessage(ctx, cursor.messageID)
+	if err == nil && message.ChannelID == channelID {
+		return true, nil
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	messages, err := list(ctx, db, channelID, cursor, 1)
+	return len(messages) > 0, err
+}
+
+// messagePageRequest works out the mode and cursor of a GetMessages request,
+// which may give the cursor as a token or as the ID of a message in the
+// channel.
+func messagePageRequest(ctx context.Context, db *database.Queries, req *pb.GetMessagesRequest) (messagePageMode, messageCursor, error) {
+	positions := []struct {
+		mode             messagePageMode
+		token, messageID string
+	}{
+		{pageBefore, req.Before, req.BeforeId},
+		{pageAfter, req.After, req.AfterId},
+		{pageAround, req.Around, req.AroundId},
+	}
+
+	mode := pageLatest
+	var token, messageID string
+	for _, position := range positions {
+		if position.token == "" && position.messageID == "" {
+			continue
+		}
+		if mode != pageLatest || (position.token != "" && position.messageID != "") {
+			return 0, messageCursor{}, status.Error(codes.InvalidArgument, "only one of before, after and around may be set")
+		}
+		mode, token, messageID = position.mode, position.token, position.messageID
+	}
+
+	switch {
+	case token != "":
+		cursor, err := parseMessageCursor(token)
+		if err != nil {
+			return 0, messageCursor{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return mode, cursor, nil
+
+	case messageID != "":
+		message, err := db.GetMessage(ctx, messageID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && message.ChannelID != req.ChannelId) {
+			return 0, messageCursor{}, status.Errorf(codes.NotFound, "message %s not found in channel", messageID)
+		}
+		if err != nil {
+			return 0, messageCursor{}, status.Errorf(codes.Internal, "failed to get message: %v", err)
+		}
+		return mode, cursorForMessage(&message), nil
+	}
+	return mode, messageCursor{}, nil
+}
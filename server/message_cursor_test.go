@@ -0,0 +1,222 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// newPagedChannel creates a channel holding msg_00 to msg_<count-1>, three
+// per second so pages have to break ties by ID, and returns their IDs
+// newest first.
+func newPagedChannel(t *testing.T, s *testServer, ctx context.Context, count int) (string, []string) {
+	t.Helper()
+
+	channel, err := s.channels.CreateChannel(ctx, &pb.CreateChannelRequest{Name: "general", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1"})
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+	channelID := channel.Channel.ChannelId
+
+	var ids []string
+	err = s.router.InScopeTx(ctx, "channel:"+channelID, func(ctx context.Context, tx *database.Queries) error {
+		for i := range count {
+			id := fmt.Sprintf("msg_%02d", i)
+			ids = append(ids, id)
+			createdAt := int64(1000 + i/3)
+			if _, err := tx.CreateMessage(ctx, database.CreateMessageParams{
+				MessageID: id,
+				ChannelID: channelID,
+				AuthorID:  "alice",
+				Content:   id,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("create messages: %v", err)
+	}
+
+	slices.Reverse(ids)
+	return channelID, ids
+}
+
+func getMessages(t *testing.T, s *testServer, ctx context.Context, req *pb.GetMessagesRequest) (*pb.GetMessagesResponse, []string) {
+	t.Helper()
+
+	resp, err := s.messages.GetMessages(ctx, req)
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+	ids := make([]string, len(resp.Messages))
+	for i, message := range resp.Messages {
+		ids[i] = message.MessageId
+	}
+	return resp, ids
+}
+
+func TestGetMessagesPagesBackward(t *testing.T) {
+	s := newTestServer(t, nil)
+	alice := s.as(t, "alice")
+	channelID, want := newPagedChannel(t, s, alice, 12)
+
+	// 12 messages split evenly into pages of 4 and unevenly into pages of 5
+	for _, limit := range []int32{4, 5} {
+		t.Run(fmt.Sprint("limit ", limit), func(t *testing.T) {
+			var got []string
+			req := &pb.GetMessagesRequest{ChannelId: channelID, Limit: limit}
+			for {
+				resp, ids := getMessages(t, s, alice, req)
+				got = append(got, ids...)
+				if resp.HasMoreAfter != (len(got) > len(ids)) {
+					t.Errorf("page ending at %s: has_more_after %v", ids[len(ids)-1], resp.HasMoreAfter)
+				}
+				if !resp.HasMore {
+					break
+				}
+				if len(got) > len(want) {
+					t.Fatalf("paged past the oldest message: %v", got)
+				}
+				req = &pb.GetMessagesRequest{ChannelId: channelID, Limit: limit, Before: resp.BeforeCursor}
+			}
+			if !slices.Equal(got, want) {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		})
+	}
+
+	// Past the oldest message the page is empty but newer ones remain
+	oldest := messageCursor{createdAt: 1000, messageID: "msg_00"}.String()
+	resp, ids := getMessages(t, s, alice, &pb.GetMessagesRequest{ChannelId: channelID, Before: oldest})
+	if len(ids) != 0 || resp.HasMore || resp.HasMoreBefore || !resp.HasMoreAfter {
+		t.Errorf("before the oldest message: got %v, has_more %v, before %v, after %v", ids, resp.HasMore, resp.HasMoreBefore, resp.HasMoreAfter)
+	}
+	if resp.AfterCursor != oldest {
+		t.Errorf("empty page moved the after cursor to %q", resp.AfterCursor)
+	}
+}
+
+func TestGetMessagesPagesForward(t *testing.T) {
+	s := newTestServer(t, nil)
+	alice := s.as(t, "alice")
+	channelID, want := newPagedChannel(t, s, alice, 12)
+	slices.Reverse(want)
+
+	var got []string
+	req := &pb.GetMessagesRequest{ChannelId: channelID, Limit: 4, AfterId: want[0]}
+	got = append(got, want[0])
+	for {
+		resp, ids := getMessages(t, s, alice, req)
+		slices.Reverse(ids)
+		got = append(got, ids...)
+		if !resp.HasMoreBefore {
+			t.Errorf("page starting at %s: no older messages", ids[0])
+		}
+		if !resp.HasMore {
+			if resp.HasMoreAfter {
+				t.Error("has_more and has_more_after disagree on the last page")
+			}
+			break
+		}
+		if len(got) > len(want) {
+			t.Fatalf("paged past the newest message: %v", got)
+		}
+		req = &pb.GetMessagesRequest{ChannelId: channelID, Limit: 4, After: resp.AfterCursor}
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestGetMessagesAround(t *testing.T) {
+	s := newTestServer(t, nil)
+	alice := s.as(t, "alice")
+	channelID, _ := newPagedChannel(t, s, alice, 12)
+
+	tests := []struct {
+		name                string
+		req                 *pb.GetMessagesRequest
+		want                []string
+		hasBefore, hasAfter bool
+	}{
+		{
+			name:      "middle, odd limit",
+			req:       &pb.GetMessagesRequest{AroundId: "msg_06", Limit: 5},
+			want:      []string{"msg_08", "msg_07", "msg_06", "msg_05", "msg_04"},
+			hasBefore: true, hasAfter: true,
+		},
+		{
+			name:      "middle, newer side gets the larger half",
+			req:       &pb.GetMessagesRequest{AroundId: "msg_06", Limit: 4},
+			want:      []string{"msg_08", "msg_07", "msg_06", "msg_05"},
+			hasBefore: true, hasAfter: true,
+		},
+		{
+			name:      "newest message",
+			req:       &pb.GetMessagesRequest{AroundId: "msg_11", Limit: 5},
+			want:      []string{"msg_11", "msg_10", "msg_09", "msg_08", "msg_07"},
+			hasBefore: true,
+		},
+		{
+			name:     "oldest message",
+			req:      &pb.GetMessagesRequest{AroundId: "msg_00", Limit: 5},
+			want:     []string{"msg_04", "msg_03", "msg_02", "msg_01", "msg_00"},
+			hasAfter: true,
+		},
+		{
+			name:      "one from the newest end",
+			req:       &pb.GetMessagesRequest{AroundId: "msg_10", Limit: 5},
+			want:      []string{"msg_11", "msg_10", "msg_09", "msg_08", "msg_07"},
+			hasBefore: true,
+		},
+		{
+			// A cursor whose message is gone sits between its neighbours
+			name:      "cursor without a message",
+			req:       &pb.GetMessagesRequest{Around: messageCursor{createdAt: 1002, messageID: "msg_06a"}.String(), Limit: 4},
+			want:      []string{"msg_08", "msg_07", "msg_06", "msg_05"},
+			hasBefore: true, hasAfter: true,
+		},
+		{
+			name: "whole channel",
+			req:  &pb.GetMessagesRequest{AroundId: "msg_05", Limit: 100},
+			want: []string{"msg_11", "msg_10", "msg_09", "msg_08", "msg_07", "msg_06", "msg_05", "msg_04", "msg_03", "msg_02", "msg_01", "msg_00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.ChannelId = channelID
+			resp, got := getMessages(t, s, alice, tt.req)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if resp.HasMoreBefore != tt.hasBefore || resp.HasMoreAfter != tt.hasAfter {
+				t.Errorf("has_more_before %v, has_more_after %v, want %v and %v", resp.HasMoreBefore, resp.HasMoreAfter, tt.hasBefore, tt.hasAfter)
+			}
+		})
+	}
+}
+
+func TestGetMessagesRejectsBadPositions(t *testing.T) {
+	s := newTestServer(t, nil)
+	alice := s.as(t, "alice")
+	channelID, _ := newPagedChannel(t, s, alice, 3)
+	otherID, _ := newPagedChannel(t, s, alice, 0)
+
+	_, err := s.messages.GetMessages(alice, &pb.GetMessagesRequest{ChannelId: channelID, BeforeId: "msg_01", AfterId: "msg_00"})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = s.messages.GetMessages(alice, &pb.GetMessagesRequest{ChannelId: channelID, Before: "not a cursor"})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = s.messages.GetMessages(alice, &pb.GetMessagesRequest{ChannelId: otherID, AroundId: "msg_01"})
+	requireCode(t, err, codes.NotFound)
+}
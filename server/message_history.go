@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// GetMessageHistory returns a message, even a deleted one, with every version
+// its edits replaced. It needs MANAGE_MESSAGES in the message's channel.
+func (s *messageServiceServer) GetMessageHistory(ctx context.Context, req *pb.GetMessageHistoryRequest) (*pb.GetMessageHistoryResponse, error) {
+	if req.MessageId == "" {
+		return nil, status.Error(codes.InvalidArgument, "message_id is required")
+	}
+
+	db, _, err := s.router.ForResource(ctx, req.MessageId)
+	if err != nil {
+		return nil, routeError(err, "message not found")
+	}
+
+	dbMessage, err := db.GetMessageIncludingDeleted(ctx, req.MessageId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get message: %v", err)
+	}
+
+	if _, err := s.requireChannelPermission(ctx, db, dbMessage.ChannelID, PermManageMessages); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		return nil, err
+	}
+
+	message := dbMessageToProto(&dbMessage)
+	if message.Attachments, err = getMessageAttachments(ctx, db, message.MessageId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get attachments: %v", err)
+	}
+	if message.Embeds, err = getMessageEmbeds(ctx, db, message.MessageId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get embeds: %v", err)
+	}
+	if err := loadMessageReactions(ctx, db, []*pb.Message{message}, getActorFromContext(ctx)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get reactions: %v", err)
+	}
+	if err := loadMessageThreads(ctx, db, []*pb.Message{message}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get threads: %v", err)
+	}
+
+	revisions, err := getMessageRevisions(ctx, db, message.MessageId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get revisions: %v", err)
+	}
+
+	return &pb.GetMessageHistoryResponse{
+		Message:   message,
+		Revisions: revisions,
+	}, nil
+}
+
+// reviseMessage keeps the current content and embeds of a message as a
+// revision before an edit by editorID at editedAt replaces them. Deleted
+// messages can't be edited, so they are reported as sql.ErrNoRows.
+func reviseMessage(ctx context.Context, tx *database.Queries, messageID, editorID string, editedAt int64) error {
+	message, err := tx.GetMessage(ctx, messageID)
+	if err != nil {
+		return err
+	}
+	embeds, err := getMessageEmbeds(ctx, tx, messageID)
+	if err != nil {
+		return err
+	}
+	encoded, err := encodeRevisionEmbeds(embeds)
+	if err != nil {
+		return err
+	}
+
+	return tx.CreateMessageRevision(ctx, database.CreateMessageRevisionParams{
+		MessageID: messageID,
+		Content:   message.Content,
+		Embeds:    encoded,
+		EditorID:  editorID,
+		CreatedAt: message.UpdatedAt,
+		EditedAt:  editedAt,
+	})
+}
+
+// eraseMessage permanently removes what a deleted message said: its content,
+// revisions, attachments and embeds. The tombstone itself is kept.
+func eraseMessage(ctx context.Context, tx *database.Queries, messageID string, purgedAt int64) error {
+	if err := tx.DeleteMessageRevisionsByMessageId(ctx, messageID); err != nil {
+		return err
+	}
+	if err := tx.DeleteEmbedFieldsByMessageId(ctx, messageID); err != nil {
+		return err
+	}
+	if err := tx.DeleteEmbedsByMessageId(ctx, messageID); err != nil {
+		return err
+	}
+	if err := tx.DeleteAttachmentsByMessageId(ctx, messageID); err != nil {
+		return err
+	}
+	return tx.PurgeMessage(ctx, database.PurgeMessageParams{
+		PurgedAt:  sql.NullInt64{Int64: purgedAt, Valid: true},
+		MessageID: messageID,
+	})
+}
+
+func getMessageRevisions(ctx context.Context, db *database.Queries, messageID string) ([]*pb.MessageRevision, error) {
+	dbRevisions, err := db.ListMessageRevisions(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*pb.MessageRevision, len(dbRevisions))
+	for i, dbRevision := range dbRevisions {
+		embeds, err := decodeRevisionEmbeds(dbRevision.Embeds)
+		if err != nil {
+			return nil, err
+		}
+		revisions[i] = &pb.MessageRevision{
+			Content:   dbRevision.Content,
+			Embeds:    embeds,
+			EditorId:  dbRevision.EditorID,
+			CreatedAt: timestamppb.New(time.Unix(dbRevision.CreatedAt, 0)),
+			EditedAt:  timestamppb.New(time.Unix(dbRevision.EditedAt, 0)),
+		}
+	}
+	return revisions, nil
+}
+
+// Revisions store their embeds as a JSON array of protojson embeds.
+func encodeRevisionEmbeds(embeds []*pb.Embed) (string, error) {
+	encoded := make([]json.RawMessage, len(embeds))
+	for i, embed := range embeds {
+		raw, err := protojson.Marshal(embed)
+		if err != nil {
+			return "", err
+		}
+		encoded[i] = raw
+	}
+	out, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func decodeRevisionEmbeds(stored string) ([]*pb.Embed, error) {
+	var encoded []json.RawMessage
+	if err := json.Unmarshal([]byte(stored), &encoded); err != nil {
+		return nil, err
+	}
+	embeds := make([]*pb.Embed, len(encoded))
+	for i, raw := range encoded {
+		embeds[i] = &pb.Embed{}
+		if err := protojson.Unmarshal(raw, embeds[i]); err != nil {
+			return nil, err
+		}
+	}
+	return embeds, nil
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// purgeBatchSize bounds how many messages the purge job loads at once.
+const purgeBatchSize = 100
+
+// messagePurger is the background job that erases deleted messages for good
+// once they have been deleted for FUWA_MESSAGE_PURGE_AFTER. It removes their
+// content, revisions, attachments and embeds, redacts the copies of them in
+// the payloads of their message.sent and message.updated events and records
+// message.purged. The tombstone stays, so GetMessageHistory still shows who
+// deleted the message and when.
+type messagePurger struct {
+	events *eventServiceServer
+	router *DatabaseRouter
+	after  time.Duration
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewMessagePurger(config *Config, router *DatabaseRouter, eventService *eventServiceServer) *messagePurger {
+	return &messagePurger{
+		events: eventService,
+		router: router,
+		after:  config.MessagePurgeAfter,
+	}
+}
+
+// Start runs the job every interval until Stop is called. A purge period of
+// 0 keeps deleted messages forever.
+func (p *messagePurger) Start(interval time.Duration) {
+	if interval <= 0 || p.after <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	if p.stop != nil {
+		p.mu.Unlock()
+		return
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	stop, done := p.stop, p.done
+	p.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.Run(context.Background())
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the job started by Start.
+func (p *messagePurger) Stop() {
+	p.mu.Lock()
+	stop, done := p.stop, p.done
+	p.stop = nil
+	p.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Run purges the expired messages of every database file once.
+func (p *messagePurger) Run(ctx context.Context) {
+	if p.router == nil || p.router.manager == nil {
+		return
+	}
+
+	names, err := p.router.manager.DatabaseFiles()
+	if err != nil {
+		log.Printf("Failed to list databases to purge: %v", err)
+		return
+	}
+
+	for _, name := range names {
+		if err := p.purgeDatabase(ctx, name); err != nil {
+			log.Printf("Failed to purge messages of database %s: %v", name, err)
+		}
+	}
+}
+
+func (p *messagePurger) purgeDatabase(ctx context.Context, name string) error {
+	lease, err := p.router.manager.AcquireBackground(name)
+	if err != nil {
+		return err
+	}
+	defer lease.Release()
+
+	cutoff := time.Now().Add(-p.after).Unix()
+	var purged int
+	defer func() {
+		if purged > 0 {
+			p.events.notify()
+			log.Printf("Purged %d deleted messages from database %s", purged, name)
+		}
+	}()
+
+	for {
+		messages, err := lease.Queries.ListPurgeableMessages(ctx, database.ListPurgeableMessagesParams{
+			DeletedAt: sql.NullInt64{Int64: cutoff, Valid: true},
+			Limit:     purgeBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i := range messages {
+			if err := p.purgeMessage(ctx, lease, &messages[i]); err != nil {
+				return fmt.Errorf("purge of %s: %w", messages[i].MessageID, err)
+			}
+			purged++
+		}
+		if len(messages) < purgeBatchSize {
+			return nil
+		}
+	}
+}
+
+// purgeMessage erases one message and records message.purged in one
+// transaction. It runs on the database being purged directly rather than
+// through the router.
+func (p *messagePurger) purgeMessage(ctx context.Context, lease *DatabaseLease, message *database.Message) error {
+	tx, err := lease.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	queries := lease.Queries.WithTx(tx)
+
+	scope := fmt.Sprintf("channel:%s", message.ChannelID)
+	purgedAt := time.Now()
+	if err := eraseMessage(ctx, queries, message.MessageID, purgedAt.Unix()); err != nil {
+		return err
+	}
+	if err := redactMessageEvents(ctx, queries, scope, message.MessageID); err != nil {
+		return err
+	}
+
+	err = p.events.appendEvent(ctx, queries, &pb.Event{
+		EventId:   id.New(id.Event),
+		EventType: "message.purged",
+		Scope:     scope,
+		ActorId:   "system",
+		Timestamp: timestamppb.New(purgedAt),
+		Payload: eventPayload(&pb.MessagePurgedPayload{
+			MessageId: message.MessageID,
+			ChannelId: message.ChannelID,
+		}),
+		Metadata: map[string]string{
+			"message_id": message.MessageID,
+			"channel_id": message.ChannelID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// redactMessageEvents clears the content, attachments and embeds from the
+// message.sent and message.updated events of a message, so replaying the
+// log doesn't bring purged content back.
+func redactMessageEvents(ctx context.Context, tx *database.Queries, scope, messageID string) error {
+	events, err := tx.ListMessageEvents(ctx, database.ListMessageEventsParams{
+		Scope:     scope,
+		MessageID: messageID,
+	})
+	if err != nil {
+		return err
+	}
+	return redactEvents(ctx, tx, events)
+}
+
+// redactScopeMessageEvents does the same for every message of a scope, such
+// as the channel scope of a deleted channel.
+func redactScopeMessageEvents(ctx context.Context, tx *database.Queries, scope string) error {
+	events, err := tx.ListScopeMessageEvents(ctx, scope)
+	if err != nil {
+		return err
+	}
+	return redactEvents(ctx, tx, events)
+}
+
+func redactEvents(ctx context.Context, tx *database.Queries, events []database.Event) error {
+	for i := range events {
+		var payload interface {
+			proto.Message
+			GetMessage() *pb.Message
+		}
+		switch events[i].EventType {
+		case "message.sent":
+			payload = &pb.MessageSentPayload{}
+		case "message.updated":
+			payload = &pb.MessageUpdatedPayload{}
+		default:
+			continue
+		}
+		if err := unpackEventPayload(dbEventToProto(&events[i]), payload); err != nil {
+			return err
+		}
+		message := payload.GetMessage()
+		if message == nil {
+			continue
+		}
+		message.Content = ""
+		message.Attachments = nil
+		message.Embeds = nil
+
+		packed, err := anypb.New(payload)
+		if err != nil {
+			return err
+		}
+		stored, err := encodeEventPayload(packed)
+		if err != nil {
+			return err
+		}
+		err = tx.UpdateEventPayload(ctx, database.UpdateEventPayloadParams{
+			Payload: sql.NullString{String: stored, Valid: true},
+			EventID: events[i].EventID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
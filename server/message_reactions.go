@@ -0,0 +1,327 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// maxEmojiLength bounds the bytes of a reaction emoji, which is a Unicode
+// emoji sequence or the name of a custom emoji.
+const maxEmojiLength = 64
+
+// AddReaction reacts to a message with an emoji as the caller. Reacting
+// twice with the same emoji changes nothing.
+func (s *messageServiceServer) AddReaction(ctx context.Context, req *pb.AddReactionRequest) (*pb.AddReactionResponse, error) {
+	if req.MessageId == "" {
+		return nil, status.Error(codes.InvalidArgument, "message_id is required")
+	}
+	if err := validateEmoji(req.Emoji); err != nil {
+		return nil, err
+	}
+
+	db, message, err := s.getReactionMessage(ctx, req.MessageId, PermSendMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := getActorFromContext(ctx)
+	added, err := s.changeReaction(ctx, "message.reaction_added", message, req.Emoji, userID, func(tx *database.Queries) (int64, error) {
+		return tx.AddReaction(ctx, database.AddReactionParams{
+			MessageID: message.MessageID,
+			Emoji:     req.Emoji,
+			UserID:    userID,
+			CreatedAt: time.Now().Unix(),
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "add reaction")
+	}
+	if added {
+		s.eventService.notify()
+	}
+
+	reaction, err := getReaction(ctx, db, message.MessageID, req.Emoji, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get reactions: %v", err)
+	}
+	return &pb.AddReactionResponse{
+		Reaction: reaction,
+	}, nil
+}
+
+// RemoveReaction removes the caller's reaction with an emoji, or another
+// user's, which needs MANAGE_MESSAGES.
+func (s *messageServiceServer) RemoveReaction(ctx context.Context, req *pb.RemoveReactionRequest) (*pb.RemoveReactionResponse, error) {
+	if req.MessageId == "" {
+		return nil, status.Error(codes.InvalidArgument, "message_id is required")
+	}
+	if err := validateEmoji(req.Emoji); err != nil {
+		return nil, err
+	}
+
+	callerID := getActorFromContext(ctx)
+	userID := req.UserId
+	requiredPerm := PermManageMessages
+	if userID == "" || userID == callerID {
+		userID = callerID
+		requiredPerm = PermViewChannel
+	}
+
+	db, message, err := s.getReactionMessage(ctx, req.MessageId, requiredPerm)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.changeReaction(ctx, "message.reaction_removed", message, req.Emoji, userID, func(tx *database.Queries) (int64, error) {
+		return tx.RemoveReaction(ctx, database.RemoveReactionParams{
+			MessageID: message.MessageID,
+			Emoji:     req.Emoji,
+			UserID:    userID,
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "remove reaction")
+	}
+	if !removed {
+		return nil, status.Error(codes.NotFound, "reaction not found")
+	}
+	s.eventService.notify()
+
+	reaction, err := getReaction(ctx, db, message.MessageID, req.Emoji, callerID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get reactions: %v", err)
+	}
+	return &pb.RemoveReactionResponse{
+		Reaction: reaction,
+	}, nil
+}
+
+// ListReactions lists the users who reacted to a message.
+func (s *messageServiceServer) ListReactions(ctx context.Context, req *pb.ListReactionsRequest) (*pb.ListReactionsResponse, error) {
+	if req.MessageId == "" {
+		return nil, status.Error(codes.InvalidArgument, "message_id is required")
+	}
+	if req.Emoji != "" {
+		if err := validateEmoji(req.Emoji); err != nil {
+			return nil, err
+		}
+	}
+
+	var afterRowid int64
+	if req.Cursor != "" {
+		var err error
+		if afterRowid, err = parseReactionCursor(req.Cursor); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "malformed cursor")
+		}
+	}
+
+	db, message, err := s.getReactionMessage(ctx, req.MessageId, PermViewChannel)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64(50) // Default limit
+	if req.Limit > 0 && req.Limit <= 100 {
+		limit = int64(req.Limit)
+	}
+
+	// One extra row tells whether another page follows
+	rows, err := db.ListReactions(ctx, database.ListReactionsParams{
+		MessageID:  message.MessageID,
+		AnyEmoji:   req.Emoji == "",
+		Emoji:      req.Emoji,
+		AfterRowid: afterRowid,
+		Limit:      limit + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list reactions: %v", err)
+	}
+
+	resp := &pb.ListReactionsResponse{HasMore: int64(len(rows)) > limit}
+	if resp.HasMore {
+		rows = rows[:limit]
+		resp.NextCursor = reactionCursor(rows[len(rows)-1].Rowid)
+	}
+	for _, row := range rows {
+		resp.Reactions = append(resp.Reactions, &pb.UserReaction{
+			Emoji:     row.Reaction.Emoji,
+			UserId:    row.Reaction.UserID,
+			CreatedAt: timestamppb.New(time.Unix(row.Reaction.CreatedAt, 0)),
+		})
+	}
+	return resp, nil
+}
+
+// getReactionMessage loads a message and checks perm for the caller in its
+// channel. Messages in channels the caller can't see are reported as not
+// found.
+func (s *messageServiceServer) getReactionMessage(ctx context.Context, messageID string, perm Permissions) (*database.Queries, *database.Message, error) {
+	db, _, err := s.router.ForResource(ctx, messageID)
+	if err != nil {
+		return nil, nil, routeError(err, "message not found")
+	}
+
+	message, err := db.GetMessage(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, status.Error(codes.NotFound, "message not found")
+		}
+		return nil, nil, status.Errorf(codes.Internal, "failed to get message: %v", err)
+	}
+
+	if _, err := s.requireChannelPermission(ctx, db, message.ChannelID, perm); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil, status.Error(codes.NotFound, "message not found")
+		}
+		return nil, nil, err
+	}
+	return db, &message, nil
+}
+
+// changeReaction runs change, which adds or removes one reaction, and
+// records eventType in the same transaction if a row changed. It reports
+// whether one did. The message is looked up again within the transaction,
+// so a message deleted since it was loaded gets no reactions.
+func (s *messageServiceServer) changeReaction(ctx context.Context, eventType string, message *database.Message, emoji, userID string, change func(tx *database.Queries) (int64, error)) (bool, error) {
+	scope := fmt.Sprintf("channel:%s", message.ChannelID)
+	changed := false
+	err := s.router.InScopeTx(ctx, scope, func(ctx context.Context, tx *database.Queries) error {
+		if _, err := tx.GetMessage(ctx, message.MessageID); err != nil {
+			if err == sql.ErrNoRows {
+				return status.Error(codes.NotFound, "message not found")
+			}
+			return status.Errorf(codes.Internal, "failed to get message: %v", err)
+		}
+
+		rows, err := change(tx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to update reactions: %v", err)
+		}
+		if rows == 0 {
+			return nil
+		}
+		changed = true
+
+		reaction, err := getReaction(ctx, tx, message.MessageID, emoji, userID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to count reactions: %v", err)
+		}
+
+		return s.eventService.appendEvent(ctx, tx, &pb.Event{
+			EventId:   id.New(id.Event),
+			EventType: eventType,
+			Scope:     scope,
+			ActorId:   getActorFromContext(ctx),
+			Timestamp: timestamppb.Now(),
+			Payload: eventPayload(&pb.MessageReactionPayload{
+				MessageId: message.MessageID,
+				ChannelId: message.ChannelID,
+				Emoji:     emoji,
+				UserId:    userID,
+				Count:     reaction.Count,
+			}),
+			Metadata: map[string]string{
+				"message_id": message.MessageID,
+				"channel_id": message.ChannelID,
+				"emoji":      emoji,
+				"user_id":    userID,
+			},
+		})
+	})
+	return changed, err
+}
+
+// getReaction returns the reactions to a message with one emoji, with me
+// set if userID is among them.
+func getReaction(ctx context.Context, db *database.Queries, messageID, emoji, userID string) (*pb.Reaction, error) {
+	rows, err := db.ListReactionCounts(ctx, database.ListReactionCountsParams{
+		UserID:     userID,
+		MessageIds: []string{messageID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if row.Emoji == emoji {
+			return &pb.Reaction{Emoji: row.Emoji, Count: int32(row.Count), Me: row.Me}, nil
+		}
+	}
+	return &pb.Reaction{Emoji: emoji}, nil
+}
+
+// loadMessageReactions sets the reactions of messages, with me set on those
+// userID is among.
+func loadMessageReactions(ctx context.Context, db *database.Queries, messages []*pb.Message, userID string) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	byID, messageIDs := indexMessages(messages)
+
+	rows, err := db.ListReactionCounts(ctx, database.ListReactionCountsParams{
+		UserID:     userID,
+		MessageIds: messageIDs,
+	})
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		message := byID[row.MessageID]
+		message.Reactions = append(message.Reactions, &pb.Reaction{
+			Emoji: row.Emoji,
+			Count: int32(row.Count),
+			Me:    row.Me,
+		})
+	}
+	return nil
+}
+
+func validateEmoji(emoji string) error {
+	if emoji == "" {
+		return status.Error(codes.InvalidArgument, "emoji is required")
+	}
+	if len(emoji) > maxEmojiLength {
+		return status.Errorf(codes.InvalidArgument, "emoji must be at most %d bytes", maxEmojiLength)
+	}
+	invalid := func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }
+	if !utf8.ValidString(emoji) || strings.ContainsFunc(emoji, invalid) {
+		return status.Error(codes.InvalidArgument, "invalid emoji")
+	}
+	return nil
+}
+
+// Reaction cursors hold the position of the last reaction returned in the
+// order reactions were added.
+func reactionCursor(rowid int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("reactions/" + strconv.FormatInt(rowid, 10)))
+}
+
+func parseReactionCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	rowid, err := strconv.ParseInt(strings.TrimPrefix(string(raw), "reactions/"), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if rowid < 0 {
+		return 0, errors.New("negative position")
+	}
+	return rowid, nil
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+func TestReactionToMessageDeletedMeanwhile(t *testing.T) {
+	s, channelID := newTestServerWithChannel(t)
+	alice := s.as(t, "alice")
+
+	sent, err := s.messages.SendMessage(alice, &pb.SendMessageRequest{ChannelId: channelID, Content: "hi"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	_, message, err := s.messages.getReactionMessage(alice, sent.Message.MessageId, PermSendMessages)
+	if err != nil {
+		t.Fatalf("getReactionMessage: %v", err)
+	}
+
+	// The message is deleted between loading it and reacting
+	if _, err := s.messages.DeleteMessage(alice, &pb.DeleteMessageRequest{MessageId: message.MessageID}); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	before := len(storedEvents(t, s, alice, "channel:"+channelID))
+
+	added, err := s.messages.changeReaction(alice, "message.reaction_added", message, "👍", "alice", func(tx *database.Queries) (int64, error) {
+		return tx.AddReaction(alice, database.AddReactionParams{
+			MessageID: message.MessageID,
+			Emoji:     "👍",
+			UserID:    "alice",
+			CreatedAt: time.Now().Unix(),
+		})
+	})
+	requireCode(t, err, codes.NotFound)
+	if added {
+		t.Error("reacted to a deleted message")
+	}
+	if after := len(storedEvents(t, s, alice, "channel:"+channelID)); after != before {
+		t.Errorf("recorded %d events for a reaction to a deleted message", after-before)
+	}
+
+	_, err = s.messages.AddReaction(alice, &pb.AddReactionRequest{MessageId: message.MessageID, Emoji: "👍"})
+	requireCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// SearchMessages finds messages containing every word of the query in the
+// channels of a server, or in one channel, that the caller can view.
+func (s *messageServiceServer) SearchMessages(ctx context.Context, req *pb.SearchMessagesRequest) (*pb.SearchMessagesResponse, error) {
+	match := ftsQuery(req.Query)
+	if match == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	createdAfter, createdBefore := int64(0), int64(math.MaxInt64)
+	if req.CreatedAfter != nil {
+		createdAfter = req.CreatedAfter.AsTime().Unix()
+	}
+	if req.CreatedBefore != nil {
+		createdBefore = req.CreatedBefore.AsTime().Unix()
+	}
+	if createdAfter >= createdBefore {
+		return nil, status.Error(codes.InvalidArgument, "created_after must be before created_before")
+	}
+
+	var offset int64
+	if req.Cursor != "" {
+		var err error
+		if offset, err = parseSearchCursor(req.Cursor); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "malformed cursor")
+		}
+	}
+
+	db, channelIDs, err := s.searchableChannels(ctx, req.ServerId, req.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+	if len(channelIDs) == 0 {
+		return &pb.SearchMessagesResponse{}, nil
+	}
+
+	limit := int64(25) // Default limit
+	if req.Limit > 0 && req.Limit <= 100 {
+		limit = int64(req.Limit)
+	}
+	highlightStart, highlightEnd := req.HighlightStart, req.HighlightEnd
+	if highlightStart == "" {
+		highlightStart = "**"
+	}
+	if highlightEnd == "" {
+		highlightEnd = "**"
+	}
+
+	// One extra row tells whether another page follows
+	rows, err := db.SearchMessages(ctx, database.SearchMessagesParams{
+		HighlightStart: highlightStart,
+		HighlightEnd:   highlightEnd,
+		Query:          match,
+		ChannelIds:     channelIDs,
+		AnyAuthor:      req.AuthorId == "",
+		AuthorID:       req.AuthorId,
+		CreatedAfter:   createdAfter,
+		CreatedBefore:  createdBefore,
+		HasAttachment:  req.HasAttachment,
+		HasEmbed:       req.HasEmbed,
+		Limit:          limit + 1,
+		Offset:         offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search messages: %v", err)
+	}
+
+	resp := &pb.SearchMessagesResponse{HasMore: int64(len(rows)) > limit}
+	if resp.HasMore {
+		rows = rows[:limit]
+		resp.NextCursor = searchCursor(offset + limit)
+	}
+
+	messages := make([]*pb.Message, len(rows))
+	for i, row := range rows {
+		message := dbMessageToProto(&row.Message)
+		messages[i] = message
+		resp.Results = append(resp.Results, &pb.MessageSearchResult{
+			Message: message,
+			Snippet: row.Snippet,
+			// bm25 ranks better matches lower
+			Score: -row.Rank,
+		})
+	}
+	if err := loadMessageAttachments(ctx, db, messages); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get attachments: %v", err)
+	}
+	if err := loadMessageEmbeds(ctx, db, messages); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get embeds: %v", err)
+	}
+	if err := loadMessageReactions(ctx, db, messages, getActorFromContext(ctx)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get reactions: %v", err)
+	}
+	if err := loadMessageThreads(ctx, db, messages); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get threads: %v", err)
+	}
+	return resp, nil
+}
+
+// searchableChannels returns the database to search and the channels in it
+// the caller can view.
+func (s *messageServiceServer) searchableChannels(ctx context.Context, serverID, channelID string) (*database.Queries, []string, error) {
+	if channelID != "" {
+		db, _, err := s.router.ForResource(ctx, channelID)
+		if err != nil {
+			return nil, nil, routeError(err, "channel not found")
+		}
+		channel, err := s.requireChannelPermission(ctx, db, channelID, PermViewChannel)
+		if err != nil {
+			return nil, nil, err
+		}
+		if serverID != "" && channel.ServerID.String != serverID {
+			return nil, nil, status.Error(codes.InvalidArgument, "channel is not in the server")
+		}
+		return db, []string{channelID}, nil
+	}
+
+	if serverID == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "server_id or channel_id is required")
+	}
+	db, err := s.router.ForServer(ctx, serverID)
+	if err != nil {
+		return nil, nil, routeError(err, "server not found")
+	}
+	channels, err := db.ListChannelsByServerId(ctx, sql.NullString{String: serverID, Valid: true})
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to list channels: %v", err)
+	}
+
+	userID := s.permissions.callerID(ctx)
+	var channelIDs []string
+	for i := range channels {
+		visible, err := s.permissions.CanViewChannel(ctx, userID, &channels[i])
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to resolve permissions: %v", err)
+		}
+		if visible {
+			channelIDs = append(channelIDs, channels[i].ChannelID)
+		}
+	}
+	return db, channelIDs, nil
+}
+
+// ftsQuery turns a search into an FTS5 query matching messages that contain
+// every word. Each word is quoted so FTS5 operators in it are taken literally.
+func ftsQuery(search string) string {
+	words := strings.Fields(search)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+	}
+	return strings.Join(words, " ")
+}
+
+// Search cursors hold the position in the ranking where the next page
+// starts. Ranks change as messages are sent and edited, so unlike history
+// cursors they can't name the last message returned.
+func searchCursor(offset int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("search/" + strconv.FormatInt(offset, 10)))
+}
+
+func parseSearchCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.ParseInt(strings.TrimPrefix(string(raw), "search/"), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if offset < 0 {
+		return 0, errors.New("negative offset")
+	}
+	return offset, nil
+}
@@ -5,27 +5,31 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"strconv"
+	"slices"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
 	pb "github.com/waifu-devs/fuwa/server/proto"
 )
 
 type messageServiceServer struct {
 	pb.UnimplementedMessageServiceServer
-	db           *database.Queries
+	router       *DatabaseRouter
 	eventService *eventServiceServer
+	permissions  *PermissionResolver
 }
 
-func NewMessageServiceServer(db *database.Queries, eventService *eventServiceServer) *messageServiceServer {
+func NewMessageServiceServer(router *DatabaseRouter, eventService *eventServiceServer, permissions *PermissionResolver) *messageServiceServer {
 	return &messageServiceServer{
-		db:           db,
+		router:       router,
 		eventService: eventService,
+		permissions:  permissions,
 	}
 }
 
@@ -37,109 +41,89 @@ func (s *messageServiceServer) SendMessage(ctx context.Context, req *pb.SendMess
 		return nil, status.Error(codes.InvalidArgument, "message must have content, attachments, or embeds")
 	}
 
-	// Generate message ID
-	messageID := fmt.Sprintf("message_%d", time.Now().UnixNano())
-	now := time.Now().Unix()
-
-	// Create message in database
-	dbMessage, err := s.db.CreateMessage(ctx, database.CreateMessageParams{
-		MessageID: messageID,
-		ChannelID: req.ChannelId,
-		AuthorID:  getActorFromContext(ctx), // TODO: Get from auth context
-		Content:   req.Content,
-		CreatedAt: now,
-		UpdatedAt: now,
-		ReplyToID: sql.NullString{String: req.ReplyToId, Valid: req.ReplyToId != ""},
-	})
+	db, serverID, err := s.router.ForResource(ctx, req.ChannelId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create message: %v", err)
+		return nil, routeError(err, "channel not found")
 	}
 
-	// Handle attachments
-	var attachments []*pb.Attachment
-	for _, attachment := range req.Attachments {
-		attachmentID := fmt.Sprintf("attachment_%d", time.Now().UnixNano())
-		_, err := s.db.CreateAttachment(ctx, database.CreateAttachmentParams{
-			AttachmentID: attachmentID,
-			MessageID:    messageID,
-			Filename:     attachment.Filename,
-			ContentType:  attachment.ContentType,
-			Size:         attachment.Size,
-			Url:          attachment.Url,
-		})
-		if err != nil {
-			log.Printf("Failed to save attachment: %v", err)
-			continue
+	channel, err := s.requireChannelPermission(ctx, db, req.ChannelId, PermSendMessages)
+	if err != nil {
+		return nil, err
+	}
+	isThread := pb.ChannelType(channel.Type) == pb.ChannelType_CHANNEL_TYPE_THREAD
+	if isThread {
+		if err := s.requireUnlockedThread(ctx, db, channel); err != nil {
+			return nil, err
 		}
-
-		// Add ID to response attachment
-		attachment.AttachmentId = attachmentID
-		attachments = append(attachments, attachment)
 	}
 
-	// Handle embeds
-	var embeds []*pb.Embed
-	for _, embed := range req.Embeds {
-		embedID := time.Now().UnixNano()
-		_, err := s.db.CreateEmbed(ctx, database.CreateEmbedParams{
-			EmbedID:      embedID,
-			MessageID:    messageID,
-			Title:        sql.NullString{String: embed.Title, Valid: embed.Title != ""},
-			Description:  sql.NullString{String: embed.Description, Valid: embed.Description != ""},
-			Url:          sql.NullString{String: embed.Url, Valid: embed.Url != ""},
-			Color:        sql.NullInt64{Int64: int64(embed.Color), Valid: embed.Color != 0},
-			ThumbnailUrl: sql.NullString{String: embed.ThumbnailUrl, Valid: embed.ThumbnailUrl != ""},
-			ImageUrl:     sql.NullString{String: embed.ImageUrl, Valid: embed.ImageUrl != ""},
+	// Generate message ID
+	messageID := id.New(id.Message)
+	now := time.Now().Unix()
+
+	// The route lives in the primary database, so it is registered first
+	if err := s.router.AddRoute(ctx, messageID, serverID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to register message route: %v", err)
+	}
+
+	// The message, its attachments and embeds and the message.sent event
+	// are committed together
+	var protoMessage *pb.Message
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("channel:%s", req.ChannelId), func(ctx context.Context, tx *database.Queries) error {
+		dbMessage, err := tx.CreateMessage(ctx, database.CreateMessageParams{
+			MessageID: messageID,
+			ChannelID: req.ChannelId,
+			AuthorID:  getActorFromContext(ctx),
+			Content:   req.Content,
+			CreatedAt: now,
+			UpdatedAt: now,
+			ReplyToID: sql.NullString{String: req.ReplyToId, Valid: req.ReplyToId != ""},
 		})
 		if err != nil {
-			log.Printf("Failed to save embed: %v", err)
-			continue
+			return status.Errorf(codes.Internal, "failed to create message: %v", err)
 		}
 
-		// Handle embed fields
-		for _, field := range embed.Fields {
-			fieldID := time.Now().UnixNano()
-			_, err := s.db.CreateEmbedField(ctx, database.CreateEmbedFieldParams{
-				FieldID: fieldID,
-				EmbedID: embedID,
-				Name:    field.Name,
-				Value:   field.Value,
-				Inline:  boolToInt64(field.Inline),
-			})
-			if err != nil {
-				log.Printf("Failed to save embed field: %v", err)
+		// Convert to proto message
+		protoMessage = dbMessageToProto(&dbMessage)
+		protoMessage.Attachments = req.Attachments
+		protoMessage.Embeds = req.Embeds
+
+		// Attachment IDs are assigned by the server
+		for _, attachment := range protoMessage.Attachments {
+			attachment.AttachmentId = ""
+		}
+		if err := saveAttachments(ctx, tx, protoMessage); err != nil {
+			return err
+		}
+		if err := saveEmbeds(ctx, tx, messageID, protoMessage.Embeds); err != nil {
+			return err
+		}
+		if isThread {
+			if err := recordThreadMessage(ctx, tx, req.ChannelId, protoMessage.AuthorId, now); err != nil {
+				return status.Errorf(codes.Internal, "failed to update thread: %v", err)
 			}
 		}
 
-		embeds = append(embeds, embed)
-	}
-
-	// Convert to proto message
-	protoMessage := dbMessageToProto(&dbMessage)
-	protoMessage.Attachments = attachments
-	protoMessage.Embeds = embeds
-
-	// Publish message.sent event
-	if s.eventService != nil {
-		eventID := fmt.Sprintf("message-sent-%d", time.Now().UnixNano())
-		event := &pb.Event{
-			EventId:   eventID,
+		return s.eventService.appendEvent(ctx, tx, &pb.Event{
+			EventId:   id.New(id.Event),
 			EventType: "message.sent",
 			Scope:     fmt.Sprintf("channel:%s", req.ChannelId),
 			ActorId:   getActorFromContext(ctx),
 			Timestamp: timestamppb.Now(),
+			Payload:   eventPayload(&pb.MessageSentPayload{Message: protoMessage}),
 			Metadata: map[string]string{
 				"message_id": messageID,
 				"channel_id": req.ChannelId,
 			},
-			Sequence: time.Now().Unix(),
-		}
-
-		_, err = s.eventService.Publish(ctx, &pb.PublishRequest{Event: event})
-		if err != nil {
-			log.Printf("Failed to publish message.sent event: %v", err)
+		})
+	})
+	if err != nil {
+		if err := s.router.RemoveRoute(ctx, messageID); err != nil {
+			log.Printf("Failed to remove route for message %s: %v", messageID, err)
 		}
+		return nil, txError(err, "send message")
 	}
+	s.eventService.notify()
 
 	return &pb.SendMessageResponse{
 		Message: protoMessage,
@@ -151,7 +135,12 @@ func (s *messageServiceServer) GetMessage(ctx context.Context, req *pb.GetMessag
 		return nil, status.Error(codes.InvalidArgument, "message_id is required")
 	}
 
-	dbMessage, err := s.db.GetMessage(ctx, req.MessageId)
+	db, _, err := s.router.ForResource(ctx, req.MessageId)
+	if err != nil {
+		return nil, routeError(err, "message not found")
+	}
+
+	dbMessage, err := db.GetMessage(ctx, req.MessageId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, status.Error(codes.NotFound, "message not found")
@@ -159,21 +148,27 @@ func (s *messageServiceServer) GetMessage(ctx context.Context, req *pb.GetMessag
 		return nil, status.Errorf(codes.Internal, "failed to get message: %v", err)
 	}
 
-	// Get attachments
-	attachments, err := s.getMessageAttachments(ctx, req.MessageId)
-	if err != nil {
-		log.Printf("Failed to get attachments: %v", err)
-	}
-
-	// Get embeds
-	embeds, err := s.getMessageEmbeds(ctx, req.MessageId)
-	if err != nil {
-		log.Printf("Failed to get embeds: %v", err)
+	if _, err := s.requireChannelPermission(ctx, db, dbMessage.ChannelID, PermViewChannel); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		return nil, err
 	}
 
 	protoMessage := dbMessageToProto(&dbMessage)
-	protoMessage.Attachments = attachments
-	protoMessage.Embeds = embeds
+	messages := []*pb.Message{protoMessage}
+	if err := loadMessageAttachments(ctx, db, messages); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get attachments: %v", err)
+	}
+	if err := loadMessageEmbeds(ctx, db, messages); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get embeds: %v", err)
+	}
+	if err := loadMessageReactions(ctx, db, messages, getActorFromContext(ctx)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get reactions: %v", err)
+	}
+	if err := loadMessageThreads(ctx, db, messages); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get threads: %v", err)
+	}
 
 	return &pb.GetMessageResponse{
 		Message: protoMessage,
@@ -185,57 +180,60 @@ func (s *messageServiceServer) GetMessages(ctx context.Context, req *pb.GetMessa
 		return nil, status.Error(codes.InvalidArgument, "channel_id is required")
 	}
 
+	db, _, err := s.router.ForResource(ctx, req.ChannelId)
+	if err != nil {
+		return nil, routeError(err, "channel not found")
+	}
+
+	if _, err := s.requireChannelPermission(ctx, db, req.ChannelId, PermViewChannel); err != nil {
+		return nil, err
+	}
+
 	limit := int64(50) // Default limit
 	if req.Limit > 0 && req.Limit <= 100 {
 		limit = int64(req.Limit)
 	}
 
-	// Handle pagination with before_id and after_id
-	var beforeTime, afterTime int64
-	if req.BeforeId != "" {
-		// Parse timestamp from ID (simplified - in production you'd want better ID design)
-		if id, err := strconv.ParseInt(req.BeforeId[8:], 10, 64); err == nil {
-			beforeTime = id
-		}
-	}
-	if req.AfterId != "" {
-		if id, err := strconv.ParseInt(req.AfterId[8:], 10, 64); err == nil {
-			afterTime = id
-		}
+	mode, cursor, err := messagePageRequest(ctx, db, req)
+	if err != nil {
+		return nil, err
 	}
-
-	dbMessages, err := s.db.GetMessages(ctx, database.GetMessagesParams{
-		ChannelID:   req.ChannelId,
-		CreatedAt:   beforeTime,
-		Column3:     beforeTime,
-		CreatedAt_2: afterTime,
-		Column5:     afterTime,
-		Limit:       limit,
-	})
+	page, err := readMessagePage(ctx, db, req.ChannelId, mode, cursor, limit)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get messages: %v", err)
 	}
 
-	messages := make([]*pb.Message, len(dbMessages))
-	for i, dbMessage := range dbMessages {
-		protoMessage := dbMessageToProto(&dbMessage)
-
-		// Get attachments and embeds for each message
-		attachments, _ := s.getMessageAttachments(ctx, dbMessage.MessageID)
-		embeds, _ := s.getMessageEmbeds(ctx, dbMessage.MessageID)
-
-		protoMessage.Attachments = attachments
-		protoMessage.Embeds = embeds
-		messages[i] = protoMessage
+	messages := make([]*pb.Message, len(page.messages))
+	for i := range page.messages {
+		messages[i] = dbMessageToProto(&page.messages[i])
+	}
+	if err := loadMessageAttachments(ctx, db, messages); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get attachments: %v", err)
+	}
+	if err := loadMessageEmbeds(ctx, db, messages); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get embeds: %v", err)
+	}
+	if err := loadMessageReactions(ctx, db, messages, getActorFromContext(ctx)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get reactions: %v", err)
+	}
+	if err := loadMessageThreads(ctx, db, messages); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get threads: %v", err)
 	}
 
-	// Check if there are more messages
-	hasMore := len(messages) == int(limit)
-
-	return &pb.GetMessagesResponse{
-		Messages: messages,
-		HasMore:  hasMore,
-	}, nil
+	resp := &pb.GetMessagesResponse{
+		Messages:      messages,
+		HasMore:       page.hasBefore,
+		HasMoreBefore: page.hasBefore,
+		HasMoreAfter:  page.hasAfter,
+	}
+	if mode == pageAfter {
+		resp.HasMore = page.hasAfter
+	}
+	if len(messages) > 0 || mode != pageLatest {
+		resp.BeforeCursor = page.before.String()
+		resp.AfterCursor = page.after.String()
+	}
+	return resp, nil
 }
 
 func (s *messageServiceServer) UpdateMessage(ctx context.Context, req *pb.UpdateMessageRequest) (*pb.UpdateMessageResponse, error) {
@@ -243,8 +241,43 @@ func (s *messageServiceServer) UpdateMessage(ctx context.Context, req *pb.Update
 		return nil, status.Error(codes.InvalidArgument, "message_id is required")
 	}
 
+	// Without a mask, the fields that are set are updated
+	mask := req.UpdateMask
+	if len(mask) == 0 {
+		if req.Content != "" {
+			mask = append(mask, "content")
+		}
+		if len(req.Embeds) > 0 {
+			mask = append(mask, "embeds")
+		}
+		if len(req.Attachments) > 0 {
+			mask = append(mask, "attachments")
+		}
+		if len(mask) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "update_mask is required")
+		}
+	}
+	var updateContent, updateEmbeds, updateAttachments bool
+	for _, field := range mask {
+		switch field {
+		case "content":
+			updateContent = true
+		case "embeds":
+			updateEmbeds = true
+		case "attachments":
+			updateAttachments = true
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown update_mask field %q", field)
+		}
+	}
+
+	db, _, err := s.router.ForResource(ctx, req.MessageId)
+	if err != nil {
+		return nil, routeError(err, "message not found")
+	}
+
 	// Get existing message first
-	existingMessage, err := s.db.GetMessage(ctx, req.MessageId)
+	existingMessage, err := db.GetMessage(ctx, req.MessageId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, status.Error(codes.NotFound, "message not found")
@@ -252,45 +285,113 @@ func (s *messageServiceServer) UpdateMessage(ctx context.Context, req *pb.Update
 		return nil, status.Errorf(codes.Internal, "failed to get message: %v", err)
 	}
 
-	// Update message
-	dbMessage, err := s.db.UpdateMessage(ctx, database.UpdateMessageParams{
-		Content:   req.Content,
-		UpdatedAt: time.Now().Unix(),
-		MessageID: req.MessageId,
-	})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update message: %v", err)
+	// Only the author may edit a message, and only while they can still see the channel
+	if _, err := s.requireChannelPermission(ctx, db, existingMessage.ChannelID, PermViewChannel); err != nil {
+		return nil, err
 	}
-
-	// Handle embed updates (simplified - delete and recreate)
-	if len(req.Embeds) > 0 {
-		// TODO: Delete existing embeds and create new ones
-		// This is a simplified implementation
+	if userID := s.permissions.callerID(ctx); userID != "" && userID != existingMessage.AuthorID {
+		return nil, status.Error(codes.PermissionDenied, "only the author can edit a message")
 	}
 
-	protoMessage := dbMessageToProto(&dbMessage)
-	protoMessage.Embeds = req.Embeds
+	// Keep the replaced version, update the message, replace its embeds and
+	// attachments and record message.updated in one transaction. Fields that
+	// end up unchanged are left out of changed_fields, and an update that
+	// changes nothing records nothing
+	var protoMessage *pb.Message
+	var changed []string
+	now := time.Now().Unix()
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("channel:%s", existingMessage.ChannelID), func(ctx context.Context, tx *database.Queries) error {
+		dbMessage, err := tx.GetMessage(ctx, req.MessageId)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return status.Error(codes.NotFound, "message not found")
+			}
+			return status.Errorf(codes.Internal, "failed to get message: %v", err)
+		}
+		protoMessage = dbMessageToProto(&dbMessage)
+		if protoMessage.Attachments, err = getMessageAttachments(ctx, tx, req.MessageId); err != nil {
+			return status.Errorf(codes.Internal, "failed to get attachments: %v", err)
+		}
+		if protoMessage.Embeds, err = getMessageEmbeds(ctx, tx, req.MessageId); err != nil {
+			return status.Errorf(codes.Internal, "failed to get embeds: %v", err)
+		}
 
-	// Publish message.updated event
-	if s.eventService != nil {
-		eventID := fmt.Sprintf("message-updated-%d", time.Now().UnixNano())
-		event := &pb.Event{
-			EventId:   eventID,
+		if updateContent && req.Content != protoMessage.Content {
+			changed = append(changed, "content")
+		}
+		if updateEmbeds && !protoListsEqual(req.Embeds, protoMessage.Embeds) {
+			changed = append(changed, "embeds")
+		}
+		var attachments []*pb.Attachment
+		if updateAttachments {
+			if attachments, err = resolveAttachments(protoMessage.Attachments, req.Attachments); err != nil {
+				return err
+			}
+			if !protoListsEqual(attachments, protoMessage.Attachments) {
+				changed = append(changed, "attachments")
+			}
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+
+		if err := reviseMessage(ctx, tx, req.MessageId, getActorFromContext(ctx), now); err != nil {
+			return status.Errorf(codes.Internal, "failed to save revision: %v", err)
+		}
+
+		content := protoMessage.Content
+		for _, field := range changed {
+			switch field {
+			case "content":
+				content = req.Content
+			case "embeds":
+				protoMessage.Embeds = req.Embeds
+				if err := replaceEmbeds(ctx, tx, req.MessageId, protoMessage.Embeds); err != nil {
+					return err
+				}
+			case "attachments":
+				protoMessage.Attachments = attachments
+				if err := replaceAttachments(ctx, tx, protoMessage); err != nil {
+					return err
+				}
+			}
+		}
+		if content == "" && len(protoMessage.Attachments) == 0 && len(protoMessage.Embeds) == 0 {
+			return status.Error(codes.InvalidArgument, "message must have content, attachments, or embeds")
+		}
+
+		dbMessage, err = tx.UpdateMessage(ctx, database.UpdateMessageParams{
+			Content:   content,
+			UpdatedAt: now,
+			MessageID: req.MessageId,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to update message: %v", err)
+		}
+		protoMessage.Content = dbMessage.Content
+		protoMessage.UpdatedAt = timestamppb.New(time.Unix(dbMessage.UpdatedAt, 0))
+
+		return s.eventService.appendEvent(ctx, tx, &pb.Event{
+			EventId:   id.New(id.Event),
 			EventType: "message.updated",
 			Scope:     fmt.Sprintf("channel:%s", existingMessage.ChannelID),
 			ActorId:   getActorFromContext(ctx),
 			Timestamp: timestamppb.Now(),
+			Payload: eventPayload(&pb.MessageUpdatedPayload{
+				Message:       protoMessage,
+				ChangedFields: changed,
+			}),
 			Metadata: map[string]string{
 				"message_id": req.MessageId,
 				"channel_id": existingMessage.ChannelID,
 			},
-			Sequence: time.Now().Unix(),
-		}
-
-		_, err = s.eventService.Publish(ctx, &pb.PublishRequest{Event: event})
-		if err != nil {
-			log.Printf("Failed to publish message.updated event: %v", err)
-		}
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "update message")
+	}
+	if len(changed) > 0 {
+		s.eventService.notify()
 	}
 
 	return &pb.UpdateMessageResponse{
@@ -303,8 +404,13 @@ func (s *messageServiceServer) DeleteMessage(ctx context.Context, req *pb.Delete
 		return nil, status.Error(codes.InvalidArgument, "message_id is required")
 	}
 
+	db, _, err := s.router.ForResource(ctx, req.MessageId)
+	if err != nil {
+		return nil, routeError(err, "message not found")
+	}
+
 	// Get message info before deletion for event
-	existingMessage, err := s.db.GetMessage(ctx, req.MessageId)
+	existingMessage, err := db.GetMessage(ctx, req.MessageId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, status.Error(codes.NotFound, "message not found")
@@ -312,95 +418,325 @@ func (s *messageServiceServer) DeleteMessage(ctx context.Context, req *pb.Delete
 		return nil, status.Errorf(codes.Internal, "failed to get message: %v", err)
 	}
 
-	// Delete message (this should cascade to attachments and embeds)
-	err = s.db.DeleteMessage(ctx, req.MessageId)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete message: %v", err)
+	// Authors may delete their own messages, everyone else needs MANAGE_MESSAGES
+	requiredPerm := PermManageMessages
+	if s.permissions.callerID(ctx) == existingMessage.AuthorID {
+		requiredPerm = PermViewChannel
+	}
+	if _, err := s.requireChannelPermission(ctx, db, existingMessage.ChannelID, requiredPerm); err != nil {
+		return nil, err
 	}
 
-	// Publish message.deleted event
-	if s.eventService != nil {
-		eventID := fmt.Sprintf("message-deleted-%d", time.Now().UnixNano())
-		event := &pb.Event{
-			EventId:   eventID,
+	// Tombstone the message and record message.deleted in one transaction.
+	// Its content stays for GetMessageHistory until the purge job erases it
+	deletedAt := time.Now()
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("channel:%s", existingMessage.ChannelID), func(ctx context.Context, tx *database.Queries) error {
+		deleted, err := tx.TombstoneMessage(ctx, database.TombstoneMessageParams{
+			DeletedAt: sql.NullInt64{Int64: deletedAt.Unix(), Valid: true},
+			DeletedBy: sql.NullString{String: getActorFromContext(ctx), Valid: true},
+			MessageID: req.MessageId,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to delete message: %v", err)
+		}
+		if deleted == 0 {
+			return status.Error(codes.NotFound, "message not found")
+		}
+
+		if err := tx.DeleteReactionsByMessageId(ctx, req.MessageId); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete reactions: %v", err)
+		}
+		if err := tx.RemoveThreadMessage(ctx, existingMessage.ChannelID); err != nil {
+			return status.Errorf(codes.Internal, "failed to update thread: %v", err)
+		}
+
+		return s.eventService.appendEvent(ctx, tx, &pb.Event{
+			EventId:   id.New(id.Event),
 			EventType: "message.deleted",
 			Scope:     fmt.Sprintf("channel:%s", existingMessage.ChannelID),
 			ActorId:   getActorFromContext(ctx),
-			Timestamp: timestamppb.Now(),
+			Timestamp: timestamppb.New(deletedAt),
+			Payload: eventPayload(&pb.MessageDeletedPayload{
+				MessageId: req.MessageId,
+				ChannelId: existingMessage.ChannelID,
+			}),
 			Metadata: map[string]string{
 				"message_id": req.MessageId,
 				"channel_id": existingMessage.ChannelID,
 			},
-			Sequence: time.Now().Unix(),
-		}
-
-		_, err = s.eventService.Publish(ctx, &pb.PublishRequest{Event: event})
-		if err != nil {
-			log.Printf("Failed to publish message.deleted event: %v", err)
-		}
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "delete message")
 	}
+	s.eventService.notify()
 
 	return &pb.DeleteMessageResponse{
 		Success: true,
 	}, nil
 }
 
-func (s *messageServiceServer) getMessageAttachments(ctx context.Context, messageID string) ([]*pb.Attachment, error) {
-	dbAttachments, err := s.db.GetAttachmentsByMessageId(ctx, messageID)
+// requireChannelPermission loads the channel and checks perm for the caller.
+func (s *messageServiceServer) requireChannelPermission(ctx context.Context, db *database.Queries, channelID string, perm Permissions) (*database.Channel, error) {
+	channel, err := db.GetChannel(ctx, channelID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "channel not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get channel: %v", err)
+	}
+	if err := s.permissions.RequireChannelPermission(ctx, &channel, perm); err != nil {
 		return nil, err
 	}
+	return &channel, nil
+}
 
-	attachments := make([]*pb.Attachment, len(dbAttachments))
-	for i, dbAttachment := range dbAttachments {
-		attachments[i] = &pb.Attachment{
-			AttachmentId: dbAttachment.AttachmentID,
-			Filename:     dbAttachment.Filename,
-			ContentType:  dbAttachment.ContentType,
-			Size:         dbAttachment.Size,
-			Url:          dbAttachment.Url,
+// requireUnlockedThread checks that the caller may post in a thread: locked
+// threads take MANAGE_CHANNELS.
+func (s *messageServiceServer) requireUnlockedThread(ctx context.Context, db *database.Queries, channel *database.Channel) error {
+	thread, err := db.GetThread(ctx, channel.ChannelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return status.Error(codes.NotFound, "thread not found")
+		}
+		return status.Errorf(codes.Internal, "failed to get thread: %v", err)
+	}
+	if thread.Locked == 0 {
+		return nil
+	}
+	if err := s.permissions.RequireChannelPermission(ctx, channel, PermManageChannels); err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return status.Error(codes.PermissionDenied, "thread is locked")
 		}
+		return err
 	}
+	return nil
+}
 
-	return attachments, nil
+// recordThreadMessage counts a message sent to a thread, which unarchives
+// it and makes the author a member.
+func recordThreadMessage(ctx context.Context, tx *database.Queries, threadID, authorID string, sentAt int64) error {
+	if err := tx.RecordThreadMessage(ctx, database.RecordThreadMessageParams{
+		LastActivityAt: sentAt,
+		ChannelID:      threadID,
+	}); err != nil {
+		return err
+	}
+	_, err := tx.AddThreadMember(ctx, database.AddThreadMemberParams{
+		ChannelID: threadID,
+		UserID:    authorID,
+		JoinedAt:  sentAt,
+	})
+	return err
 }
 
-func (s *messageServiceServer) getMessageEmbeds(ctx context.Context, messageID string) ([]*pb.Embed, error) {
-	dbEmbeds, err := s.db.GetEmbedsByMessageId(ctx, messageID)
-	if err != nil {
-		return nil, err
+// saveAttachments stores a message's attachments, assigning IDs to those
+// that don't have one yet.
+func saveAttachments(ctx context.Context, tx *database.Queries, message *pb.Message) error {
+	for _, attachment := range message.Attachments {
+		if attachment.AttachmentId == "" {
+			attachment.AttachmentId = id.New(id.Attachment)
+		}
+		_, err := tx.CreateAttachment(ctx, database.CreateAttachmentParams{
+			AttachmentID: attachment.AttachmentId,
+			MessageID:    message.MessageId,
+			ChannelID:    message.ChannelId,
+			AuthorID:     message.AuthorId,
+			Filename:     attachment.Filename,
+			ContentType:  attachment.ContentType,
+			Size:         attachment.Size,
+			Url:          attachment.Url,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to save attachment: %v", err)
+		}
 	}
+	return nil
+}
 
-	embeds := make([]*pb.Embed, len(dbEmbeds))
-	for i, dbEmbed := range dbEmbeds {
-		// Get embed fields
-		dbFields, _ := s.db.GetEmbedFieldsByEmbedId(ctx, dbEmbed.EmbedID)
+// saveEmbeds stores a message's embeds and their fields.
+func saveEmbeds(ctx context.Context, tx *database.Queries, messageID string, embeds []*pb.Embed) error {
+	for _, embed := range embeds {
+		// Embed and field IDs are assigned by the database
+		dbEmbed, err := tx.CreateEmbed(ctx, database.CreateEmbedParams{
+			MessageID:    messageID,
+			Title:        sql.NullString{String: embed.Title, Valid: embed.Title != ""},
+			Description:  sql.NullString{String: embed.Description, Valid: embed.Description != ""},
+			Url:          sql.NullString{String: embed.Url, Valid: embed.Url != ""},
+			Color:        sql.NullInt64{Int64: int64(embed.Color), Valid: embed.Color != 0},
+			ThumbnailUrl: sql.NullString{String: embed.ThumbnailUrl, Valid: embed.ThumbnailUrl != ""},
+			ImageUrl:     sql.NullString{String: embed.ImageUrl, Valid: embed.ImageUrl != ""},
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to save embed: %v", err)
+		}
 
-		fields := make([]*pb.EmbedField, len(dbFields))
-		for j, dbField := range dbFields {
-			fields[j] = &pb.EmbedField{
-				Name:   dbField.Name,
-				Value:  dbField.Value,
-				Inline: int64ToBool(dbField.Inline),
+		for _, field := range embed.Fields {
+			_, err := tx.CreateEmbedField(ctx, database.CreateEmbedFieldParams{
+				EmbedID: dbEmbed.EmbedID,
+				Name:    field.Name,
+				Value:   field.Value,
+				Inline:  boolToInt64(field.Inline),
+			})
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to save embed field: %v", err)
 			}
 		}
+	}
+	return nil
+}
+
+// replaceEmbeds swaps all embeds of a message, and their fields, for embeds.
+func replaceEmbeds(ctx context.Context, tx *database.Queries, messageID string, embeds []*pb.Embed) error {
+	if err := tx.DeleteEmbedFieldsByMessageId(ctx, messageID); err != nil {
+		return status.Errorf(codes.Internal, "failed to delete embed fields: %v", err)
+	}
+	if err := tx.DeleteEmbedsByMessageId(ctx, messageID); err != nil {
+		return status.Errorf(codes.Internal, "failed to delete embeds: %v", err)
+	}
+	return saveEmbeds(ctx, tx, messageID, embeds)
+}
+
+// replaceAttachments swaps all attachments of a message for the message's
+// Attachments, assigning IDs to new ones.
+func replaceAttachments(ctx context.Context, tx *database.Queries, message *pb.Message) error {
+	if err := tx.DeleteAttachmentsByMessageId(ctx, message.MessageId); err != nil {
+		return status.Errorf(codes.Internal, "failed to delete attachments: %v", err)
+	}
+	return saveAttachments(ctx, tx, message)
+}
+
+// resolveAttachments works out a message's attachments after an update.
+// Requested attachments with an ID must be current attachments of the
+// message and keep what is stored for them; those without one are new.
+func resolveAttachments(current, requested []*pb.Attachment) ([]*pb.Attachment, error) {
+	byID := make(map[string]*pb.Attachment, len(current))
+	for _, attachment := range current {
+		byID[attachment.AttachmentId] = attachment
+	}
+
+	resolved := make([]*pb.Attachment, 0, len(requested))
+	for _, attachment := range requested {
+		if attachment.AttachmentId == "" {
+			resolved = append(resolved, proto.Clone(attachment).(*pb.Attachment))
+			continue
+		}
+		existing, ok := byID[attachment.AttachmentId]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "attachment %s does not belong to the message", attachment.AttachmentId)
+		}
+		if slices.Contains(resolved, existing) {
+			return nil, status.Errorf(codes.InvalidArgument, "attachment %s is listed twice", attachment.AttachmentId)
+		}
+		resolved = append(resolved, existing)
+	}
+	return resolved, nil
+}
+
+// protoListsEqual reports whether two lists hold equal messages in the same
+// order.
+func protoListsEqual[T proto.Message](a, b []T) bool {
+	return slices.EqualFunc(a, b, func(x, y T) bool { return proto.Equal(x, y) })
+}
+
+func getMessageAttachments(ctx context.Context, db *database.Queries, messageID string) ([]*pb.Attachment, error) {
+	message := &pb.Message{MessageId: messageID}
+	if err := loadMessageAttachments(ctx, db, []*pb.Message{message}); err != nil {
+		return nil, err
+	}
+	return message.Attachments, nil
+}
 
-		embeds[i] = &pb.Embed{
+func getMessageEmbeds(ctx context.Context, db *database.Queries, messageID string) ([]*pb.Embed, error) {
+	message := &pb.Message{MessageId: messageID}
+	if err := loadMessageEmbeds(ctx, db, []*pb.Message{message}); err != nil {
+		return nil, err
+	}
+	return message.Embeds, nil
+}
+
+// loadMessageAttachments sets the attachments of messages with one query.
+func loadMessageAttachments(ctx context.Context, db *database.Queries, messages []*pb.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	byID, messageIDs := indexMessages(messages)
+
+	dbAttachments, err := db.ListAttachmentsByMessageIds(ctx, messageIDs)
+	if err != nil {
+		return err
+	}
+	for _, dbAttachment := range dbAttachments {
+		message := byID[dbAttachment.MessageID]
+		message.Attachments = append(message.Attachments, &pb.Attachment{
+			AttachmentId: dbAttachment.AttachmentID,
+			Filename:     dbAttachment.Filename,
+			ContentType:  dbAttachment.ContentType,
+			Size:         dbAttachment.Size,
+			Url:          dbAttachment.Url,
+		})
+	}
+	return nil
+}
+
+// loadMessageEmbeds sets the embeds of messages, with their fields, with one
+// query for the embeds and one for the fields.
+func loadMessageEmbeds(ctx context.Context, db *database.Queries, messages []*pb.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	byID, messageIDs := indexMessages(messages)
+
+	dbEmbeds, err := db.ListEmbedsByMessageIds(ctx, messageIDs)
+	if err != nil {
+		return err
+	}
+	dbFields, err := db.ListEmbedFieldsByMessageIds(ctx, messageIDs)
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[int64][]*pb.EmbedField)
+	for _, dbField := range dbFields {
+		fields[dbField.EmbedID] = append(fields[dbField.EmbedID], &pb.EmbedField{
+			Name:   dbField.Name,
+			Value:  dbField.Value,
+			Inline: int64ToBool(dbField.Inline),
+		})
+	}
+	for _, dbEmbed := range dbEmbeds {
+		message := byID[dbEmbed.MessageID]
+		message.Embeds = append(message.Embeds, &pb.Embed{
 			Title:        dbEmbed.Title.String,
 			Description:  dbEmbed.Description.String,
 			Url:          dbEmbed.Url.String,
 			Color:        int32(dbEmbed.Color.Int64),
-			Fields:       fields,
+			Fields:       fields[dbEmbed.EmbedID],
 			ThumbnailUrl: dbEmbed.ThumbnailUrl.String,
 			ImageUrl:     dbEmbed.ImageUrl.String,
-		}
+		})
 	}
+	return nil
+}
 
-	return embeds, nil
+// indexMessages returns messages by ID and their IDs, for the queries that
+// load what belongs to a page of messages at once.
+func indexMessages(messages []*pb.Message) (map[string]*pb.Message, []string) {
+	byID := make(map[string]*pb.Message, len(messages))
+	messageIDs := make([]string, len(messages))
+	for i, message := range messages {
+		byID[message.MessageId] = message
+		messageIDs[i] = message.MessageId
+	}
+	return byID, messageIDs
 }
 
 // Helper function to convert database message to proto message
 func dbMessageToProto(dbMessage *database.Message) *pb.Message {
+	var deletedAt *timestamppb.Timestamp
+	if dbMessage.DeletedAt.Valid {
+		deletedAt = timestamppb.New(time.Unix(dbMessage.DeletedAt.Int64, 0))
+	}
 	return &pb.Message{
 		MessageId: dbMessage.MessageID,
 		ChannelId: dbMessage.ChannelID,
@@ -409,6 +745,9 @@ func dbMessageToProto(dbMessage *database.Message) *pb.Message {
 		CreatedAt: timestamppb.New(time.Unix(dbMessage.CreatedAt, 0)),
 		UpdatedAt: timestamppb.New(time.Unix(dbMessage.UpdatedAt, 0)),
 		ReplyToId: dbMessage.ReplyToID.String,
+		Deleted:   dbMessage.DeletedAt.Valid,
+		DeletedBy: dbMessage.DeletedBy.String,
+		DeletedAt: deletedAt,
 	}
 }
 
@@ -0,0 +1,309 @@
+package server
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pressly/goose/v3"
+)
+
+const migrationsDir = "database/migrations"
+
+// goose keeps its configuration in globals, so migrations run one at a time
+var migrationMu sync.Mutex
+
+// MigrationStatus describes the schema version of one database file.
+type MigrationStatus struct {
+	Database string
+	Path     string
+	Current  int64
+	Latest   int64
+	// Backup is the copy taken before the file was migrated, if any
+	Backup string
+}
+
+// Pending reports whether migrations remain to be applied.
+func (s MigrationStatus) Pending() bool {
+	return s.Current < s.Latest
+}
+
+func (s MigrationStatus) String() string {
+	state := "up to date"
+	if s.Pending() {
+		state = "pending"
+	} else if s.Current > s.Latest {
+		state = "ahead of this build"
+	}
+	return fmt.Sprintf("%s: version %d, latest %d (%s)", s.Database, s.Current, s.Latest, state)
+}
+
+// setupGoose points goose at the embedded migrations; callers hold migrationMu.
+func setupGoose() error {
+	// Set up goose with embedded migrations
+	goose.SetBaseFS(embedMigrations)
+
+	// Set dialect for SQLite
+	if err := goose.SetDialect("sqlite3"); err != nil {
+		return fmt.Errorf("failed to set goose dialect: %w", err)
+	}
+	return nil
+}
+
+// migrationVersions returns the current version of db and the latest embedded
+// one. It doesn't write to db: goose would create its version table if it is
+// missing, so a database without one is reported at version 0 instead.
+func migrationVersions(db *sql.DB) (int64, int64, error) {
+	var tables int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", goose.TableName()).Scan(&tables)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to look up schema version table: %w", err)
+	}
+
+	var current int64
+	if tables > 0 {
+		current, err = goose.GetDBVersion(db)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to read schema version: %w", err)
+		}
+	}
+
+	migrations, err := goose.CollectMigrations(migrationsDir, 0, goose.MaxVersion)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to collect migrations: %w", err)
+	}
+	last, err := migrations.Last()
+	if err != nil {
+		return current, 0, nil
+	}
+	return current, last.Version, nil
+}
+
+// runMigrations applies pending migrations to a database file. Unless the
+// file was just created, it is copied to the backups directory first.
+func (mdm *MultiDatabaseManager) runMigrations(name, path string, db *sql.DB, isNew bool) (MigrationStatus, error) {
+	migrationMu.Lock()
+	defer migrationMu.Unlock()
+
+	migrationStatus := MigrationStatus{Database: name, Path: path}
+	if err := setupGoose(); err != nil {
+		return migrationStatus, err
+	}
+
+	current, latest, err := migrationVersions(db)
+	if err != nil {
+		return migrationStatus, err
+	}
+	migrationStatus.Current, migrationStatus.Latest = current, latest
+	if !migrationStatus.Pending() {
+		return migrationStatus, nil
+	}
+
+	if !isNew {
+		backup, err := mdm.backupDatabase(name, path, current)
+		if err != nil {
+			return migrationStatus, err
+		}
+		migrationStatus.Backup = backup
+	}
+
+	// Apply all migrations
+	if err := withoutForeignKeys(db, func() error { return goose.Up(db, migrationsDir) }); err != nil {
+		return migrationStatus, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	if !isNew {
+		log.Printf("Migrated database %s from version %d to %d (backup: %s)", name, current, latest, migrationStatus.Backup)
+	}
+	migrationStatus.Current = latest
+	return migrationStatus, nil
+}
+
+// withoutForeignKeys runs fn, which changes the schema of db, with foreign
+// key enforcement off: rebuilding a table other tables refer to would
+// otherwise delete or reject their rows. SQLite ignores the pragma inside a
+// transaction, so it is set on the connection around goose's transactions.
+// The keys are checked once fn is done.
+func withoutForeignKeys(db *sql.DB, fn func() error) error {
+	if _, err := db.Exec("PRAGMA foreign_keys = OFF"); err != nil {
+		return fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer db.Exec("PRAGMA foreign_keys = ON")
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	rows, err := db.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		return fmt.Errorf("failed to check foreign keys: %w", err)
+	}
+	defer rows.Close()
+	if rows.Next() {
+		var table, parent string
+		var rowid sql.NullInt64
+		var fkid int64
+		if err := rows.Scan(&table, &rowid, &parent, &fkid); err != nil {
+			return fmt.Errorf("failed to check foreign keys: %w", err)
+		}
+		return fmt.Errorf("row %d of %s refers to a missing row of %s", rowid.Int64, table, parent)
+	}
+	return rows.Err()
+}
+
+// backupDatabase copies a database file (and its write-ahead log, if present)
+// to "<data path>/backups/<name>-v<version>-<timestamp>.db". The file must not
+// be written to while it is copied.
+func (mdm *MultiDatabaseManager) backupDatabase(name, path string, version int64) (string, error) {
+	backupDir := filepath.Join(mdm.dataPath, "backups")
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory %s: %w", backupDir, err)
+	}
+
+	backupPath := filepath.Join(backupDir, fmt.Sprintf("%s-v%d-%s.db", name, version, time.Now().UTC().Format("20060102150405")))
+	if err := copyFile(path, backupPath); err != nil {
+		return "", fmt.Errorf("failed to back up database %s: %w", name, err)
+	}
+	if _, err := os.Stat(path + "-wal"); err == nil {
+		if err := copyFile(path+"-wal", backupPath+"-wal"); err != nil {
+			return "", fmt.Errorf("failed to back up write-ahead log of %s: %w", name, err)
+		}
+	}
+
+	return backupPath, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
+
+// DatabaseFiles returns the names of all database files in the data path.
+func (mdm *MultiDatabaseManager) DatabaseFiles() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(mdm.dataPath, "*.db"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan for database files in %s: %w", mdm.dataPath, err)
+	}
+
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, strings.TrimSuffix(filepath.Base(match), ".db"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// withClosedDatabase opens a database file that is not in use by the manager
+// for a one-off operation such as a migration command.
+func (mdm *MultiDatabaseManager) withClosedDatabase(name string, fn func(path string, db *sql.DB) error) error {
+	mdm.mu.Lock()
+	_, open := mdm.databases[name]
+	mdm.mu.Unlock()
+	if open {
+		return fmt.Errorf("database %s is open", name)
+	}
+
+	path := filepath.Join(mdm.dataPath, name+".db")
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("database %s not found: %w", name, err)
+	}
+
+	db, err := mdm.openDatabase(name, path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return fn(path, db)
+}
+
+// MigrationStatus reports the schema version of a database file without
+// migrating it.
+func (mdm *MultiDatabaseManager) MigrationStatus(name string) (MigrationStatus, error) {
+	migrationStatus := MigrationStatus{Database: name}
+	err := mdm.withClosedDatabase(name, func(path string, db *sql.DB) error {
+		migrationMu.Lock()
+		defer migrationMu.Unlock()
+
+		if err := setupGoose(); err != nil {
+			return err
+		}
+		current, latest, err := migrationVersions(db)
+		if err != nil {
+			return err
+		}
+		migrationStatus.Path, migrationStatus.Current, migrationStatus.Latest = path, current, latest
+		return nil
+	})
+	return migrationStatus, err
+}
+
+// MigrateUp backs up a database file and applies all pending migrations.
+func (mdm *MultiDatabaseManager) MigrateUp(name string) (MigrationStatus, error) {
+	var migrationStatus MigrationStatus
+	err := mdm.withClosedDatabase(name, func(path string, db *sql.DB) error {
+		var err error
+		migrationStatus, err = mdm.runMigrations(name, path, db, false)
+		return err
+	})
+	return migrationStatus, err
+}
+
+// MigrateDown backs up a database file and rolls back its latest migration.
+func (mdm *MultiDatabaseManager) MigrateDown(name string) (MigrationStatus, error) {
+	migrationStatus := MigrationStatus{Database: name}
+	err := mdm.withClosedDatabase(name, func(path string, db *sql.DB) error {
+		migrationMu.Lock()
+		defer migrationMu.Unlock()
+
+		if err := setupGoose(); err != nil {
+			return err
+		}
+		current, latest, err := migrationVersions(db)
+		if err != nil {
+			return err
+		}
+		migrationStatus.Path, migrationStatus.Latest = path, latest
+		if current == 0 {
+			return fmt.Errorf("database %s has no migrations to roll back", name)
+		}
+
+		backup, err := mdm.backupDatabase(name, path, current)
+		if err != nil {
+			return err
+		}
+		migrationStatus.Backup = backup
+
+		if err := withoutForeignKeys(db, func() error { return goose.Down(db, migrationsDir) }); err != nil {
+			return fmt.Errorf("failed to roll back migration: %w", err)
+		}
+
+		migrationStatus.Current, err = goose.GetDBVersion(db)
+		if err != nil {
+			return fmt.Errorf("failed to read schema version: %w", err)
+		}
+		return nil
+	})
+	return migrationStatus, err
+}
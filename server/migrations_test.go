@@ -0,0 +1,44 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+)
+
+func TestMigrationStatusDoesNotWrite(t *testing.T) {
+	s := newTestServer(t, nil)
+
+	// A file that predates migrations has no goose version table
+	path := filepath.Join(s.manager.dataPath, "legacy.db")
+	db, err := s.manager.openDatabase("legacy", path)
+	if err != nil {
+		t.Fatalf("openDatabase: %v", err)
+	}
+	if _, err := db.Exec("CREATE TABLE channels (channel_id TEXT PRIMARY KEY)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	db.Close()
+
+	migrationStatus, err := s.manager.MigrationStatus("legacy")
+	if err != nil {
+		t.Fatalf("MigrationStatus: %v", err)
+	}
+	if migrationStatus.Current != 0 || !migrationStatus.Pending() {
+		t.Fatalf("got %s, want version 0 with migrations pending", migrationStatus)
+	}
+
+	db, err = s.manager.openDatabase("legacy", path)
+	if err != nil {
+		t.Fatalf("openDatabase: %v", err)
+	}
+	defer db.Close()
+	var tables int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = ?", goose.TableName()).Scan(&tables); err != nil {
+		t.Fatalf("look up version table: %v", err)
+	}
+	if tables != 0 {
+		t.Fatal("MigrationStatus created the version table")
+	}
+}
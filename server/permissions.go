@@ -0,0 +1,396 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// Permissions is a bitmask of pb.Permission flags.
+type Permissions uint64
+
+const (
+	PermViewChannel    = Permissions(pb.Permission_PERMISSION_VIEW_CHANNEL)
+	PermSendMessages   = Permissions(pb.Permission_PERMISSION_SEND_MESSAGES)
+	PermManageMessages = Permissions(pb.Permission_PERMISSION_MANAGE_MESSAGES)
+	PermManageChannels = Permissions(pb.Permission_PERMISSION_MANAGE_CHANNELS)
+	PermManageConfig   = Permissions(pb.Permission_PERMISSION_MANAGE_CONFIG)
+	PermManageRoles    = Permissions(pb.Permission_PERMISSION_MANAGE_ROLES)
+	PermAdministrator  = Permissions(pb.Permission_PERMISSION_ADMINISTRATOR)
+
+	PermAll = PermViewChannel | PermSendMessages | PermManageMessages | PermManageChannels |
+		PermManageConfig | PermManageRoles | PermAdministrator
+
+	// Granted to @everyone when a server is first bootstrapped
+	defaultEveryonePermissions = PermViewChannel | PermSendMessages
+)
+
+// maxChannelDepth bounds parent_id traversal in case of cycles.
+const maxChannelDepth = 16
+
+func (p Permissions) Has(perm Permissions) bool {
+	return p&PermAdministrator != 0 || p&perm == perm
+}
+
+// PermissionResolver computes effective permissions from roles, role
+// membership and per-channel overwrites inherited along Channel.parent_id.
+type PermissionResolver struct {
+	router      *DatabaseRouter
+	adminUsers  map[string]bool
+	enforcement bool
+}
+
+func NewPermissionResolver(router *DatabaseRouter, config *Config) *PermissionResolver {
+	adminUsers := make(map[string]bool)
+	if config != nil {
+		for _, userID := range strings.Split(config.AdminUsers, ",") {
+			if userID = strings.TrimSpace(userID); userID != "" {
+				adminUsers[userID] = true
+			}
+		}
+	}
+	return &PermissionResolver{
+		router:      router,
+		adminUsers:  adminUsers,
+		enforcement: router != nil,
+	}
+}
+
+// callerID returns the authenticated user, or "" for trusted internal and
+// unauthenticated development calls, which bypass permission checks.
+func (r *PermissionResolver) callerID(ctx context.Context) string {
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		return principal.UserID
+	}
+	return ""
+}
+
+// isUnrestricted reports whether userID bypasses role permissions. Admins are
+// matched by user ID only: usernames can be changed and re-registered, so they
+// can't be trusted to identify an administrator.
+func (r *PermissionResolver) isUnrestricted(userID string) bool {
+	if !r.enforcement || userID == "" {
+		return true
+	}
+	return r.adminUsers[userID]
+}
+
+// ServerPermissions returns the union of the user's role permissions in a server.
+func (r *PermissionResolver) ServerPermissions(ctx context.Context, userID, serverID string) (Permissions, error) {
+	if r.isUnrestricted(userID) {
+		return PermAll, nil
+	}
+
+	db, err := r.router.ForServer(ctx, serverID)
+	if err != nil {
+		return 0, err
+	}
+
+	roles, err := db.GetMemberRoles(ctx, database.GetMemberRolesParams{
+		ServerID: serverID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get member roles: %w", err)
+	}
+
+	var perms Permissions
+	for _, role := range roles {
+		perms |= Permissions(role.Permissions)
+	}
+	if len(roles) == 0 {
+		// Servers that predate roles behave as if only @everyone existed
+		perms = defaultEveryonePermissions
+	}
+	if perms&PermAdministrator != 0 {
+		return PermAll, nil
+	}
+	return perms, nil
+}
+
+// ChannelPermissions applies the overwrites of every ancestor (root first)
+// and then the channel itself on top of the user's server permissions.
+func (r *PermissionResolver) ChannelPermissions(ctx context.Context, userID string, channel *database.Channel) (Permissions, error) {
+	if r.isUnrestricted(userID) {
+		return PermAll, nil
+	}
+
+	serverID := channel.ServerID.String
+	db, err := r.router.ForServer(ctx, serverID)
+	if err != nil {
+		return 0, err
+	}
+
+	roles, err := db.GetMemberRoles(ctx, database.GetMemberRolesParams{
+		ServerID: serverID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get member roles: %w", err)
+	}
+
+	var perms Permissions
+	var everyoneRoleID string
+	memberRoles := make(map[string]bool)
+	for _, role := range roles {
+		perms |= Permissions(role.Permissions)
+		if role.IsDefault != 0 {
+			everyoneRoleID = role.RoleID
+		} else {
+			memberRoles[role.RoleID] = true
+		}
+	}
+	if len(roles) == 0 {
+		perms = defaultEveryonePermissions
+	}
+	if perms&PermAdministrator != 0 {
+		return PermAll, nil
+	}
+
+	chain, err := channelChain(ctx, db, channel)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		overwrites, err := db.GetPermissionOverwritesByChannelId(ctx, chain[i])
+		if err != nil {
+			return 0, fmt.Errorf("failed to get permission overwrites: %w", err)
+		}
+		perms = applyOverwrites(perms, overwrites, everyoneRoleID, memberRoles, userID)
+	}
+
+	// Without view access nothing else in the channel is usable
+	if perms&PermViewChannel == 0 {
+		return 0, nil
+	}
+	return perms, nil
+}
+
+// applyOverwrites follows the @everyone, then roles, then member precedence.
+func applyOverwrites(perms Permissions, overwrites []database.PermissionOverwrite, everyoneRoleID string, memberRoles map[string]bool, userID string) Permissions {
+	var roleAllow, roleDeny Permissions
+	var userOverwrite *database.PermissionOverwrite
+
+	for i, overwrite := range overwrites {
+		switch pb.OverwriteTargetType(overwrite.TargetType) {
+		case pb.OverwriteTargetType_OVERWRITE_TARGET_TYPE_ROLE:
+			if overwrite.TargetID == everyoneRoleID {
+				perms &^= Permissions(overwrite.Deny)
+				perms |= Permissions(overwrite.Allow)
+			} else if memberRoles[overwrite.TargetID] {
+				roleAllow |= Permissions(overwrite.Allow)
+				roleDeny |= Permissions(overwrite.Deny)
+			}
+		case pb.OverwriteTargetType_OVERWRITE_TARGET_TYPE_USER:
+			if overwrite.TargetID == userID {
+				userOverwrite = &overwrites[i]
+			}
+		}
+	}
+
+	perms &^= roleDeny
+	perms |= roleAllow
+
+	if userOverwrite != nil {
+		perms &^= Permissions(userOverwrite.Deny)
+		perms |= Permissions(userOverwrite.Allow)
+	}
+
+	return perms
+}
+
+// channelChain returns the channel ID followed by its ancestors' IDs.
+func channelChain(ctx context.Context, db *database.Queries, channel *database.Channel) ([]string, error) {
+	chain := []string{channel.ChannelID}
+	parentID := channel.ParentID.String
+
+	for parentID != "" && len(chain) < maxChannelDepth {
+		parent, err := db.GetChannel(ctx, parentID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				break
+			}
+			return nil, fmt.Errorf("failed to get parent channel: %w", err)
+		}
+		chain = append(chain, parent.ChannelID)
+		parentID = parent.ParentID.String
+	}
+
+	return chain, nil
+}
+
+// RequireServerPermission returns PermissionDenied unless the caller holds perm in the server.
+func (r *PermissionResolver) RequireServerPermission(ctx context.Context, serverID string, perm Permissions) error {
+	perms, err := r.ServerPermissions(ctx, r.callerID(ctx), serverID)
+	if err != nil {
+		return permissionError(err)
+	}
+	if !perms.Has(perm) {
+		return status.Error(codes.PermissionDenied, "missing permission in server")
+	}
+	return nil
+}
+
+// permissionError converts a failure to resolve server permissions to a gRPC
+// status, reporting servers without a database as NotFound.
+func permissionError(err error) error {
+	if errors.Is(err, ErrDatabaseNotFound) || errors.Is(err, ErrInvalidServerID) {
+		return routeError(err, "server not found")
+	}
+	return status.Errorf(codes.Internal, "failed to resolve permissions: %v", err)
+}
+
+// RequireChannelPermission returns PermissionDenied unless the caller holds perm in the
+// channel. Channels the caller cannot view are reported as NotFound.
+func (r *PermissionResolver) RequireChannelPermission(ctx context.Context, channel *database.Channel, perm Permissions) error {
+	perms, err := r.ChannelPermissions(ctx, r.callerID(ctx), channel)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to resolve permissions: %v", err)
+	}
+	if !perms.Has(PermViewChannel) {
+		return status.Error(codes.NotFound, "channel not found")
+	}
+	if !perms.Has(perm) {
+		return status.Error(codes.PermissionDenied, "missing permission in channel")
+	}
+	return nil
+}
+
+// CanViewChannel reports whether the caller may see the channel.
+func (r *PermissionResolver) CanViewChannel(ctx context.Context, userID string, channel *database.Channel) (bool, error) {
+	perms, err := r.ChannelPermissions(ctx, userID, channel)
+	if err != nil {
+		return false, err
+	}
+	return perms.Has(PermViewChannel), nil
+}
+
+// RequireScopePermission checks perm against a config/event scope such as
+// "server:<id>", "channel:<id>" or "user:<id>". Other scopes are reserved for
+// instance administrators.
+func (r *PermissionResolver) RequireScopePermission(ctx context.Context, scope string, perm Permissions) error {
+	userID := r.callerID(ctx)
+	if r.isUnrestricted(userID) {
+		return nil
+	}
+
+	kind, id, _ := strings.Cut(scope, ":")
+	switch {
+	case kind == "server" && id != "":
+		return r.RequireServerPermission(ctx, id, perm)
+	case kind == "channel" && id != "":
+		db, _, err := r.router.ForResource(ctx, id)
+		if err != nil {
+			return routeError(err, "channel not found")
+		}
+		channel, err := db.GetChannel(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return status.Error(codes.NotFound, "channel not found")
+			}
+			return status.Errorf(codes.Internal, "failed to get channel: %v", err)
+		}
+		return r.RequireChannelPermission(ctx, &channel, perm)
+	case kind == "user" && id != "":
+		if id == userID {
+			return nil
+		}
+		return status.Error(codes.PermissionDenied, "cannot access another user's scope")
+	}
+
+	return status.Errorf(codes.PermissionDenied, "scope %q is restricted to administrators", scope)
+}
+
+// RequireScopeRead checks that the caller may read the events and config of
+// a scope. Every read path uses it, whatever the kind of scope, so a scope is
+// never readable by someone who couldn't read it through another RPC.
+func (r *PermissionResolver) RequireScopeRead(ctx context.Context, scope string) error {
+	return r.RequireScopePermission(ctx, scope, PermViewChannel)
+}
+
+// BootstrapServer creates the @everyone and owner roles for a server that has
+// neither channels nor roles yet and makes userID its owner. The counts and
+// inserts share one transaction, so concurrent callers can't both claim the
+// server, and a server that already has channels is never bootstrapped.
+func (r *PermissionResolver) BootstrapServer(ctx context.Context, serverID, userID string) error {
+	if r.router == nil {
+		return nil
+	}
+
+	return r.router.InScopeTx(ctx, fmt.Sprintf("server:%s", serverID), func(ctx context.Context, tx *database.Queries) error {
+		channels, err := tx.CountChannelsByServerId(ctx, sql.NullString{String: serverID, Valid: true})
+		if err != nil {
+			return fmt.Errorf("failed to count channels: %w", err)
+		}
+		roles, err := tx.CountRolesByServerId(ctx, serverID)
+		if err != nil {
+			return fmt.Errorf("failed to count roles: %w", err)
+		}
+		if channels > 0 || roles > 0 {
+			return nil
+		}
+
+		now := time.Now()
+		everyoneRoleID := id.New(id.Role)
+		if _, err := tx.CreateRole(ctx, database.CreateRoleParams{
+			RoleID:      everyoneRoleID,
+			ServerID:    serverID,
+			Name:        "@everyone",
+			Permissions: int64(defaultEveryonePermissions),
+			Position:    0,
+			IsDefault:   1,
+			CreatedAt:   now.Unix(),
+			UpdatedAt:   now.Unix(),
+		}); err != nil {
+			return fmt.Errorf("failed to create default role: %w", err)
+		}
+		// Routes live in the primary database; one left behind by a rollback
+		// points at a role that doesn't exist and resolves to NotFound
+		if err := r.router.AddRoute(ctx, everyoneRoleID, serverID); err != nil {
+			return fmt.Errorf("failed to register default role route: %w", err)
+		}
+
+		if userID == "" {
+			return nil
+		}
+
+		ownerRoleID := id.New(id.Role)
+		if _, err := tx.CreateRole(ctx, database.CreateRoleParams{
+			RoleID:      ownerRoleID,
+			ServerID:    serverID,
+			Name:        "owner",
+			Permissions: int64(PermAdministrator),
+			Position:    1000,
+			IsDefault:   0,
+			CreatedAt:   now.Unix(),
+			UpdatedAt:   now.Unix(),
+		}); err != nil {
+			return fmt.Errorf("failed to create owner role: %w", err)
+		}
+		if err := r.router.AddRoute(ctx, ownerRoleID, serverID); err != nil {
+			return fmt.Errorf("failed to register owner role route: %w", err)
+		}
+
+		if err := tx.AddRoleMember(ctx, database.AddRoleMemberParams{
+			RoleID:    ownerRoleID,
+			UserID:    userID,
+			ServerID:  serverID,
+			CreatedAt: now.Unix(),
+		}); err != nil {
+			return fmt.Errorf("failed to assign owner role: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,259 @@
+package server
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+func TestApplyOverwrites(t *testing.T) {
+	const everyone, mods, helpers = "role_everyone", "role_mods", "role_helpers"
+	role := int64(pb.OverwriteTargetType_OVERWRITE_TARGET_TYPE_ROLE)
+	user := int64(pb.OverwriteTargetType_OVERWRITE_TARGET_TYPE_USER)
+
+	tests := []struct {
+		name       string
+		perms      Permissions
+		overwrites []database.PermissionOverwrite
+		want       Permissions
+	}{
+		{
+			name:  "everyone deny",
+			perms: PermViewChannel | PermSendMessages,
+			overwrites: []database.PermissionOverwrite{
+				{TargetType: role, TargetID: everyone, Deny: int64(PermSendMessages)},
+			},
+			want: PermViewChannel,
+		},
+		{
+			name:  "role allow beats everyone deny",
+			perms: PermViewChannel | PermSendMessages,
+			overwrites: []database.PermissionOverwrite{
+				{TargetType: role, TargetID: everyone, Deny: int64(PermViewChannel)},
+				{TargetType: role, TargetID: mods, Allow: int64(PermViewChannel)},
+			},
+			want: PermViewChannel | PermSendMessages,
+		},
+		{
+			name:  "role allow beats another role's deny",
+			perms: PermViewChannel,
+			overwrites: []database.PermissionOverwrite{
+				{TargetType: role, TargetID: mods, Deny: int64(PermSendMessages)},
+				{TargetType: role, TargetID: helpers, Allow: int64(PermSendMessages)},
+			},
+			want: PermViewChannel | PermSendMessages,
+		},
+		{
+			name:  "roles the user lacks are ignored",
+			perms: PermViewChannel,
+			overwrites: []database.PermissionOverwrite{
+				{TargetType: role, TargetID: "role_other", Allow: int64(PermManageMessages)},
+			},
+			want: PermViewChannel,
+		},
+		{
+			name:  "user overwrite beats roles",
+			perms: PermViewChannel | PermSendMessages,
+			overwrites: []database.PermissionOverwrite{
+				{TargetType: role, TargetID: mods, Allow: int64(PermManageMessages)},
+				{TargetType: user, TargetID: "bob", Deny: int64(PermManageMessages | PermSendMessages)},
+			},
+			want: PermViewChannel,
+		},
+		{
+			name:  "other users' overwrites are ignored",
+			perms: PermViewChannel,
+			overwrites: []database.PermissionOverwrite{
+				{TargetType: user, TargetID: "carol", Deny: int64(PermViewChannel)},
+			},
+			want: PermViewChannel,
+		},
+	}
+
+	memberRoles := map[string]bool{mods: true, helpers: true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyOverwrites(tt.perms, tt.overwrites, everyone, memberRoles, "bob")
+			if got != tt.want {
+				t.Errorf("got %b, want %b", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannelPermissionsInheritOverwrites(t *testing.T) {
+	s := newTestServer(t, nil)
+	alice := s.as(t, "alice")
+	internal := s.as(t, "")
+
+	parent, err := s.channels.CreateChannel(alice, &pb.CreateChannelRequest{Name: "staff", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1"})
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+	child, err := s.channels.CreateChannel(alice, &pb.CreateChannelRequest{Name: "staff-chat", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1", ParentId: parent.Channel.ChannelId})
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	roles, err := s.roles.ListRoles(alice, &pb.ListRolesRequest{ServerId: "srv1"})
+	if err != nil {
+		t.Fatalf("ListRoles: %v", err)
+	}
+	var everyoneID string
+	for _, role := range roles.Roles {
+		if role.IsDefault {
+			everyoneID = role.RoleId
+		}
+	}
+	if everyoneID == "" {
+		t.Fatal("server has no @everyone role")
+	}
+
+	mods, err := s.roles.CreateRole(alice, &pb.CreateRoleRequest{ServerId: "srv1", Name: "mods", Permissions: uint64(PermViewChannel | PermSendMessages)})
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+	if _, err := s.roles.AddRoleMember(alice, &pb.AddRoleMemberRequest{RoleId: mods.Role.RoleId, UserId: "bob"}); err != nil {
+		t.Fatalf("AddRoleMember: %v", err)
+	}
+
+	setOverwrite := func(channelID string, targetType pb.OverwriteTargetType, targetID string, allow, deny Permissions) {
+		t.Helper()
+		_, err := s.roles.SetPermissionOverwrite(alice, &pb.SetPermissionOverwriteRequest{Overwrite: &pb.PermissionOverwrite{
+			ChannelId:  channelID,
+			TargetType: targetType,
+			TargetId:   targetID,
+			Allow:      uint64(allow),
+			Deny:       uint64(deny),
+		}})
+		if err != nil {
+			t.Fatalf("SetPermissionOverwrite: %v", err)
+		}
+	}
+	permissions := func(channelID, userID string) Permissions {
+		t.Helper()
+		resp, err := s.roles.GetPermissions(internal, &pb.GetPermissionsRequest{ChannelId: channelID, UserId: userID})
+		if err != nil {
+			t.Fatalf("GetPermissions: %v", err)
+		}
+		return Permissions(resp.Permissions)
+	}
+
+	// Hide the parent from @everyone but let mods in; the child inherits both
+	setOverwrite(parent.Channel.ChannelId, pb.OverwriteTargetType_OVERWRITE_TARGET_TYPE_ROLE, everyoneID, 0, PermViewChannel)
+	setOverwrite(parent.Channel.ChannelId, pb.OverwriteTargetType_OVERWRITE_TARGET_TYPE_ROLE, mods.Role.RoleId, PermViewChannel, 0)
+
+	if got := permissions(child.Channel.ChannelId, "carol"); got != 0 {
+		t.Errorf("carol in child: got %b, want no permissions", got)
+	}
+	if got := permissions(child.Channel.ChannelId, "bob"); got != PermViewChannel|PermSendMessages {
+		t.Errorf("bob in child: got %b, want view and send", got)
+	}
+	if got := permissions(child.Channel.ChannelId, "alice"); got != PermAll {
+		t.Errorf("owner in child: got %b, want all", got)
+	}
+
+	// The child's own overwrites apply after its parent's
+	setOverwrite(child.Channel.ChannelId, pb.OverwriteTargetType_OVERWRITE_TARGET_TYPE_USER, "bob", 0, PermSendMessages)
+	if got := permissions(child.Channel.ChannelId, "bob"); got != PermViewChannel {
+		t.Errorf("bob in child after user overwrite: got %b, want view only", got)
+	}
+	if got := permissions(parent.Channel.ChannelId, "bob"); got != PermViewChannel|PermSendMessages {
+		t.Errorf("bob in parent: got %b, want view and send", got)
+	}
+
+	bob := s.as(t, "bob")
+	carol := s.as(t, "carol")
+	if err := s.permissions.RequireScopeRead(bob, "channel:"+child.Channel.ChannelId); err != nil {
+		t.Errorf("bob reading child scope: %v", err)
+	}
+	// Channels the caller can't see don't exist as far as they can tell
+	requireCode(t, s.permissions.RequireScopeRead(carol, "channel:"+child.Channel.ChannelId), codes.NotFound)
+	_, err = s.messages.SendMessage(bob, &pb.SendMessageRequest{ChannelId: child.Channel.ChannelId, Content: "hi"})
+	requireCode(t, err, codes.PermissionDenied)
+}
+
+func TestAdminUsersMatchByUserID(t *testing.T) {
+	s := newTestServer(t, map[string]string{"FUWA_ADMIN_USERS": "user_root"})
+	alice := s.as(t, "alice")
+
+	channel, err := s.channels.CreateChannel(alice, &pb.CreateChannelRequest{Name: "general", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1"})
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	admin := s.as(t, "user_root")
+	if err := s.permissions.RequireServerPermission(admin, "srv1", PermManageRoles); err != nil {
+		t.Errorf("admin by user ID: %v", err)
+	}
+
+	// A user merely named like the admin gets nothing extra
+	impostor := ContextWithPrincipal(s.as(t, ""), &Principal{UserID: "user_mallory", Username: "user_root"})
+	requireCode(t, s.permissions.RequireServerPermission(impostor, "srv1", PermManageRoles), codes.PermissionDenied)
+	_, err = s.channels.DeleteChannel(impostor, &pb.DeleteChannelRequest{ChannelId: channel.Channel.ChannelId})
+	requireCode(t, err, codes.PermissionDenied)
+}
+
+func TestDeletedPrivateChannelEventsStayHidden(t *testing.T) {
+	s := newTestServer(t, nil)
+	alice := s.as(t, "alice")
+	bob := s.as(t, "bob")
+
+	secret, err := s.channels.CreateChannel(alice, &pb.CreateChannelRequest{Name: "secret", Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1"})
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+	channelID := secret.Channel.ChannelId
+	roles, err := s.roles.ListRoles(alice, &pb.ListRolesRequest{ServerId: "srv1"})
+	if err != nil {
+		t.Fatalf("ListRoles: %v", err)
+	}
+	for _, role := range roles.Roles {
+		if !role.IsDefault {
+			continue
+		}
+		_, err := s.roles.SetPermissionOverwrite(alice, &pb.SetPermissionOverwriteRequest{Overwrite: &pb.PermissionOverwrite{
+			ChannelId:  channelID,
+			TargetType: pb.OverwriteTargetType_OVERWRITE_TARGET_TYPE_ROLE,
+			TargetId:   role.RoleId,
+			Deny:       uint64(PermViewChannel),
+		}})
+		if err != nil {
+			t.Fatalf("SetPermissionOverwrite: %v", err)
+		}
+	}
+	if _, err := s.messages.SendMessage(alice, &pb.SendMessageRequest{ChannelId: channelID, Content: "launch codes"}); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	if _, err := s.channels.DeleteChannel(alice, &pb.DeleteChannelRequest{ChannelId: channelID}); err != nil {
+		t.Fatalf("DeleteChannel: %v", err)
+	}
+
+	_, err = s.events.GetEvents(bob, &pb.GetEventsRequest{Scope: "channel:" + channelID, FromSequence: 1})
+	requireCode(t, err, codes.NotFound)
+	message := &pb.Event{Scope: "channel:" + channelID, EventType: "message.sent"}
+	if s.events.canSeeEvent(bob, message, newChannelVisibilityCache()) {
+		t.Error("non-member can see the events of a deleted private channel")
+	}
+	if !s.events.canSeeEvent(alice, message, newChannelVisibilityCache()) {
+		t.Error("channel manager can't see the events of a deleted channel")
+	}
+
+	// Only the deletion itself shows up in the server scope
+	var deleted bool
+	for _, event := range storedEvents(t, s, bob, "server:srv1") {
+		switch {
+		case event.Metadata["channel_id"] != channelID:
+		case event.EventType == "channel.deleted":
+			deleted = true
+		default:
+			t.Errorf("non-member can see %s of the deleted channel", event.EventType)
+		}
+	}
+	if !deleted {
+		t.Error("non-member can't see that the channel was deleted")
+	}
+}
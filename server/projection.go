@@ -0,0 +1,554 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// Projection builds a read model from the event log. Replaying the log
+// through a projection rebuilds its tables from scratch, so Apply may only
+// depend on the event and the state earlier events produced. New read models
+// are added by implementing Projection and listing it in projections.
+type Projection interface {
+	Name() string
+	// EventTypes lists the event types Apply handles. Retention never
+	// deletes them, since a replay rebuilds the read model from them
+	EventTypes() []string
+	// Reset clears the read model before a replay
+	Reset(ctx context.Context, tx *database.Queries) error
+	// Apply updates the read model for one event; events the projection
+	// doesn't handle are ignored
+	Apply(ctx context.Context, tx *database.Queries, event *pb.Event) error
+}
+
+var projections = []Projection{
+	channelProjection{},
+	messageProjection{},
+	threadProjection{},
+	configProjection{},
+}
+
+// ProjectionNames lists the projections a replay can run.
+func ProjectionNames() []string {
+	names := make([]string, len(projections))
+	for i, projection := range projections {
+		names[i] = projection.Name()
+	}
+	return names
+}
+
+func projectionsByName(names []string) ([]Projection, error) {
+	if len(names) == 0 {
+		return projections, nil
+	}
+
+	var selected []Projection
+	for _, name := range names {
+		i := slices.IndexFunc(projections, func(p Projection) bool { return p.Name() == name })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown projection %q, expected one of %v", name, ProjectionNames())
+		}
+		selected = append(selected, projections[i])
+	}
+	return selected, nil
+}
+
+// isProjectedEventType reports whether any projection handles an event type.
+func isProjectedEventType(eventType string) bool {
+	for _, projection := range projections {
+		if slices.Contains(projection.EventTypes(), eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// errEventSkipped marks an event a projection could not apply because the
+// event log lacks the data, e.g. a redacted sensitive config value.
+var errEventSkipped = errors.New("event skipped")
+
+// channelProjection maintains the channels table, including the channels of
+// threads.
+type channelProjection struct{}
+
+func (channelProjection) Name() string { return "channels" }
+
+func (channelProjection) EventTypes() []string {
+	return []string{"channel.created", "thread.created", "channel.updated", "channel.deleted"}
+}
+
+func (channelProjection) Reset(ctx context.Context, tx *database.Queries) error {
+	return tx.DeleteAllChannels(ctx)
+}
+
+func (channelProjection) Apply(ctx context.Context, tx *database.Queries, event *pb.Event) error {
+	switch event.EventType {
+	case "channel.created":
+		payload := &pb.ChannelCreatedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		return createProjectedChannel(ctx, tx, payload.Channel)
+
+	case "thread.created":
+		payload := &pb.ThreadCreatedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		return createProjectedChannel(ctx, tx, payload.Thread)
+
+	case "channel.updated":
+		payload := &pb.ChannelUpdatedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		channel := payload.Channel
+		metadata, err := channelMetadataJSON(channel.Metadata)
+		if err != nil {
+			return err
+		}
+		_, err = tx.UpdateChannel(ctx, database.UpdateChannelParams{
+			Name:      channel.Name,
+			ParentID:  sql.NullString{String: channel.ParentId, Valid: channel.ParentId != ""},
+			Metadata:  metadata,
+			UpdatedAt: channel.UpdatedAt.AsTime().Unix(),
+			ChannelID: channel.ChannelId,
+		})
+		return err
+
+	case "channel.deleted":
+		payload := &pb.ChannelDeletedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		// Channels are now deleted after their threads and without
+		// subchannels; logs from before that still need the children
+		// handled the way the migration did
+		children, err := tx.ListChildChannels(ctx, sql.NullString{String: payload.ChannelId, Valid: true})
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if pb.ChannelType(child.Type) == pb.ChannelType_CHANNEL_TYPE_THREAD {
+				if err := tx.DeleteChannel(ctx, child.ChannelID); err != nil {
+					return err
+				}
+			}
+		}
+		if err := tx.DetachChildChannels(ctx, sql.NullString{String: payload.ChannelId, Valid: true}); err != nil {
+			return err
+		}
+		return tx.DeleteChannel(ctx, payload.ChannelId)
+	}
+	return nil
+}
+
+func createProjectedChannel(ctx context.Context, tx *database.Queries, channel *pb.Channel) error {
+	metadata, err := channelMetadataJSON(channel.Metadata)
+	if err != nil {
+		return err
+	}
+	_, err = tx.CreateChannel(ctx, database.CreateChannelParams{
+		ChannelID: channel.ChannelId,
+		Name:      channel.Name,
+		Type:      int64(channel.Type),
+		ServerID:  sql.NullString{String: channel.ServerId, Valid: channel.ServerId != ""},
+		ParentID:  sql.NullString{String: channel.ParentId, Valid: channel.ParentId != ""},
+		Metadata:  metadata,
+		CreatedAt: channel.CreatedAt.AsTime().Unix(),
+		UpdatedAt: channel.UpdatedAt.AsTime().Unix(),
+	})
+	return err
+}
+
+func channelMetadataJSON(metadata map[string]string) (sql.NullString, error) {
+	if len(metadata) == 0 {
+		return sql.NullString{}, nil
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+// messageProjection maintains the messages, message_revisions, attachments,
+// embeds, embed_fields and reactions tables.
+type messageProjection struct{}
+
+func (messageProjection) Name() string { return "messages" }
+
+func (messageProjection) EventTypes() []string {
+	return []string{
+		"message.sent", "message.updated", "message.deleted", "message.purged",
+		"message.reaction_added", "message.reaction_removed", "channel.deleted",
+	}
+}
+
+func (messageProjection) Reset(ctx context.Context, tx *database.Queries) error {
+	if err := tx.DeleteAllReactions(ctx); err != nil {
+		return err
+	}
+	if err := tx.DeleteAllMessageRevisions(ctx); err != nil {
+		return err
+	}
+	if err := tx.DeleteAllEmbedFields(ctx); err != nil {
+		return err
+	}
+	if err := tx.DeleteAllEmbeds(ctx); err != nil {
+		return err
+	}
+	if err := tx.DeleteAllAttachments(ctx); err != nil {
+		return err
+	}
+	return tx.DeleteAllMessages(ctx)
+}
+
+func (messageProjection) Apply(ctx context.Context, tx *database.Queries, event *pb.Event) error {
+	switch event.EventType {
+	case "message.sent":
+		payload := &pb.MessageSentPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		message := payload.Message
+		_, err := tx.CreateMessage(ctx, database.CreateMessageParams{
+			MessageID: message.MessageId,
+			ChannelID: message.ChannelId,
+			AuthorID:  message.AuthorId,
+			Content:   message.Content,
+			CreatedAt: message.CreatedAt.AsTime().Unix(),
+			UpdatedAt: message.UpdatedAt.AsTime().Unix(),
+			ReplyToID: sql.NullString{String: message.ReplyToId, Valid: message.ReplyToId != ""},
+		})
+		if err != nil {
+			return err
+		}
+		if err := saveAttachments(ctx, tx, message); err != nil {
+			return err
+		}
+		return saveEmbeds(ctx, tx, message.MessageId, message.Embeds)
+
+	case "message.updated":
+		// Embeds and attachments are only replaced when they changed, as in
+		// UpdateMessage
+		payload := &pb.MessageUpdatedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		message := payload.Message
+		if err := reviseMessage(ctx, tx, message.MessageId, event.ActorId, message.UpdatedAt.AsTime().Unix()); err != nil {
+			return err
+		}
+		for _, field := range payload.ChangedFields {
+			switch field {
+			case "embeds":
+				if err := replaceEmbeds(ctx, tx, message.MessageId, message.Embeds); err != nil {
+					return err
+				}
+			case "attachments":
+				if err := replaceAttachments(ctx, tx, message); err != nil {
+					return err
+				}
+			}
+		}
+		_, err := tx.UpdateMessage(ctx, database.UpdateMessageParams{
+			Content:   message.Content,
+			UpdatedAt: message.UpdatedAt.AsTime().Unix(),
+			MessageID: message.MessageId,
+		})
+		return err
+
+	case "message.deleted":
+		payload := &pb.MessageDeletedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		if _, err := tx.TombstoneMessage(ctx, database.TombstoneMessageParams{
+			DeletedAt: sql.NullInt64{Int64: event.Timestamp.AsTime().Unix(), Valid: true},
+			DeletedBy: sql.NullString{String: event.ActorId, Valid: true},
+			MessageID: payload.MessageId,
+		}); err != nil {
+			return err
+		}
+		return tx.DeleteReactionsByMessageId(ctx, payload.MessageId)
+
+	case "message.purged":
+		payload := &pb.MessagePurgedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		return eraseMessage(ctx, tx, payload.MessageId, event.Timestamp.AsTime().Unix())
+
+	case "message.reaction_added":
+		payload := &pb.MessageReactionPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		_, err := tx.AddReaction(ctx, database.AddReactionParams{
+			MessageID: payload.MessageId,
+			Emoji:     payload.Emoji,
+			UserID:    payload.UserId,
+			CreatedAt: event.Timestamp.AsTime().Unix(),
+		})
+		return err
+
+	case "message.reaction_removed":
+		payload := &pb.MessageReactionPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		_, err := tx.RemoveReaction(ctx, database.RemoveReactionParams{
+			MessageID: payload.MessageId,
+			Emoji:     payload.Emoji,
+			UserID:    payload.UserId,
+		})
+		return err
+
+	case "channel.deleted":
+		payload := &pb.ChannelDeletedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		// Older logs delete channels before their threads
+		threads, err := tx.ListThreadsByParentId(ctx, payload.ChannelId)
+		if err != nil {
+			return err
+		}
+		for _, thread := range threads {
+			if err := deleteChannelMessages(ctx, tx, thread.ChannelID); err != nil {
+				return err
+			}
+		}
+		return deleteChannelMessages(ctx, tx, payload.ChannelId)
+	}
+	return nil
+}
+
+// deleteChannelMessages removes the messages of a deleted channel with
+// everything attached to them. Foreign keys do this when a channel is
+// deleted, but replays run without them.
+func deleteChannelMessages(ctx context.Context, tx *database.Queries, channelID string) error {
+	if err := tx.DeleteReactionsByChannelId(ctx, channelID); err != nil {
+		return err
+	}
+	if err := tx.DeleteMessageRevisionsByChannelId(ctx, channelID); err != nil {
+		return err
+	}
+	if err := tx.DeleteEmbedFieldsByChannelId(ctx, channelID); err != nil {
+		return err
+	}
+	if err := tx.DeleteEmbedsByChannelId(ctx, channelID); err != nil {
+		return err
+	}
+	if err := tx.DeleteAttachmentsByChannelId(ctx, channelID); err != nil {
+		return err
+	}
+	_, err := tx.DeleteMessagesByChannelId(ctx, channelID)
+	return err
+}
+
+// threadProjection maintains the threads and thread_members tables. Message
+// counts and activity follow the messages sent to and deleted from threads.
+type threadProjection struct{}
+
+func (threadProjection) Name() string { return "threads" }
+
+func (threadProjection) EventTypes() []string {
+	return []string{
+		"thread.created", "thread.updated", "thread.member_added", "thread.member_removed",
+		"message.sent", "message.deleted", "channel.deleted",
+	}
+}
+
+func (threadProjection) Reset(ctx context.Context, tx *database.Queries) error {
+	if err := tx.DeleteAllThreadMembers(ctx); err != nil {
+		return err
+	}
+	return tx.DeleteAllThreads(ctx)
+}
+
+func (threadProjection) Apply(ctx context.Context, tx *database.Queries, event *pb.Event) error {
+	switch event.EventType {
+	case "thread.created":
+		payload := &pb.ThreadCreatedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		channel, thread := payload.Thread, payload.Thread.GetThread()
+		if thread == nil {
+			return fmt.Errorf("thread.created event for %s has no thread", channel.ChannelId)
+		}
+		createdAt := channel.CreatedAt.AsTime().Unix()
+		_, err := tx.CreateThread(ctx, database.CreateThreadParams{
+			ChannelID:           channel.ChannelId,
+			ParentID:            channel.ParentId,
+			MessageID:           thread.MessageId,
+			OwnerID:             thread.OwnerId,
+			AutoArchiveDuration: int64(thread.AutoArchiveDuration),
+			LastActivityAt:      thread.LastActivityAt.AsTime().Unix(),
+			CreatedAt:           createdAt,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tx.AddThreadMember(ctx, database.AddThreadMemberParams{
+			ChannelID: channel.ChannelId,
+			UserID:    thread.OwnerId,
+			JoinedAt:  createdAt,
+		})
+		return err
+
+	case "thread.updated":
+		payload := &pb.ThreadUpdatedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		channel, thread := payload.Thread, payload.Thread.GetThread()
+		if thread == nil {
+			return fmt.Errorf("thread.updated event for %s has no thread", channel.ChannelId)
+		}
+		_, err := tx.UpdateThread(ctx, database.UpdateThreadParams{
+			Archived:            boolToInt64(thread.Archived),
+			Locked:              boolToInt64(thread.Locked),
+			AutoArchiveDuration: int64(thread.AutoArchiveDuration),
+			LastActivityAt:      thread.LastActivityAt.AsTime().Unix(),
+			ChannelID:           channel.ChannelId,
+		})
+		return err
+
+	case "thread.member_added":
+		payload := &pb.ThreadMemberPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		_, err := tx.AddThreadMember(ctx, database.AddThreadMemberParams{
+			ChannelID: payload.ThreadId,
+			UserID:    payload.UserId,
+			JoinedAt:  event.Timestamp.AsTime().Unix(),
+		})
+		return err
+
+	case "thread.member_removed":
+		payload := &pb.ThreadMemberPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		_, err := tx.RemoveThreadMember(ctx, database.RemoveThreadMemberParams{
+			ChannelID: payload.ThreadId,
+			UserID:    payload.UserId,
+		})
+		return err
+
+	case "message.sent":
+		payload := &pb.MessageSentPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		message := payload.Message
+		if _, err := tx.GetThread(ctx, message.ChannelId); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		return recordThreadMessage(ctx, tx, message.ChannelId, message.AuthorId, message.CreatedAt.AsTime().Unix())
+
+	case "message.deleted":
+		payload := &pb.MessageDeletedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		return tx.RemoveThreadMessage(ctx, payload.ChannelId)
+
+	case "channel.deleted":
+		payload := &pb.ChannelDeletedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		// Older logs delete channels before their threads
+		threads, err := tx.ListThreadsByParentId(ctx, payload.ChannelId)
+		if err != nil {
+			return err
+		}
+		for _, thread := range threads {
+			if err := deleteThread(ctx, tx, thread.ChannelID); err != nil {
+				return err
+			}
+		}
+		return deleteThread(ctx, tx, payload.ChannelId)
+	}
+	return nil
+}
+
+// configProjection maintains the config_values table. Events only carry
+// redacted sensitive values, so sensitive configs are kept as stored and
+// never touched by a replay.
+type configProjection struct{}
+
+func (configProjection) Name() string { return "config" }
+
+func (configProjection) EventTypes() []string {
+	return []string{"config.updated", "config.deleted"}
+}
+
+func (configProjection) Reset(ctx context.Context, tx *database.Queries) error {
+	return tx.DeleteNonSensitiveConfigs(ctx)
+}
+
+func (configProjection) Apply(ctx context.Context, tx *database.Queries, event *pb.Event) error {
+	switch event.EventType {
+	case "config.updated":
+		payload := &pb.ConfigUpdatedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		if payload.NewValue.GetIsSensitive() {
+			return fmt.Errorf("%w: sensitive config %s in %s is redacted in the event log", errEventSkipped, payload.Key, payload.Scope)
+		}
+		if sensitive, err := storedConfigIsSensitive(ctx, tx, payload.Scope, payload.Key); err != nil || sensitive {
+			return err
+		}
+
+		params, err := setConfigParams(payload.Scope, payload.Key, payload.NewValue, event.Timestamp.AsTime().Unix())
+		if err != nil {
+			return err
+		}
+		_, err = tx.SetConfig(ctx, params)
+		return err
+
+	case "config.deleted":
+		payload := &pb.ConfigDeletedPayload{}
+		if err := unpackEventPayload(event, payload); err != nil {
+			return err
+		}
+		if sensitive, err := storedConfigIsSensitive(ctx, tx, payload.Scope, payload.Key); err != nil || sensitive {
+			return err
+		}
+
+		_, err := tx.DeleteConfig(ctx, database.DeleteConfigParams{Scope: payload.Scope, Key: payload.Key})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func storedConfigIsSensitive(ctx context.Context, tx *database.Queries, scope, key string) (bool, error) {
+	row, err := tx.GetConfig(ctx, database.GetConfigParams{Scope: scope, Key: key})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return row.IsSensitive != 0, nil
+}
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,14 +25,21 @@ const (
 // Event subscription configuration
 type SubscribeRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Event types to subscribe to (empty = all)
+	// Event types to subscribe to (empty = all); "*" matches one dot
+	// separated segment, e.g. "message.*"
 	EventTypes []string `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
-	// Scopes to filter by (empty = all accessible)
+	// Scope patterns to filter by (empty = all accessible), e.g.
+	// "server:abc", "server:abc/*" or "server:abc/channel:*"
 	Scopes []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
-	// Start from this sequence number (0 = current, -1 = beginning)
+	// Start from this sequence number (0 = current, -1 = beginning); a replay
+	// needs scopes
 	FromSequence int64 `protobuf:"varint,3,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
 	// Additional filtering metadata
-	Filters       map[string]string `protobuf:"bytes,4,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Filters map[string]string `protobuf:"bytes,4,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// CEL expression over event_type, scope, actor, sequence, timestamp,
+	// metadata, the decoded payload and the caller's user ID, e.g.
+	// `event_type == "message.sent" && payload.message.content.contains("@" + caller)`
+	Filter        string `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -94,6 +102,13 @@ func (x *SubscribeRequest) GetFilters() map[string]string {
 	return nil
 }
 
+func (x *SubscribeRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
 type PublishRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
@@ -207,12 +222,14 @@ func (x *PublishResponse) GetError() string {
 }
 
 type GetEventsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
-	EventTypes    []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
-	FromSequence  int64                  `protobuf:"varint,3,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
-	ToSequence    int64                  `protobuf:"varint,4,opt,name=to_sequence,json=toSequence,proto3" json:"to_sequence,omitempty"`
-	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Scope        string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	EventTypes   []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	FromSequence int64                  `protobuf:"varint,3,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
+	ToSequence   int64                  `protobuf:"varint,4,opt,name=to_sequence,json=toSequence,proto3" json:"to_sequence,omitempty"`
+	Limit        int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Filter expression in CEL, as in SubscribeRequest.filter
+	Filter        string `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -282,6 +299,13 @@ func (x *GetEventsRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *GetEventsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
 type GetEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
@@ -342,17 +366,523 @@ func (x *GetEventsResponse) GetNextSequence() int64 {
 	return 0
 }
 
+type ConsumeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Consumer group name; the group's position is kept across restarts
+	Group string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	// Scope to consume, e.g. "channel:xyz"; patterns are not allowed
+	Scope string `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	// Event types to consume (empty = all), as in SubscribeRequest.event_types.
+	// Events that don't match are skipped and count as acked
+	EventTypes []string `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// Filter expression in CEL, as in SubscribeRequest.filter
+	Filter string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Unacked events this member holds at once (0 = 10)
+	MaxInFlight int32 `protobuf:"varint,5,opt,name=max_in_flight,json=maxInFlight,proto3" json:"max_in_flight,omitempty"`
+	// Seconds before an unacked event is redelivered (0 = 30)
+	AckTimeoutSeconds int32 `protobuf:"varint,6,opt,name=ack_timeout_seconds,json=ackTimeoutSeconds,proto3" json:"ack_timeout_seconds,omitempty"`
+	// Deliveries before an event is moved to the group's dead-letter scope,
+	// "dead_letter:<group>" (0 = 5)
+	MaxDeliveries int32 `protobuf:"varint,7,opt,name=max_deliveries,json=maxDeliveries,proto3" json:"max_deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumeRequest) Reset() {
+	*x = ConsumeRequest{}
+	mi := &file_event_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeRequest) ProtoMessage() {}
+
+func (x *ConsumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumeRequest.ProtoReflect.Descriptor instead.
+func (*ConsumeRequest) Descriptor() ([]byte, []int) {
+	return file_event_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConsumeRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ConsumeRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *ConsumeRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *ConsumeRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ConsumeRequest) GetMaxInFlight() int32 {
+	if x != nil {
+		return x.MaxInFlight
+	}
+	return 0
+}
+
+func (x *ConsumeRequest) GetAckTimeoutSeconds() int32 {
+	if x != nil {
+		return x.AckTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ConsumeRequest) GetMaxDeliveries() int32 {
+	if x != nil {
+		return x.MaxDeliveries
+	}
+	return 0
+}
+
+type ConsumedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Event *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// 1 on first delivery, incremented on each redelivery
+	DeliveryCount int32 `protobuf:"varint,2,opt,name=delivery_count,json=deliveryCount,proto3" json:"delivery_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumedEvent) Reset() {
+	*x = ConsumedEvent{}
+	mi := &file_event_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumedEvent) ProtoMessage() {}
+
+func (x *ConsumedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumedEvent.ProtoReflect.Descriptor instead.
+func (*ConsumedEvent) Descriptor() ([]byte, []int) {
+	return file_event_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConsumedEvent) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ConsumedEvent) GetDeliveryCount() int32 {
+	if x != nil {
+		return x.DeliveryCount
+	}
+	return 0
+}
+
+type AckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	Sequences     []int64                `protobuf:"varint,3,rep,packed,name=sequences,proto3" json:"sequences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckRequest) Reset() {
+	*x = AckRequest{}
+	mi := &file_event_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckRequest) ProtoMessage() {}
+
+func (x *AckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckRequest.ProtoReflect.Descriptor instead.
+func (*AckRequest) Descriptor() ([]byte, []int) {
+	return file_event_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AckRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *AckRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *AckRequest) GetSequences() []int64 {
+	if x != nil {
+		return x.Sequences
+	}
+	return nil
+}
+
+type AckResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Every event up to this sequence has been acked by the group
+	AckedSequence int64 `protobuf:"varint,1,opt,name=acked_sequence,json=ackedSequence,proto3" json:"acked_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckResponse) Reset() {
+	*x = AckResponse{}
+	mi := &file_event_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckResponse) ProtoMessage() {}
+
+func (x *AckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckResponse.ProtoReflect.Descriptor instead.
+func (*AckResponse) Descriptor() ([]byte, []int) {
+	return file_event_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AckResponse) GetAckedSequence() int64 {
+	if x != nil {
+		return x.AckedSequence
+	}
+	return 0
+}
+
+type NackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	Sequences     []int64                `protobuf:"varint,3,rep,packed,name=sequences,proto3" json:"sequences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NackRequest) Reset() {
+	*x = NackRequest{}
+	mi := &file_event_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NackRequest) ProtoMessage() {}
+
+func (x *NackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackRequest.ProtoReflect.Descriptor instead.
+func (*NackRequest) Descriptor() ([]byte, []int) {
+	return file_event_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NackRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *NackRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *NackRequest) GetSequences() []int64 {
+	if x != nil {
+		return x.Sequences
+	}
+	return nil
+}
+
+type NackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NackResponse) Reset() {
+	*x = NackResponse{}
+	mi := &file_event_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NackResponse) ProtoMessage() {}
+
+func (x *NackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackResponse.ProtoReflect.Descriptor instead.
+func (*NackResponse) Descriptor() ([]byte, []int) {
+	return file_event_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NackResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSnapshotRequest) Reset() {
+	*x = GetSnapshotRequest{}
+	mi := &file_event_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotRequest) ProtoMessage() {}
+
+func (x *GetSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_event_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetSnapshotRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+// State of a scope as of an event sequence
+type ScopeSnapshot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Scope string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	// Events up to and including this sequence are reflected in the state
+	Sequence  int64                  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Channels of a server scope, or the channel of a channel scope
+	Channels []*Channel `protobuf:"bytes,4,rep,name=channels,proto3" json:"channels,omitempty"`
+	// Roles of a server scope
+	Roles []*Role `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles,omitempty"`
+	// Permission overwrites of a channel scope
+	PermissionOverwrites []*PermissionOverwrite `protobuf:"bytes,6,rep,name=permission_overwrites,json=permissionOverwrites,proto3" json:"permission_overwrites,omitempty"`
+	// Config values set in the scope; sensitive values are redacted
+	Configs       map[string]*ConfigValue `protobuf:"bytes,7,rep,name=configs,proto3" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScopeSnapshot) Reset() {
+	*x = ScopeSnapshot{}
+	mi := &file_event_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScopeSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScopeSnapshot) ProtoMessage() {}
+
+func (x *ScopeSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_event_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScopeSnapshot.ProtoReflect.Descriptor instead.
+func (*ScopeSnapshot) Descriptor() ([]byte, []int) {
+	return file_event_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ScopeSnapshot) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *ScopeSnapshot) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ScopeSnapshot) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ScopeSnapshot) GetChannels() []*Channel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *ScopeSnapshot) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *ScopeSnapshot) GetPermissionOverwrites() []*PermissionOverwrite {
+	if x != nil {
+		return x.PermissionOverwrites
+	}
+	return nil
+}
+
+func (x *ScopeSnapshot) GetConfigs() map[string]*ConfigValue {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
 var File_event_service_proto protoreflect.FileDescriptor
 
 const file_event_service_proto_rawDesc = "" +
 	"\n" +
-	"\x13event_service.proto\x12\x04fuwa\x1a\vtypes.proto\"\xeb\x01\n" +
+	"\x13event_service.proto\x12\x04fuwa\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\vtypes.proto\"\x83\x02\n" +
 	"\x10SubscribeRequest\x12\x1f\n" +
 	"\vevent_types\x18\x01 \x03(\tR\n" +
 	"eventTypes\x12\x16\n" +
 	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x12#\n" +
 	"\rfrom_sequence\x18\x03 \x01(\x03R\ffromSequence\x12=\n" +
-	"\afilters\x18\x04 \x03(\v2#.fuwa.SubscribeRequest.FiltersEntryR\afilters\x1a:\n" +
+	"\afilters\x18\x04 \x03(\v2#.fuwa.SubscribeRequest.FiltersEntryR\afilters\x12\x16\n" +
+	"\x06filter\x18\x05 \x01(\tR\x06filter\x1a:\n" +
 	"\fFiltersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"3\n" +
@@ -362,7 +892,7 @@ const file_event_service_proto_rawDesc = "" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1a\n" +
 	"\bsequence\x18\x02 \x01(\x03R\bsequence\x12\x18\n" +
 	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x04 \x01(\tR\x05error\"\xa5\x01\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xbd\x01\n" +
 	"\x10GetEventsRequest\x12\x14\n" +
 	"\x05scope\x18\x01 \x01(\tR\x05scope\x12\x1f\n" +
 	"\vevent_types\x18\x02 \x03(\tR\n" +
@@ -370,15 +900,60 @@ const file_event_service_proto_rawDesc = "" +
 	"\rfrom_sequence\x18\x03 \x01(\x03R\ffromSequence\x12\x1f\n" +
 	"\vto_sequence\x18\x04 \x01(\x03R\n" +
 	"toSequence\x12\x14\n" +
-	"\x05limit\x18\x05 \x01(\x05R\x05limit\"x\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06filter\x18\x06 \x01(\tR\x06filter\"x\n" +
 	"\x11GetEventsResponse\x12#\n" +
 	"\x06events\x18\x01 \x03(\v2\v.fuwa.EventR\x06events\x12\x19\n" +
 	"\bhas_more\x18\x02 \x01(\bR\ahasMore\x12#\n" +
-	"\rnext_sequence\x18\x03 \x01(\x03R\fnextSequence2\xb8\x01\n" +
+	"\rnext_sequence\x18\x03 \x01(\x03R\fnextSequence\"\xf0\x01\n" +
+	"\x0eConsumeRequest\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x14\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12\x16\n" +
+	"\x06filter\x18\x04 \x01(\tR\x06filter\x12\"\n" +
+	"\rmax_in_flight\x18\x05 \x01(\x05R\vmaxInFlight\x12.\n" +
+	"\x13ack_timeout_seconds\x18\x06 \x01(\x05R\x11ackTimeoutSeconds\x12%\n" +
+	"\x0emax_deliveries\x18\a \x01(\x05R\rmaxDeliveries\"Y\n" +
+	"\rConsumedEvent\x12!\n" +
+	"\x05event\x18\x01 \x01(\v2\v.fuwa.EventR\x05event\x12%\n" +
+	"\x0edelivery_count\x18\x02 \x01(\x05R\rdeliveryCount\"V\n" +
+	"\n" +
+	"AckRequest\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x14\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\x12\x1c\n" +
+	"\tsequences\x18\x03 \x03(\x03R\tsequences\"4\n" +
+	"\vAckResponse\x12%\n" +
+	"\x0eacked_sequence\x18\x01 \x01(\x03R\rackedSequence\"W\n" +
+	"\vNackRequest\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x14\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\x12\x1c\n" +
+	"\tsequences\x18\x03 \x03(\x03R\tsequences\"(\n" +
+	"\fNackResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"*\n" +
+	"\x12GetSnapshotRequest\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\"\xa4\x03\n" +
+	"\rScopeSnapshot\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x03R\bsequence\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12)\n" +
+	"\bchannels\x18\x04 \x03(\v2\r.fuwa.ChannelR\bchannels\x12 \n" +
+	"\x05roles\x18\x05 \x03(\v2\n" +
+	".fuwa.RoleR\x05roles\x12N\n" +
+	"\x15permission_overwrites\x18\x06 \x03(\v2\x19.fuwa.PermissionOverwriteR\x14permissionOverwrites\x12:\n" +
+	"\aconfigs\x18\a \x03(\v2 .fuwa.ScopeSnapshot.ConfigsEntryR\aconfigs\x1aM\n" +
+	"\fConfigsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.fuwa.ConfigValueR\x05value:\x028\x012\x89\x03\n" +
 	"\fEventService\x122\n" +
 	"\tSubscribe\x12\x16.fuwa.SubscribeRequest\x1a\v.fuwa.Event0\x01\x126\n" +
 	"\aPublish\x12\x14.fuwa.PublishRequest\x1a\x15.fuwa.PublishResponse\x12<\n" +
-	"\tGetEvents\x12\x16.fuwa.GetEventsRequest\x1a\x17.fuwa.GetEventsResponseB\"Z github.com/waifu-devs/fuwa/protob\x06proto3"
+	"\tGetEvents\x12\x16.fuwa.GetEventsRequest\x1a\x17.fuwa.GetEventsResponse\x126\n" +
+	"\aConsume\x12\x14.fuwa.ConsumeRequest\x1a\x13.fuwa.ConsumedEvent0\x01\x12*\n" +
+	"\x03Ack\x12\x10.fuwa.AckRequest\x1a\x11.fuwa.AckResponse\x12-\n" +
+	"\x04Nack\x12\x11.fuwa.NackRequest\x1a\x12.fuwa.NackResponse\x12<\n" +
+	"\vGetSnapshot\x12\x18.fuwa.GetSnapshotRequest\x1a\x13.fuwa.ScopeSnapshotB\"Z github.com/waifu-devs/fuwa/protob\x06proto3"
 
 var (
 	file_event_service_proto_rawDescOnce sync.Once
@@ -392,31 +967,60 @@ func file_event_service_proto_rawDescGZIP() []byte {
 	return file_event_service_proto_rawDescData
 }
 
-var file_event_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_event_service_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_event_service_proto_goTypes = []any{
-	(*SubscribeRequest)(nil),  // 0: fuwa.SubscribeRequest
-	(*PublishRequest)(nil),    // 1: fuwa.PublishRequest
-	(*PublishResponse)(nil),   // 2: fuwa.PublishResponse
-	(*GetEventsRequest)(nil),  // 3: fuwa.GetEventsRequest
-	(*GetEventsResponse)(nil), // 4: fuwa.GetEventsResponse
-	nil,                       // 5: fuwa.SubscribeRequest.FiltersEntry
-	(*Event)(nil),             // 6: fuwa.Event
+	(*SubscribeRequest)(nil),      // 0: fuwa.SubscribeRequest
+	(*PublishRequest)(nil),        // 1: fuwa.PublishRequest
+	(*PublishResponse)(nil),       // 2: fuwa.PublishResponse
+	(*GetEventsRequest)(nil),      // 3: fuwa.GetEventsRequest
+	(*GetEventsResponse)(nil),     // 4: fuwa.GetEventsResponse
+	(*ConsumeRequest)(nil),        // 5: fuwa.ConsumeRequest
+	(*ConsumedEvent)(nil),         // 6: fuwa.ConsumedEvent
+	(*AckRequest)(nil),            // 7: fuwa.AckRequest
+	(*AckResponse)(nil),           // 8: fuwa.AckResponse
+	(*NackRequest)(nil),           // 9: fuwa.NackRequest
+	(*NackResponse)(nil),          // 10: fuwa.NackResponse
+	(*GetSnapshotRequest)(nil),    // 11: fuwa.GetSnapshotRequest
+	(*ScopeSnapshot)(nil),         // 12: fuwa.ScopeSnapshot
+	nil,                           // 13: fuwa.SubscribeRequest.FiltersEntry
+	nil,                           // 14: fuwa.ScopeSnapshot.ConfigsEntry
+	(*Event)(nil),                 // 15: fuwa.Event
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*Channel)(nil),               // 17: fuwa.Channel
+	(*Role)(nil),                  // 18: fuwa.Role
+	(*PermissionOverwrite)(nil),   // 19: fuwa.PermissionOverwrite
+	(*ConfigValue)(nil),           // 20: fuwa.ConfigValue
 }
 var file_event_service_proto_depIdxs = []int32{
-	5, // 0: fuwa.SubscribeRequest.filters:type_name -> fuwa.SubscribeRequest.FiltersEntry
-	6, // 1: fuwa.PublishRequest.event:type_name -> fuwa.Event
-	6, // 2: fuwa.GetEventsResponse.events:type_name -> fuwa.Event
-	0, // 3: fuwa.EventService.Subscribe:input_type -> fuwa.SubscribeRequest
-	1, // 4: fuwa.EventService.Publish:input_type -> fuwa.PublishRequest
-	3, // 5: fuwa.EventService.GetEvents:input_type -> fuwa.GetEventsRequest
-	6, // 6: fuwa.EventService.Subscribe:output_type -> fuwa.Event
-	2, // 7: fuwa.EventService.Publish:output_type -> fuwa.PublishResponse
-	4, // 8: fuwa.EventService.GetEvents:output_type -> fuwa.GetEventsResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	13, // 0: fuwa.SubscribeRequest.filters:type_name -> fuwa.SubscribeRequest.FiltersEntry
+	15, // 1: fuwa.PublishRequest.event:type_name -> fuwa.Event
+	15, // 2: fuwa.GetEventsResponse.events:type_name -> fuwa.Event
+	15, // 3: fuwa.ConsumedEvent.event:type_name -> fuwa.Event
+	16, // 4: fuwa.ScopeSnapshot.created_at:type_name -> google.protobuf.Timestamp
+	17, // 5: fuwa.ScopeSnapshot.channels:type_name -> fuwa.Channel
+	18, // 6: fuwa.ScopeSnapshot.roles:type_name -> fuwa.Role
+	19, // 7: fuwa.ScopeSnapshot.permission_overwrites:type_name -> fuwa.PermissionOverwrite
+	14, // 8: fuwa.ScopeSnapshot.configs:type_name -> fuwa.ScopeSnapshot.ConfigsEntry
+	20, // 9: fuwa.ScopeSnapshot.ConfigsEntry.value:type_name -> fuwa.ConfigValue
+	0,  // 10: fuwa.EventService.Subscribe:input_type -> fuwa.SubscribeRequest
+	1,  // 11: fuwa.EventService.Publish:input_type -> fuwa.PublishRequest
+	3,  // 12: fuwa.EventService.GetEvents:input_type -> fuwa.GetEventsRequest
+	5,  // 13: fuwa.EventService.Consume:input_type -> fuwa.ConsumeRequest
+	7,  // 14: fuwa.EventService.Ack:input_type -> fuwa.AckRequest
+	9,  // 15: fuwa.EventService.Nack:input_type -> fuwa.NackRequest
+	11, // 16: fuwa.EventService.GetSnapshot:input_type -> fuwa.GetSnapshotRequest
+	15, // 17: fuwa.EventService.Subscribe:output_type -> fuwa.Event
+	2,  // 18: fuwa.EventService.Publish:output_type -> fuwa.PublishResponse
+	4,  // 19: fuwa.EventService.GetEvents:output_type -> fuwa.GetEventsResponse
+	6,  // 20: fuwa.EventService.Consume:output_type -> fuwa.ConsumedEvent
+	8,  // 21: fuwa.EventService.Ack:output_type -> fuwa.AckResponse
+	10, // 22: fuwa.EventService.Nack:output_type -> fuwa.NackResponse
+	12, // 23: fuwa.EventService.GetSnapshot:output_type -> fuwa.ScopeSnapshot
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_event_service_proto_init() }
@@ -431,7 +1035,7 @@ func file_event_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_service_proto_rawDesc), len(file_event_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	EventService_Subscribe_FullMethodName = "/fuwa.EventService/Subscribe"
-	EventService_Publish_FullMethodName   = "/fuwa.EventService/Publish"
-	EventService_GetEvents_FullMethodName = "/fuwa.EventService/GetEvents"
+	EventService_Subscribe_FullMethodName   = "/fuwa.EventService/Subscribe"
+	EventService_Publish_FullMethodName     = "/fuwa.EventService/Publish"
+	EventService_GetEvents_FullMethodName   = "/fuwa.EventService/GetEvents"
+	EventService_Consume_FullMethodName     = "/fuwa.EventService/Consume"
+	EventService_Ack_FullMethodName         = "/fuwa.EventService/Ack"
+	EventService_Nack_FullMethodName        = "/fuwa.EventService/Nack"
+	EventService_GetSnapshot_FullMethodName = "/fuwa.EventService/GetSnapshot"
 )
 
 // EventServiceClient is the client API for EventService service.
@@ -32,10 +36,23 @@ const (
 type EventServiceClient interface {
 	// Subscribe to events with optional filtering
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
-	// Publish events to the system
+	// Publish a custom event to a scope. The event type must be namespaced
+	// ("<namespace>.<name>") outside the namespaces the services use, such as
+	// channel, message and config, and the caller needs SEND_MESSAGES in the
+	// scope
 	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
 	// Get event history for a specific scope
 	GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (*GetEventsResponse, error)
+	// Consume a scope as a member of a durable consumer group. Members of a
+	// group share its events, and each event is redelivered until acked
+	Consume(ctx context.Context, in *ConsumeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConsumedEvent], error)
+	// Acknowledge events delivered by Consume
+	Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error)
+	// Hand events delivered by Consume back for redelivery
+	Nack(ctx context.Context, in *NackRequest, opts ...grpc.CallOption) (*NackResponse, error)
+	// Get the latest state snapshot of a server or channel scope. Clients load
+	// it and Subscribe from its sequence + 1 instead of replaying from 1
+	GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*ScopeSnapshot, error)
 }
 
 type eventServiceClient struct {
@@ -85,6 +102,55 @@ func (c *eventServiceClient) GetEvents(ctx context.Context, in *GetEventsRequest
 	return out, nil
 }
 
+func (c *eventServiceClient) Consume(ctx context.Context, in *ConsumeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConsumedEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EventService_ServiceDesc.Streams[1], EventService_Consume_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ConsumeRequest, ConsumedEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventService_ConsumeClient = grpc.ServerStreamingClient[ConsumedEvent]
+
+func (c *eventServiceClient) Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AckResponse)
+	err := c.cc.Invoke(ctx, EventService_Ack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) Nack(ctx context.Context, in *NackRequest, opts ...grpc.CallOption) (*NackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NackResponse)
+	err := c.cc.Invoke(ctx, EventService_Nack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*ScopeSnapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScopeSnapshot)
+	err := c.cc.Invoke(ctx, EventService_GetSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EventServiceServer is the server API for EventService service.
 // All implementations must embed UnimplementedEventServiceServer
 // for forward compatibility.
@@ -93,10 +159,23 @@ func (c *eventServiceClient) GetEvents(ctx context.Context, in *GetEventsRequest
 type EventServiceServer interface {
 	// Subscribe to events with optional filtering
 	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error
-	// Publish events to the system
+	// Publish a custom event to a scope. The event type must be namespaced
+	// ("<namespace>.<name>") outside the namespaces the services use, such as
+	// channel, message and config, and the caller needs SEND_MESSAGES in the
+	// scope
 	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
 	// Get event history for a specific scope
 	GetEvents(context.Context, *GetEventsRequest) (*GetEventsResponse, error)
+	// Consume a scope as a member of a durable consumer group. Members of a
+	// group share its events, and each event is redelivered until acked
+	Consume(*ConsumeRequest, grpc.ServerStreamingServer[ConsumedEvent]) error
+	// Acknowledge events delivered by Consume
+	Ack(context.Context, *AckRequest) (*AckResponse, error)
+	// Hand events delivered by Consume back for redelivery
+	Nack(context.Context, *NackRequest) (*NackResponse, error)
+	// Get the latest state snapshot of a server or channel scope. Clients load
+	// it and Subscribe from its sequence + 1 instead of replaying from 1
+	GetSnapshot(context.Context, *GetSnapshotRequest) (*ScopeSnapshot, error)
 	mustEmbedUnimplementedEventServiceServer()
 }
 
@@ -116,6 +195,18 @@ func (UnimplementedEventServiceServer) Publish(context.Context, *PublishRequest)
 func (UnimplementedEventServiceServer) GetEvents(context.Context, *GetEventsRequest) (*GetEventsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEvents not implemented")
 }
+func (UnimplementedEventServiceServer) Consume(*ConsumeRequest, grpc.ServerStreamingServer[ConsumedEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Consume not implemented")
+}
+func (UnimplementedEventServiceServer) Ack(context.Context, *AckRequest) (*AckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ack not implemented")
+}
+func (UnimplementedEventServiceServer) Nack(context.Context, *NackRequest) (*NackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Nack not implemented")
+}
+func (UnimplementedEventServiceServer) GetSnapshot(context.Context, *GetSnapshotRequest) (*ScopeSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshot not implemented")
+}
 func (UnimplementedEventServiceServer) mustEmbedUnimplementedEventServiceServer() {}
 func (UnimplementedEventServiceServer) testEmbeddedByValue()                      {}
 
@@ -184,6 +275,71 @@ func _EventService_GetEvents_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EventService_Consume_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConsumeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventServiceServer).Consume(m, &grpc.GenericServerStream[ConsumeRequest, ConsumedEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventService_ConsumeServer = grpc.ServerStreamingServer[ConsumedEvent]
+
+func _EventService_Ack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).Ack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_Ack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).Ack(ctx, req.(*AckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_Nack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).Nack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_Nack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).Nack(ctx, req.(*NackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetSnapshot(ctx, req.(*GetSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -199,6 +355,18 @@ var EventService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetEvents",
 			Handler:    _EventService_GetEvents_Handler,
 		},
+		{
+			MethodName: "Ack",
+			Handler:    _EventService_Ack_Handler,
+		},
+		{
+			MethodName: "Nack",
+			Handler:    _EventService_Nack_Handler,
+		},
+		{
+			MethodName: "GetSnapshot",
+			Handler:    _EventService_GetSnapshot_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -206,6 +374,11 @@ var EventService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _EventService_Subscribe_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Consume",
+			Handler:       _EventService_Consume_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "event_service.proto",
 }
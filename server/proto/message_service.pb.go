@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -230,12 +231,19 @@ func (x *GetMessageResponse) GetMessage() *Message {
 	return nil
 }
 
+// Messages are returned newest first. At most one of before, after, around
+// and their message ID forms may be set; with none the latest page is
+// returned.
 type GetMessagesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
 	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	BeforeId      string                 `protobuf:"bytes,3,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"` // For pagination
-	AfterId       string                 `protobuf:"bytes,4,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	BeforeId      string                 `protobuf:"bytes,3,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"` // Messages older than this message
+	AfterId       string                 `protobuf:"bytes,4,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`    // Messages newer than this message
+	Before        string                 `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`                     // Messages older than a cursor from GetMessagesResponse
+	After         string                 `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`                       // Messages newer than a cursor
+	Around        string                 `protobuf:"bytes,7,opt,name=around,proto3" json:"around,omitempty"`                     // Messages on both sides of a cursor, including its message
+	AroundId      string                 `protobuf:"bytes,8,opt,name=around_id,json=aroundId,proto3" json:"around_id,omitempty"` // Messages on both sides of this message, including it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -298,10 +306,42 @@ func (x *GetMessagesRequest) GetAfterId() string {
 	return ""
 }
 
+func (x *GetMessagesRequest) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *GetMessagesRequest) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *GetMessagesRequest) GetAround() string {
+	if x != nil {
+		return x.Around
+	}
+	return ""
+}
+
+func (x *GetMessagesRequest) GetAroundId() string {
+	if x != nil {
+		return x.AroundId
+	}
+	return ""
+}
+
 type GetMessagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
-	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`                     // More messages in the direction paged, older unless after was set
+	BeforeCursor  string                 `protobuf:"bytes,3,opt,name=before_cursor,json=beforeCursor,proto3" json:"before_cursor,omitempty"`       // Pass as before for the next older page
+	AfterCursor   string                 `protobuf:"bytes,4,opt,name=after_cursor,json=afterCursor,proto3" json:"after_cursor,omitempty"`          // Pass as after for the next newer page
+	HasMoreBefore bool                   `protobuf:"varint,5,opt,name=has_more_before,json=hasMoreBefore,proto3" json:"has_more_before,omitempty"` // Older messages exist
+	HasMoreAfter  bool                   `protobuf:"varint,6,opt,name=has_more_after,json=hasMoreAfter,proto3" json:"has_more_after,omitempty"`    // Newer messages exist
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -350,12 +390,46 @@ func (x *GetMessagesResponse) GetHasMore() bool {
 	return false
 }
 
+func (x *GetMessagesResponse) GetBeforeCursor() string {
+	if x != nil {
+		return x.BeforeCursor
+	}
+	return ""
+}
+
+func (x *GetMessagesResponse) GetAfterCursor() string {
+	if x != nil {
+		return x.AfterCursor
+	}
+	return ""
+}
+
+func (x *GetMessagesResponse) GetHasMoreBefore() bool {
+	if x != nil {
+		return x.HasMoreBefore
+	}
+	return false
+}
+
+func (x *GetMessagesResponse) GetHasMoreAfter() bool {
+	if x != nil {
+		return x.HasMoreAfter
+	}
+	return false
+}
+
+// Updates the fields of a message named in update_mask: "content", "embeds"
+// or "attachments". Embeds and attachments replace the message's whole list;
+// attachments with an attachment_id keep that attachment of the message,
+// those without one are added. Without a mask, every field that is set is
+// updated.
 type UpdateMessageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
 	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	Embeds        []*Embed               `protobuf:"bytes,3,rep,name=embeds,proto3" json:"embeds,omitempty"`
 	UpdateMask    []string               `protobuf:"bytes,4,rep,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Attachments   []*Attachment          `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -418,6 +492,13 @@ func (x *UpdateMessageRequest) GetUpdateMask() []string {
 	return nil
 }
 
+func (x *UpdateMessageRequest) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
 type UpdateMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -550,55 +631,869 @@ func (x *DeleteMessageResponse) GetSuccess() bool {
 	return false
 }
 
-var File_message_service_proto protoreflect.FileDescriptor
+// Searches the messages of one server, or one of its channels, that the
+// caller can view. Results are ranked by relevance.
+type SearchMessagesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Query          string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                       // Words that must all appear in a message
+	ServerId       string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // Required unless channel_id is set
+	ChannelId      string                 `protobuf:"bytes,3,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	AuthorId       string                 `protobuf:"bytes,4,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	CreatedAfter   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	HasAttachment  bool                   `protobuf:"varint,7,opt,name=has_attachment,json=hasAttachment,proto3" json:"has_attachment,omitempty"` // Only messages with attachments
+	HasEmbed       bool                   `protobuf:"varint,8,opt,name=has_embed,json=hasEmbed,proto3" json:"has_embed,omitempty"`                // Only messages with embeds
+	Limit          int32                  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor         string                 `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`                                       // next_cursor of the previous page
+	HighlightStart string                 `protobuf:"bytes,11,opt,name=highlight_start,json=highlightStart,proto3" json:"highlight_start,omitempty"` // Marks the start of matches in snippets (default "**")
+	HighlightEnd   string                 `protobuf:"bytes,12,opt,name=highlight_end,json=highlightEnd,proto3" json:"highlight_end,omitempty"`       // Marks the end of matches in snippets (default "**")
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchMessagesRequest) Reset() {
+	*x = SearchMessagesRequest{}
+	mi := &file_message_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-const file_message_service_proto_rawDesc = "" +
-	"\n" +
-	"\x15message_service.proto\x12\x04fuwa\x1a\vtypes.proto\"\xc6\x01\n" +
-	"\x12SendMessageRequest\x12\x1d\n" +
-	"\n" +
-	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\x122\n" +
-	"\vattachments\x18\x03 \x03(\v2\x10.fuwa.AttachmentR\vattachments\x12#\n" +
-	"\x06embeds\x18\x04 \x03(\v2\v.fuwa.EmbedR\x06embeds\x12\x1e\n" +
-	"\vreply_to_id\x18\x05 \x01(\tR\treplyToId\">\n" +
-	"\x13SendMessageResponse\x12'\n" +
-	"\amessage\x18\x01 \x01(\v2\r.fuwa.MessageR\amessage\"2\n" +
-	"\x11GetMessageRequest\x12\x1d\n" +
-	"\n" +
-	"message_id\x18\x01 \x01(\tR\tmessageId\"=\n" +
-	"\x12GetMessageResponse\x12'\n" +
-	"\amessage\x18\x01 \x01(\v2\r.fuwa.MessageR\amessage\"\x81\x01\n" +
-	"\x12GetMessagesRequest\x12\x1d\n" +
-	"\n" +
-	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1b\n" +
-	"\tbefore_id\x18\x03 \x01(\tR\bbeforeId\x12\x19\n" +
-	"\bafter_id\x18\x04 \x01(\tR\aafterId\"[\n" +
-	"\x13GetMessagesResponse\x12)\n" +
-	"\bmessages\x18\x01 \x03(\v2\r.fuwa.MessageR\bmessages\x12\x19\n" +
-	"\bhas_more\x18\x02 \x01(\bR\ahasMore\"\x95\x01\n" +
-	"\x14UpdateMessageRequest\x12\x1d\n" +
-	"\n" +
-	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\x12#\n" +
-	"\x06embeds\x18\x03 \x03(\v2\v.fuwa.EmbedR\x06embeds\x12\x1f\n" +
-	"\vupdate_mask\x18\x04 \x03(\tR\n" +
-	"updateMask\"@\n" +
-	"\x15UpdateMessageResponse\x12'\n" +
-	"\amessage\x18\x01 \x01(\v2\r.fuwa.MessageR\amessage\"5\n" +
-	"\x14DeleteMessageRequest\x12\x1d\n" +
-	"\n" +
-	"message_id\x18\x01 \x01(\tR\tmessageId\"1\n" +
-	"\x15DeleteMessageResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xed\x02\n" +
+func (x *SearchMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesRequest) ProtoMessage() {}
+
+func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SearchMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchMessagesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *SearchMessagesRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *SearchMessagesRequest) GetHasAttachment() bool {
+	if x != nil {
+		return x.HasAttachment
+	}
+	return false
+}
+
+func (x *SearchMessagesRequest) GetHasEmbed() bool {
+	if x != nil {
+		return x.HasEmbed
+	}
+	return false
+}
+
+func (x *SearchMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetHighlightStart() string {
+	if x != nil {
+		return x.HighlightStart
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetHighlightEnd() string {
+	if x != nil {
+		return x.HighlightEnd
+	}
+	return ""
+}
+
+type SearchMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*MessageSearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesResponse) Reset() {
+	*x = SearchMessagesResponse{}
+	mi := &file_message_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesResponse) ProtoMessage() {}
+
+func (x *SearchMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesResponse.ProtoReflect.Descriptor instead.
+func (*SearchMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchMessagesResponse) GetResults() []*MessageSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchMessagesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *SearchMessagesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type MessageSearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Snippet       string                 `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"` // Excerpt of the content with matches highlighted
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`   // Relevance, higher is better
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageSearchResult) Reset() {
+	*x = MessageSearchResult{}
+	mi := &file_message_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageSearchResult) ProtoMessage() {}
+
+func (x *MessageSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageSearchResult.ProtoReflect.Descriptor instead.
+func (*MessageSearchResult) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MessageSearchResult) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *MessageSearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *MessageSearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type AddReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,2,opt,name=emoji,proto3" json:"emoji,omitempty"` // A Unicode emoji or a custom emoji name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReactionRequest) Reset() {
+	*x = AddReactionRequest{}
+	mi := &file_message_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReactionRequest) ProtoMessage() {}
+
+func (x *AddReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReactionRequest.ProtoReflect.Descriptor instead.
+func (*AddReactionRequest) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AddReactionRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *AddReactionRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type AddReactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reaction      *Reaction              `protobuf:"bytes,1,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReactionResponse) Reset() {
+	*x = AddReactionResponse{}
+	mi := &file_message_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReactionResponse) ProtoMessage() {}
+
+func (x *AddReactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReactionResponse.ProtoReflect.Descriptor instead.
+func (*AddReactionResponse) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AddReactionResponse) GetReaction() *Reaction {
+	if x != nil {
+		return x.Reaction
+	}
+	return nil
+}
+
+type RemoveReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,2,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Another user's reaction to remove, the caller's if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveReactionRequest) Reset() {
+	*x = RemoveReactionRequest{}
+	mi := &file_message_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionRequest) ProtoMessage() {}
+
+func (x *RemoveReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionRequest.ProtoReflect.Descriptor instead.
+func (*RemoveReactionRequest) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RemoveReactionRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *RemoveReactionRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *RemoveReactionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveReactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reaction      *Reaction              `protobuf:"bytes,1,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveReactionResponse) Reset() {
+	*x = RemoveReactionResponse{}
+	mi := &file_message_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionResponse) ProtoMessage() {}
+
+func (x *RemoveReactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionResponse.ProtoReflect.Descriptor instead.
+func (*RemoveReactionResponse) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RemoveReactionResponse) GetReaction() *Reaction {
+	if x != nil {
+		return x.Reaction
+	}
+	return nil
+}
+
+// Lists who reacted to a message, oldest reaction first.
+type ListReactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,2,opt,name=emoji,proto3" json:"emoji,omitempty"` // Only reactions with this emoji
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReactionsRequest) Reset() {
+	*x = ListReactionsRequest{}
+	mi := &file_message_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReactionsRequest) ProtoMessage() {}
+
+func (x *ListReactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListReactionsRequest) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListReactionsRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ListReactionsRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ListReactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListReactionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListReactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reactions     []*UserReaction        `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReactionsResponse) Reset() {
+	*x = ListReactionsResponse{}
+	mi := &file_message_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReactionsResponse) ProtoMessage() {}
+
+func (x *ListReactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListReactionsResponse) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListReactionsResponse) GetReactions() []*UserReaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *ListReactionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListReactionsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type UserReaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emoji         string                 `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserReaction) Reset() {
+	*x = UserReaction{}
+	mi := &file_message_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserReaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserReaction) ProtoMessage() {}
+
+func (x *UserReaction) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserReaction.ProtoReflect.Descriptor instead.
+func (*UserReaction) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UserReaction) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *UserReaction) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserReaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Returns a message, deleted or not, with the versions its edits replaced.
+// Requires MANAGE_MESSAGES in the message's channel.
+type GetMessageHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageHistoryRequest) Reset() {
+	*x = GetMessageHistoryRequest{}
+	mi := &file_message_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageHistoryRequest) ProtoMessage() {}
+
+func (x *GetMessageHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetMessageHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetMessageHistoryRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type GetMessageHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Revisions     []*MessageRevision     `protobuf:"bytes,2,rep,name=revisions,proto3" json:"revisions,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageHistoryResponse) Reset() {
+	*x = GetMessageHistoryResponse{}
+	mi := &file_message_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageHistoryResponse) ProtoMessage() {}
+
+func (x *GetMessageHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetMessageHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_message_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetMessageHistoryResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *GetMessageHistoryResponse) GetRevisions() []*MessageRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+var File_message_service_proto protoreflect.FileDescriptor
+
+const file_message_service_proto_rawDesc = "" +
+	"\n" +
+	"\x15message_service.proto\x12\x04fuwa\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\vtypes.proto\"\xc6\x01\n" +
+	"\x12SendMessageRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x122\n" +
+	"\vattachments\x18\x03 \x03(\v2\x10.fuwa.AttachmentR\vattachments\x12#\n" +
+	"\x06embeds\x18\x04 \x03(\v2\v.fuwa.EmbedR\x06embeds\x12\x1e\n" +
+	"\vreply_to_id\x18\x05 \x01(\tR\treplyToId\">\n" +
+	"\x13SendMessageResponse\x12'\n" +
+	"\amessage\x18\x01 \x01(\v2\r.fuwa.MessageR\amessage\"2\n" +
+	"\x11GetMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\"=\n" +
+	"\x12GetMessageResponse\x12'\n" +
+	"\amessage\x18\x01 \x01(\v2\r.fuwa.MessageR\amessage\"\xe4\x01\n" +
+	"\x12GetMessagesRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1b\n" +
+	"\tbefore_id\x18\x03 \x01(\tR\bbeforeId\x12\x19\n" +
+	"\bafter_id\x18\x04 \x01(\tR\aafterId\x12\x16\n" +
+	"\x06before\x18\x05 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x06 \x01(\tR\x05after\x12\x16\n" +
+	"\x06around\x18\a \x01(\tR\x06around\x12\x1b\n" +
+	"\taround_id\x18\b \x01(\tR\baroundId\"\xf1\x01\n" +
+	"\x13GetMessagesResponse\x12)\n" +
+	"\bmessages\x18\x01 \x03(\v2\r.fuwa.MessageR\bmessages\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\x12#\n" +
+	"\rbefore_cursor\x18\x03 \x01(\tR\fbeforeCursor\x12!\n" +
+	"\fafter_cursor\x18\x04 \x01(\tR\vafterCursor\x12&\n" +
+	"\x0fhas_more_before\x18\x05 \x01(\bR\rhasMoreBefore\x12$\n" +
+	"\x0ehas_more_after\x18\x06 \x01(\bR\fhasMoreAfter\"\xc9\x01\n" +
+	"\x14UpdateMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12#\n" +
+	"\x06embeds\x18\x03 \x03(\v2\v.fuwa.EmbedR\x06embeds\x12\x1f\n" +
+	"\vupdate_mask\x18\x04 \x03(\tR\n" +
+	"updateMask\x122\n" +
+	"\vattachments\x18\x05 \x03(\v2\x10.fuwa.AttachmentR\vattachments\"@\n" +
+	"\x15UpdateMessageResponse\x12'\n" +
+	"\amessage\x18\x01 \x01(\v2\r.fuwa.MessageR\amessage\"5\n" +
+	"\x14DeleteMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\"1\n" +
+	"\x15DeleteMessageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xca\x03\n" +
+	"\x15SearchMessagesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x03 \x01(\tR\tchannelId\x12\x1b\n" +
+	"\tauthor_id\x18\x04 \x01(\tR\bauthorId\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12%\n" +
+	"\x0ehas_attachment\x18\a \x01(\bR\rhasAttachment\x12\x1b\n" +
+	"\thas_embed\x18\b \x01(\bR\bhasEmbed\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\n" +
+	" \x01(\tR\x06cursor\x12'\n" +
+	"\x0fhighlight_start\x18\v \x01(\tR\x0ehighlightStart\x12#\n" +
+	"\rhighlight_end\x18\f \x01(\tR\fhighlightEnd\"\x89\x01\n" +
+	"\x16SearchMessagesResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.fuwa.MessageSearchResultR\aresults\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"n\n" +
+	"\x13MessageSearchResult\x12'\n" +
+	"\amessage\x18\x01 \x01(\v2\r.fuwa.MessageR\amessage\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\"I\n" +
+	"\x12AddReactionRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x02 \x01(\tR\x05emoji\"A\n" +
+	"\x13AddReactionResponse\x12*\n" +
+	"\breaction\x18\x01 \x01(\v2\x0e.fuwa.ReactionR\breaction\"e\n" +
+	"\x15RemoveReactionRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x02 \x01(\tR\x05emoji\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"D\n" +
+	"\x16RemoveReactionResponse\x12*\n" +
+	"\breaction\x18\x01 \x01(\v2\x0e.fuwa.ReactionR\breaction\"y\n" +
+	"\x14ListReactionsRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x02 \x01(\tR\x05emoji\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\x85\x01\n" +
+	"\x15ListReactionsResponse\x120\n" +
+	"\treactions\x18\x01 \x03(\v2\x12.fuwa.UserReactionR\treactions\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"x\n" +
+	"\fUserReaction\x12\x14\n" +
+	"\x05emoji\x18\x01 \x01(\tR\x05emoji\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"9\n" +
+	"\x18GetMessageHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\"y\n" +
+	"\x19GetMessageHistoryResponse\x12'\n" +
+	"\amessage\x18\x01 \x01(\v2\r.fuwa.MessageR\amessage\x123\n" +
+	"\trevisions\x18\x02 \x03(\v2\x15.fuwa.MessageRevisionR\trevisions2\xeb\x05\n" +
 	"\x0eMessageService\x12B\n" +
 	"\vSendMessage\x12\x18.fuwa.SendMessageRequest\x1a\x19.fuwa.SendMessageResponse\x12?\n" +
 	"\n" +
 	"GetMessage\x12\x17.fuwa.GetMessageRequest\x1a\x18.fuwa.GetMessageResponse\x12B\n" +
 	"\vGetMessages\x12\x18.fuwa.GetMessagesRequest\x1a\x19.fuwa.GetMessagesResponse\x12H\n" +
 	"\rUpdateMessage\x12\x1a.fuwa.UpdateMessageRequest\x1a\x1b.fuwa.UpdateMessageResponse\x12H\n" +
-	"\rDeleteMessage\x12\x1a.fuwa.DeleteMessageRequest\x1a\x1b.fuwa.DeleteMessageResponseB\"Z github.com/waifu-devs/fuwa/protob\x06proto3"
+	"\rDeleteMessage\x12\x1a.fuwa.DeleteMessageRequest\x1a\x1b.fuwa.DeleteMessageResponse\x12K\n" +
+	"\x0eSearchMessages\x12\x1b.fuwa.SearchMessagesRequest\x1a\x1c.fuwa.SearchMessagesResponse\x12B\n" +
+	"\vAddReaction\x12\x18.fuwa.AddReactionRequest\x1a\x19.fuwa.AddReactionResponse\x12K\n" +
+	"\x0eRemoveReaction\x12\x1b.fuwa.RemoveReactionRequest\x1a\x1c.fuwa.RemoveReactionResponse\x12H\n" +
+	"\rListReactions\x12\x1a.fuwa.ListReactionsRequest\x1a\x1b.fuwa.ListReactionsResponse\x12T\n" +
+	"\x11GetMessageHistory\x12\x1e.fuwa.GetMessageHistoryRequest\x1a\x1f.fuwa.GetMessageHistoryResponseB\"Z github.com/waifu-devs/fuwa/protob\x06proto3"
 
 var (
 	file_message_service_proto_rawDescOnce sync.Once
@@ -612,45 +1507,81 @@ func file_message_service_proto_rawDescGZIP() []byte {
 	return file_message_service_proto_rawDescData
 }
 
-var file_message_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_message_service_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_message_service_proto_goTypes = []any{
-	(*SendMessageRequest)(nil),    // 0: fuwa.SendMessageRequest
-	(*SendMessageResponse)(nil),   // 1: fuwa.SendMessageResponse
-	(*GetMessageRequest)(nil),     // 2: fuwa.GetMessageRequest
-	(*GetMessageResponse)(nil),    // 3: fuwa.GetMessageResponse
-	(*GetMessagesRequest)(nil),    // 4: fuwa.GetMessagesRequest
-	(*GetMessagesResponse)(nil),   // 5: fuwa.GetMessagesResponse
-	(*UpdateMessageRequest)(nil),  // 6: fuwa.UpdateMessageRequest
-	(*UpdateMessageResponse)(nil), // 7: fuwa.UpdateMessageResponse
-	(*DeleteMessageRequest)(nil),  // 8: fuwa.DeleteMessageRequest
-	(*DeleteMessageResponse)(nil), // 9: fuwa.DeleteMessageResponse
-	(*Attachment)(nil),            // 10: fuwa.Attachment
-	(*Embed)(nil),                 // 11: fuwa.Embed
-	(*Message)(nil),               // 12: fuwa.Message
+	(*SendMessageRequest)(nil),        // 0: fuwa.SendMessageRequest
+	(*SendMessageResponse)(nil),       // 1: fuwa.SendMessageResponse
+	(*GetMessageRequest)(nil),         // 2: fuwa.GetMessageRequest
+	(*GetMessageResponse)(nil),        // 3: fuwa.GetMessageResponse
+	(*GetMessagesRequest)(nil),        // 4: fuwa.GetMessagesRequest
+	(*GetMessagesResponse)(nil),       // 5: fuwa.GetMessagesResponse
+	(*UpdateMessageRequest)(nil),      // 6: fuwa.UpdateMessageRequest
+	(*UpdateMessageResponse)(nil),     // 7: fuwa.UpdateMessageResponse
+	(*DeleteMessageRequest)(nil),      // 8: fuwa.DeleteMessageRequest
+	(*DeleteMessageResponse)(nil),     // 9: fuwa.DeleteMessageResponse
+	(*SearchMessagesRequest)(nil),     // 10: fuwa.SearchMessagesRequest
+	(*SearchMessagesResponse)(nil),    // 11: fuwa.SearchMessagesResponse
+	(*MessageSearchResult)(nil),       // 12: fuwa.MessageSearchResult
+	(*AddReactionRequest)(nil),        // 13: fuwa.AddReactionRequest
+	(*AddReactionResponse)(nil),       // 14: fuwa.AddReactionResponse
+	(*RemoveReactionRequest)(nil),     // 15: fuwa.RemoveReactionRequest
+	(*RemoveReactionResponse)(nil),    // 16: fuwa.RemoveReactionResponse
+	(*ListReactionsRequest)(nil),      // 17: fuwa.ListReactionsRequest
+	(*ListReactionsResponse)(nil),     // 18: fuwa.ListReactionsResponse
+	(*UserReaction)(nil),              // 19: fuwa.UserReaction
+	(*GetMessageHistoryRequest)(nil),  // 20: fuwa.GetMessageHistoryRequest
+	(*GetMessageHistoryResponse)(nil), // 21: fuwa.GetMessageHistoryResponse
+	(*Attachment)(nil),                // 22: fuwa.Attachment
+	(*Embed)(nil),                     // 23: fuwa.Embed
+	(*Message)(nil),                   // 24: fuwa.Message
+	(*timestamppb.Timestamp)(nil),     // 25: google.protobuf.Timestamp
+	(*Reaction)(nil),                  // 26: fuwa.Reaction
+	(*MessageRevision)(nil),           // 27: fuwa.MessageRevision
 }
 var file_message_service_proto_depIdxs = []int32{
-	10, // 0: fuwa.SendMessageRequest.attachments:type_name -> fuwa.Attachment
-	11, // 1: fuwa.SendMessageRequest.embeds:type_name -> fuwa.Embed
-	12, // 2: fuwa.SendMessageResponse.message:type_name -> fuwa.Message
-	12, // 3: fuwa.GetMessageResponse.message:type_name -> fuwa.Message
-	12, // 4: fuwa.GetMessagesResponse.messages:type_name -> fuwa.Message
-	11, // 5: fuwa.UpdateMessageRequest.embeds:type_name -> fuwa.Embed
-	12, // 6: fuwa.UpdateMessageResponse.message:type_name -> fuwa.Message
-	0,  // 7: fuwa.MessageService.SendMessage:input_type -> fuwa.SendMessageRequest
-	2,  // 8: fuwa.MessageService.GetMessage:input_type -> fuwa.GetMessageRequest
-	4,  // 9: fuwa.MessageService.GetMessages:input_type -> fuwa.GetMessagesRequest
-	6,  // 10: fuwa.MessageService.UpdateMessage:input_type -> fuwa.UpdateMessageRequest
-	8,  // 11: fuwa.MessageService.DeleteMessage:input_type -> fuwa.DeleteMessageRequest
-	1,  // 12: fuwa.MessageService.SendMessage:output_type -> fuwa.SendMessageResponse
-	3,  // 13: fuwa.MessageService.GetMessage:output_type -> fuwa.GetMessageResponse
-	5,  // 14: fuwa.MessageService.GetMessages:output_type -> fuwa.GetMessagesResponse
-	7,  // 15: fuwa.MessageService.UpdateMessage:output_type -> fuwa.UpdateMessageResponse
-	9,  // 16: fuwa.MessageService.DeleteMessage:output_type -> fuwa.DeleteMessageResponse
-	12, // [12:17] is the sub-list for method output_type
-	7,  // [7:12] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	22, // 0: fuwa.SendMessageRequest.attachments:type_name -> fuwa.Attachment
+	23, // 1: fuwa.SendMessageRequest.embeds:type_name -> fuwa.Embed
+	24, // 2: fuwa.SendMessageResponse.message:type_name -> fuwa.Message
+	24, // 3: fuwa.GetMessageResponse.message:type_name -> fuwa.Message
+	24, // 4: fuwa.GetMessagesResponse.messages:type_name -> fuwa.Message
+	23, // 5: fuwa.UpdateMessageRequest.embeds:type_name -> fuwa.Embed
+	22, // 6: fuwa.UpdateMessageRequest.attachments:type_name -> fuwa.Attachment
+	24, // 7: fuwa.UpdateMessageResponse.message:type_name -> fuwa.Message
+	25, // 8: fuwa.SearchMessagesRequest.created_after:type_name -> google.protobuf.Timestamp
+	25, // 9: fuwa.SearchMessagesRequest.created_before:type_name -> google.protobuf.Timestamp
+	12, // 10: fuwa.SearchMessagesResponse.results:type_name -> fuwa.MessageSearchResult
+	24, // 11: fuwa.MessageSearchResult.message:type_name -> fuwa.Message
+	26, // 12: fuwa.AddReactionResponse.reaction:type_name -> fuwa.Reaction
+	26, // 13: fuwa.RemoveReactionResponse.reaction:type_name -> fuwa.Reaction
+	19, // 14: fuwa.ListReactionsResponse.reactions:type_name -> fuwa.UserReaction
+	25, // 15: fuwa.UserReaction.created_at:type_name -> google.protobuf.Timestamp
+	24, // 16: fuwa.GetMessageHistoryResponse.message:type_name -> fuwa.Message
+	27, // 17: fuwa.GetMessageHistoryResponse.revisions:type_name -> fuwa.MessageRevision
+	0,  // 18: fuwa.MessageService.SendMessage:input_type -> fuwa.SendMessageRequest
+	2,  // 19: fuwa.MessageService.GetMessage:input_type -> fuwa.GetMessageRequest
+	4,  // 20: fuwa.MessageService.GetMessages:input_type -> fuwa.GetMessagesRequest
+	6,  // 21: fuwa.MessageService.UpdateMessage:input_type -> fuwa.UpdateMessageRequest
+	8,  // 22: fuwa.MessageService.DeleteMessage:input_type -> fuwa.DeleteMessageRequest
+	10, // 23: fuwa.MessageService.SearchMessages:input_type -> fuwa.SearchMessagesRequest
+	13, // 24: fuwa.MessageService.AddReaction:input_type -> fuwa.AddReactionRequest
+	15, // 25: fuwa.MessageService.RemoveReaction:input_type -> fuwa.RemoveReactionRequest
+	17, // 26: fuwa.MessageService.ListReactions:input_type -> fuwa.ListReactionsRequest
+	20, // 27: fuwa.MessageService.GetMessageHistory:input_type -> fuwa.GetMessageHistoryRequest
+	1,  // 28: fuwa.MessageService.SendMessage:output_type -> fuwa.SendMessageResponse
+	3,  // 29: fuwa.MessageService.GetMessage:output_type -> fuwa.GetMessageResponse
+	5,  // 30: fuwa.MessageService.GetMessages:output_type -> fuwa.GetMessagesResponse
+	7,  // 31: fuwa.MessageService.UpdateMessage:output_type -> fuwa.UpdateMessageResponse
+	9,  // 32: fuwa.MessageService.DeleteMessage:output_type -> fuwa.DeleteMessageResponse
+	11, // 33: fuwa.MessageService.SearchMessages:output_type -> fuwa.SearchMessagesResponse
+	14, // 34: fuwa.MessageService.AddReaction:output_type -> fuwa.AddReactionResponse
+	16, // 35: fuwa.MessageService.RemoveReaction:output_type -> fuwa.RemoveReactionResponse
+	18, // 36: fuwa.MessageService.ListReactions:output_type -> fuwa.ListReactionsResponse
+	21, // 37: fuwa.MessageService.GetMessageHistory:output_type -> fuwa.GetMessageHistoryResponse
+	28, // [28:38] is the sub-list for method output_type
+	18, // [18:28] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_message_service_proto_init() }
@@ -665,7 +1596,7 @@ func file_message_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_service_proto_rawDesc), len(file_message_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -79,6 +79,120 @@ func (ChannelType) EnumDescriptor() ([]byte, []int) {
 	return file_types_proto_rawDescGZIP(), []int{0}
 }
 
+// Permission bit flags, combined into the uint64 masks used by roles and overwrites
+type Permission int32
+
+const (
+	Permission_PERMISSION_UNSPECIFIED     Permission = 0
+	Permission_PERMISSION_VIEW_CHANNEL    Permission = 1
+	Permission_PERMISSION_SEND_MESSAGES   Permission = 2
+	Permission_PERMISSION_MANAGE_MESSAGES Permission = 4
+	Permission_PERMISSION_MANAGE_CHANNELS Permission = 8
+	Permission_PERMISSION_MANAGE_CONFIG   Permission = 16
+	Permission_PERMISSION_MANAGE_ROLES    Permission = 32
+	Permission_PERMISSION_ADMINISTRATOR   Permission = 64 // Grants every permission and bypasses overwrites
+)
+
+// Enum value maps for Permission.
+var (
+	Permission_name = map[int32]string{
+		0:  "PERMISSION_UNSPECIFIED",
+		1:  "PERMISSION_VIEW_CHANNEL",
+		2:  "PERMISSION_SEND_MESSAGES",
+		4:  "PERMISSION_MANAGE_MESSAGES",
+		8:  "PERMISSION_MANAGE_CHANNELS",
+		16: "PERMISSION_MANAGE_CONFIG",
+		32: "PERMISSION_MANAGE_ROLES",
+		64: "PERMISSION_ADMINISTRATOR",
+	}
+	Permission_value = map[string]int32{
+		"PERMISSION_UNSPECIFIED":     0,
+		"PERMISSION_VIEW_CHANNEL":    1,
+		"PERMISSION_SEND_MESSAGES":   2,
+		"PERMISSION_MANAGE_MESSAGES": 4,
+		"PERMISSION_MANAGE_CHANNELS": 8,
+		"PERMISSION_MANAGE_CONFIG":   16,
+		"PERMISSION_MANAGE_ROLES":    32,
+		"PERMISSION_ADMINISTRATOR":   64,
+	}
+)
+
+func (x Permission) Enum() *Permission {
+	p := new(Permission)
+	*p = x
+	return p
+}
+
+func (x Permission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Permission) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_proto_enumTypes[1].Descriptor()
+}
+
+func (Permission) Type() protoreflect.EnumType {
+	return &file_types_proto_enumTypes[1]
+}
+
+func (x Permission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Permission.Descriptor instead.
+func (Permission) EnumDescriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{1}
+}
+
+type OverwriteTargetType int32
+
+const (
+	OverwriteTargetType_OVERWRITE_TARGET_TYPE_UNSPECIFIED OverwriteTargetType = 0
+	OverwriteTargetType_OVERWRITE_TARGET_TYPE_ROLE        OverwriteTargetType = 1
+	OverwriteTargetType_OVERWRITE_TARGET_TYPE_USER        OverwriteTargetType = 2
+)
+
+// Enum value maps for OverwriteTargetType.
+var (
+	OverwriteTargetType_name = map[int32]string{
+		0: "OVERWRITE_TARGET_TYPE_UNSPECIFIED",
+		1: "OVERWRITE_TARGET_TYPE_ROLE",
+		2: "OVERWRITE_TARGET_TYPE_USER",
+	}
+	OverwriteTargetType_value = map[string]int32{
+		"OVERWRITE_TARGET_TYPE_UNSPECIFIED": 0,
+		"OVERWRITE_TARGET_TYPE_ROLE":        1,
+		"OVERWRITE_TARGET_TYPE_USER":        2,
+	}
+)
+
+func (x OverwriteTargetType) Enum() *OverwriteTargetType {
+	p := new(OverwriteTargetType)
+	*p = x
+	return p
+}
+
+func (x OverwriteTargetType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OverwriteTargetType) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_proto_enumTypes[2].Descriptor()
+}
+
+func (OverwriteTargetType) Type() protoreflect.EnumType {
+	return &file_types_proto_enumTypes[2]
+}
+
+func (x OverwriteTargetType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OverwriteTargetType.Descriptor instead.
+func (OverwriteTargetType) EnumDescriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{2}
+}
+
 type ConfigValueType int32
 
 const (
@@ -124,11 +238,11 @@ func (x ConfigValueType) String() string {
 }
 
 func (ConfigValueType) Descriptor() protoreflect.EnumDescriptor {
-	return file_types_proto_enumTypes[1].Descriptor()
+	return file_types_proto_enumTypes[3].Descriptor()
 }
 
 func (ConfigValueType) Type() protoreflect.EnumType {
-	return &file_types_proto_enumTypes[1]
+	return &file_types_proto_enumTypes[3]
 }
 
 func (x ConfigValueType) Number() protoreflect.EnumNumber {
@@ -137,7 +251,7 @@ func (x ConfigValueType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ConfigValueType.Descriptor instead.
 func (ConfigValueType) EnumDescriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{1}
+	return file_types_proto_rawDescGZIP(), []int{3}
 }
 
 // The core event primitive - everything in Fuwa is an event
@@ -249,6 +363,74 @@ func (x *Event) GetSequence() int64 {
 	return 0
 }
 
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_types_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 type Channel struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
@@ -259,13 +441,14 @@ type Channel struct {
 	Metadata      map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Thread        *ThreadMetadata        `protobuf:"bytes,9,opt,name=thread,proto3" json:"thread,omitempty"` // Set for CHANNEL_TYPE_THREAD channels
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Channel) Reset() {
 	*x = Channel{}
-	mi := &file_types_proto_msgTypes[1]
+	mi := &file_types_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -277,7 +460,7 @@ func (x *Channel) String() string {
 func (*Channel) ProtoMessage() {}
 
 func (x *Channel) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[1]
+	mi := &file_types_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -290,7 +473,7 @@ func (x *Channel) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Channel.ProtoReflect.Descriptor instead.
 func (*Channel) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{1}
+	return file_types_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Channel) GetChannelId() string {
@@ -349,24 +532,138 @@ func (x *Channel) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Channel) GetThread() *ThreadMetadata {
+	if x != nil {
+		return x.Thread
+	}
+	return nil
+}
+
+// State of a thread, a channel started from a message of its parent channel
+type ThreadMetadata struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	MessageId           string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // Message the thread was started from
+	OwnerId             string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Archived            bool                   `protobuf:"varint,3,opt,name=archived,proto3" json:"archived,omitempty"`                                                    // Archived, or inactive for auto_archive_duration
+	Locked              bool                   `protobuf:"varint,4,opt,name=locked,proto3" json:"locked,omitempty"`                                                        // Only MANAGE_CHANNELS may send, unarchive or unlock
+	AutoArchiveDuration int32                  `protobuf:"varint,5,opt,name=auto_archive_duration,json=autoArchiveDuration,proto3" json:"auto_archive_duration,omitempty"` // Minutes: 60, 1440, 4320 or 10080
+	MemberIds           []string               `protobuf:"bytes,6,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"`
+	MessageCount        int32                  `protobuf:"varint,7,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"`
+	LastActivityAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ThreadMetadata) Reset() {
+	*x = ThreadMetadata{}
+	mi := &file_types_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThreadMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThreadMetadata) ProtoMessage() {}
+
+func (x *ThreadMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThreadMetadata.ProtoReflect.Descriptor instead.
+func (*ThreadMetadata) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ThreadMetadata) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ThreadMetadata) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *ThreadMetadata) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *ThreadMetadata) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *ThreadMetadata) GetAutoArchiveDuration() int32 {
+	if x != nil {
+		return x.AutoArchiveDuration
+	}
+	return 0
+}
+
+func (x *ThreadMetadata) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+func (x *ThreadMetadata) GetMessageCount() int32 {
+	if x != nil {
+		return x.MessageCount
+	}
+	return 0
+}
+
+func (x *ThreadMetadata) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
 type Message struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	ChannelId     string                 `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	AuthorId      string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	Attachments   []*Attachment          `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`
-	Embeds        []*Embed               `protobuf:"bytes,6,rep,name=embeds,proto3" json:"embeds,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ReplyToId     string                 `protobuf:"bytes,9,opt,name=reply_to_id,json=replyToId,proto3" json:"reply_to_id,omitempty"` // For message replies
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	MessageId   string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ChannelId   string                 `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	AuthorId    string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Content     string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Attachments []*Attachment          `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	Embeds      []*Embed               `protobuf:"bytes,6,rep,name=embeds,proto3" json:"embeds,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ReplyToId   string                 `protobuf:"bytes,9,opt,name=reply_to_id,json=replyToId,proto3" json:"reply_to_id,omitempty"` // For message replies
+	Reactions   []*Reaction            `protobuf:"bytes,10,rep,name=reactions,proto3" json:"reactions,omitempty"`                   // In the order each emoji was first used
+	ThreadId    string                 `protobuf:"bytes,11,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`     // Thread started from this message
+	// Set on deleted messages, which only GetMessageHistory returns
+	Deleted       bool                   `protobuf:"varint,12,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	DeletedBy     string                 `protobuf:"bytes,13,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_types_proto_msgTypes[2]
+	mi := &file_types_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -378,7 +675,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[2]
+	mi := &file_types_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +688,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{2}
+	return file_types_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Message) GetMessageId() string {
@@ -457,32 +754,68 @@ func (x *Message) GetReplyToId() string {
 	return ""
 }
 
-type Attachment struct {
+func (x *Message) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *Message) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *Message) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Message) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return ""
+}
+
+func (x *Message) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+// A version of a message that an edit replaced
+type MessageRevision struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AttachmentId  string                 `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
-	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	Url           string                 `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Embeds        []*Embed               `protobuf:"bytes,2,rep,name=embeds,proto3" json:"embeds,omitempty"`
+	EditorId      string                 `protobuf:"bytes,3,opt,name=editor_id,json=editorId,proto3" json:"editor_id,omitempty"`    // Who made the edit
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // When this version was written
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`    // When the edit replaced it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Attachment) Reset() {
-	*x = Attachment{}
-	mi := &file_types_proto_msgTypes[3]
+func (x *MessageRevision) Reset() {
+	*x = MessageRevision{}
+	mi := &file_types_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Attachment) String() string {
+func (x *MessageRevision) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Attachment) ProtoMessage() {}
+func (*MessageRevision) ProtoMessage() {}
 
-func (x *Attachment) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[3]
+func (x *MessageRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -493,42 +826,179 @@ func (x *Attachment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
-func (*Attachment) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use MessageRevision.ProtoReflect.Descriptor instead.
+func (*MessageRevision) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Attachment) GetAttachmentId() string {
+func (x *MessageRevision) GetContent() string {
 	if x != nil {
-		return x.AttachmentId
+		return x.Content
 	}
 	return ""
 }
 
-func (x *Attachment) GetFilename() string {
+func (x *MessageRevision) GetEmbeds() []*Embed {
 	if x != nil {
-		return x.Filename
+		return x.Embeds
 	}
-	return ""
+	return nil
 }
 
-func (x *Attachment) GetContentType() string {
+func (x *MessageRevision) GetEditorId() string {
 	if x != nil {
-		return x.ContentType
+		return x.EditorId
 	}
 	return ""
 }
 
-func (x *Attachment) GetSize() int64 {
+func (x *MessageRevision) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Size
+		return x.CreatedAt
 	}
-	return 0
+	return nil
 }
 
-func (x *Attachment) GetUrl() string {
+func (x *MessageRevision) GetEditedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Url
+		return x.EditedAt
+	}
+	return nil
+}
+
+// Reactions to a message with one emoji
+type Reaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emoji         string                 `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Me            bool                   `protobuf:"varint,3,opt,name=me,proto3" json:"me,omitempty"` // The caller is one of the users who reacted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reaction) Reset() {
+	*x = Reaction{}
+	mi := &file_types_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reaction) ProtoMessage() {}
+
+func (x *Reaction) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reaction.ProtoReflect.Descriptor instead.
+func (*Reaction) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Reaction) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *Reaction) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Reaction) GetMe() bool {
+	if x != nil {
+		return x.Me
+	}
+	return false
+}
+
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId  string                 `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Url           string                 `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_types_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Attachment) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetUrl() string {
+	if x != nil {
+		return x.Url
 	}
 	return ""
 }
@@ -548,7 +1018,7 @@ type Embed struct {
 
 func (x *Embed) Reset() {
 	*x = Embed{}
-	mi := &file_types_proto_msgTypes[4]
+	mi := &file_types_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -560,7 +1030,7 @@ func (x *Embed) String() string {
 func (*Embed) ProtoMessage() {}
 
 func (x *Embed) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[4]
+	mi := &file_types_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -573,7 +1043,7 @@ func (x *Embed) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Embed.ProtoReflect.Descriptor instead.
 func (*Embed) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{4}
+	return file_types_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Embed) GetTitle() string {
@@ -611,44 +1081,809 @@ func (x *Embed) GetFields() []*EmbedField {
 	return nil
 }
 
-func (x *Embed) GetThumbnailUrl() string {
+func (x *Embed) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *Embed) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+type EmbedField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Inline        bool                   `protobuf:"varint,3,opt,name=inline,proto3" json:"inline,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedField) Reset() {
+	*x = EmbedField{}
+	mi := &file_types_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedField) ProtoMessage() {}
+
+func (x *EmbedField) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedField.ProtoReflect.Descriptor instead.
+func (*EmbedField) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EmbedField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EmbedField) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *EmbedField) GetInline() bool {
+	if x != nil {
+		return x.Inline
+	}
+	return false
+}
+
+type Role struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Permissions   uint64                 `protobuf:"varint,4,opt,name=permissions,proto3" json:"permissions,omitempty"` // Bitmask of Permission values
+	Position      int32                  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	IsDefault     bool                   `protobuf:"varint,6,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"` // The @everyone role every member implicitly has
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Role) Reset() {
+	*x = Role{}
+	mi := &file_types_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Role) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Role) ProtoMessage() {}
+
+func (x *Role) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Role.ProtoReflect.Descriptor instead.
+func (*Role) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Role) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *Role) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *Role) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Role) GetPermissions() uint64 {
+	if x != nil {
+		return x.Permissions
+	}
+	return 0
+}
+
+func (x *Role) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Role) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *Role) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Role) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Per-channel adjustment applied on top of role permissions
+type PermissionOverwrite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	TargetType    OverwriteTargetType    `protobuf:"varint,2,opt,name=target_type,json=targetType,proto3,enum=fuwa.OverwriteTargetType" json:"target_type,omitempty"`
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"` // role_id or user_id
+	Allow         uint64                 `protobuf:"varint,4,opt,name=allow,proto3" json:"allow,omitempty"`
+	Deny          uint64                 `protobuf:"varint,5,opt,name=deny,proto3" json:"deny,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PermissionOverwrite) Reset() {
+	*x = PermissionOverwrite{}
+	mi := &file_types_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PermissionOverwrite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionOverwrite) ProtoMessage() {}
+
+func (x *PermissionOverwrite) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionOverwrite.ProtoReflect.Descriptor instead.
+func (*PermissionOverwrite) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PermissionOverwrite) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *PermissionOverwrite) GetTargetType() OverwriteTargetType {
+	if x != nil {
+		return x.TargetType
+	}
+	return OverwriteTargetType_OVERWRITE_TARGET_TYPE_UNSPECIFIED
+}
+
+func (x *PermissionOverwrite) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *PermissionOverwrite) GetAllow() uint64 {
+	if x != nil {
+		return x.Allow
+	}
+	return 0
+}
+
+func (x *PermissionOverwrite) GetDeny() uint64 {
+	if x != nil {
+		return x.Deny
+	}
+	return 0
+}
+
+// Channel events
+type ChannelCreatedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       *Channel               `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelCreatedPayload) Reset() {
+	*x = ChannelCreatedPayload{}
+	mi := &file_types_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelCreatedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelCreatedPayload) ProtoMessage() {}
+
+func (x *ChannelCreatedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelCreatedPayload.ProtoReflect.Descriptor instead.
+func (*ChannelCreatedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChannelCreatedPayload) GetChannel() *Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return nil
+}
+
+type ChannelUpdatedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       *Channel               `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	ChangedFields []string               `protobuf:"bytes,2,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelUpdatedPayload) Reset() {
+	*x = ChannelUpdatedPayload{}
+	mi := &file_types_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelUpdatedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelUpdatedPayload) ProtoMessage() {}
+
+func (x *ChannelUpdatedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelUpdatedPayload.ProtoReflect.Descriptor instead.
+func (*ChannelUpdatedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ChannelUpdatedPayload) GetChannel() *Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return nil
+}
+
+func (x *ChannelUpdatedPayload) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+type ChannelDeletedPayload struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId       string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	ServerId        string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	DeletedMessages int64                  `protobuf:"varint,3,opt,name=deleted_messages,json=deletedMessages,proto3" json:"deleted_messages,omitempty"` // Messages removed along with the channel, deleted ones included
+	DeletedThreads  int64                  `protobuf:"varint,4,opt,name=deleted_threads,json=deletedThreads,proto3" json:"deleted_threads,omitempty"`    // Threads removed along with the channel, each recorded by its own channel.deleted first
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChannelDeletedPayload) Reset() {
+	*x = ChannelDeletedPayload{}
+	mi := &file_types_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelDeletedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelDeletedPayload) ProtoMessage() {}
+
+func (x *ChannelDeletedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelDeletedPayload.ProtoReflect.Descriptor instead.
+func (*ChannelDeletedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChannelDeletedPayload) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *ChannelDeletedPayload) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ChannelDeletedPayload) GetDeletedMessages() int64 {
+	if x != nil {
+		return x.DeletedMessages
+	}
+	return 0
+}
+
+func (x *ChannelDeletedPayload) GetDeletedThreads() int64 {
+	if x != nil {
+		return x.DeletedThreads
+	}
+	return 0
+}
+
+// Thread events
+type ThreadCreatedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Thread        *Channel               `protobuf:"bytes,1,opt,name=thread,proto3" json:"thread,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThreadCreatedPayload) Reset() {
+	*x = ThreadCreatedPayload{}
+	mi := &file_types_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThreadCreatedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThreadCreatedPayload) ProtoMessage() {}
+
+func (x *ThreadCreatedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThreadCreatedPayload.ProtoReflect.Descriptor instead.
+func (*ThreadCreatedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ThreadCreatedPayload) GetThread() *Channel {
+	if x != nil {
+		return x.Thread
+	}
+	return nil
+}
+
+type ThreadUpdatedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Thread        *Channel               `protobuf:"bytes,1,opt,name=thread,proto3" json:"thread,omitempty"`
+	ChangedFields []string               `protobuf:"bytes,2,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThreadUpdatedPayload) Reset() {
+	*x = ThreadUpdatedPayload{}
+	mi := &file_types_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThreadUpdatedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThreadUpdatedPayload) ProtoMessage() {}
+
+func (x *ThreadUpdatedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThreadUpdatedPayload.ProtoReflect.Descriptor instead.
+func (*ThreadUpdatedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ThreadUpdatedPayload) GetThread() *Channel {
+	if x != nil {
+		return x.Thread
+	}
+	return nil
+}
+
+func (x *ThreadUpdatedPayload) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+// Sent with both thread.member_added and thread.member_removed
+type ThreadMemberPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ThreadId      string                 `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThreadMemberPayload) Reset() {
+	*x = ThreadMemberPayload{}
+	mi := &file_types_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThreadMemberPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThreadMemberPayload) ProtoMessage() {}
+
+func (x *ThreadMemberPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThreadMemberPayload.ProtoReflect.Descriptor instead.
+func (*ThreadMemberPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ThreadMemberPayload) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *ThreadMemberPayload) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *ThreadMemberPayload) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Message events
+type MessageSentPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageSentPayload) Reset() {
+	*x = MessageSentPayload{}
+	mi := &file_types_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageSentPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageSentPayload) ProtoMessage() {}
+
+func (x *MessageSentPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageSentPayload.ProtoReflect.Descriptor instead.
+func (*MessageSentPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MessageSentPayload) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type MessageUpdatedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ChangedFields []string               `protobuf:"bytes,2,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageUpdatedPayload) Reset() {
+	*x = MessageUpdatedPayload{}
+	mi := &file_types_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageUpdatedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageUpdatedPayload) ProtoMessage() {}
+
+func (x *MessageUpdatedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageUpdatedPayload.ProtoReflect.Descriptor instead.
+func (*MessageUpdatedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MessageUpdatedPayload) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *MessageUpdatedPayload) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+type MessageDeletedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ChannelId     string                 `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageDeletedPayload) Reset() {
+	*x = MessageDeletedPayload{}
+	mi := &file_types_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageDeletedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageDeletedPayload) ProtoMessage() {}
+
+func (x *MessageDeletedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageDeletedPayload.ProtoReflect.Descriptor instead.
+func (*MessageDeletedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MessageDeletedPayload) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MessageDeletedPayload) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+// Sent when the content of a deleted message is erased for good
+type MessagePurgedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ChannelId     string                 `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessagePurgedPayload) Reset() {
+	*x = MessagePurgedPayload{}
+	mi := &file_types_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessagePurgedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessagePurgedPayload) ProtoMessage() {}
+
+func (x *MessagePurgedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessagePurgedPayload.ProtoReflect.Descriptor instead.
+func (*MessagePurgedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MessagePurgedPayload) GetMessageId() string {
 	if x != nil {
-		return x.ThumbnailUrl
+		return x.MessageId
 	}
 	return ""
 }
 
-func (x *Embed) GetImageUrl() string {
+func (x *MessagePurgedPayload) GetChannelId() string {
 	if x != nil {
-		return x.ImageUrl
+		return x.ChannelId
 	}
 	return ""
 }
 
-type EmbedField struct {
+// Sent with both message.reaction_added and message.reaction_removed
+type MessageReactionPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Inline        bool                   `protobuf:"varint,3,opt,name=inline,proto3" json:"inline,omitempty"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ChannelId     string                 `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,3,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Count         int32                  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"` // Reactions with the emoji after the change
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EmbedField) Reset() {
-	*x = EmbedField{}
-	mi := &file_types_proto_msgTypes[5]
+func (x *MessageReactionPayload) Reset() {
+	*x = MessageReactionPayload{}
+	mi := &file_types_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EmbedField) String() string {
+func (x *MessageReactionPayload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmbedField) ProtoMessage() {}
+func (*MessageReactionPayload) ProtoMessage() {}
 
-func (x *EmbedField) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[5]
+func (x *MessageReactionPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -659,55 +1894,69 @@ func (x *EmbedField) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmbedField.ProtoReflect.Descriptor instead.
-func (*EmbedField) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use MessageReactionPayload.ProtoReflect.Descriptor instead.
+func (*MessageReactionPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *EmbedField) GetName() string {
+func (x *MessageReactionPayload) GetMessageId() string {
 	if x != nil {
-		return x.Name
+		return x.MessageId
 	}
 	return ""
 }
 
-func (x *EmbedField) GetValue() string {
+func (x *MessageReactionPayload) GetChannelId() string {
 	if x != nil {
-		return x.Value
+		return x.ChannelId
 	}
 	return ""
 }
 
-func (x *EmbedField) GetInline() bool {
+func (x *MessageReactionPayload) GetEmoji() string {
 	if x != nil {
-		return x.Inline
+		return x.Emoji
 	}
-	return false
+	return ""
 }
 
-// Channel events
-type ChannelCreatedPayload struct {
+func (x *MessageReactionPayload) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MessageReactionPayload) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Role events
+type RoleCreatedPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Channel       *Channel               `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Role          *Role                  `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChannelCreatedPayload) Reset() {
-	*x = ChannelCreatedPayload{}
-	mi := &file_types_proto_msgTypes[6]
+func (x *RoleCreatedPayload) Reset() {
+	*x = RoleCreatedPayload{}
+	mi := &file_types_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChannelCreatedPayload) String() string {
+func (x *RoleCreatedPayload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChannelCreatedPayload) ProtoMessage() {}
+func (*RoleCreatedPayload) ProtoMessage() {}
 
-func (x *ChannelCreatedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[6]
+func (x *RoleCreatedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -718,41 +1967,41 @@ func (x *ChannelCreatedPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChannelCreatedPayload.ProtoReflect.Descriptor instead.
-func (*ChannelCreatedPayload) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RoleCreatedPayload.ProtoReflect.Descriptor instead.
+func (*RoleCreatedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *ChannelCreatedPayload) GetChannel() *Channel {
+func (x *RoleCreatedPayload) GetRole() *Role {
 	if x != nil {
-		return x.Channel
+		return x.Role
 	}
 	return nil
 }
 
-type ChannelUpdatedPayload struct {
+type RoleUpdatedPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Channel       *Channel               `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Role          *Role                  `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
 	ChangedFields []string               `protobuf:"bytes,2,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChannelUpdatedPayload) Reset() {
-	*x = ChannelUpdatedPayload{}
-	mi := &file_types_proto_msgTypes[7]
+func (x *RoleUpdatedPayload) Reset() {
+	*x = RoleUpdatedPayload{}
+	mi := &file_types_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChannelUpdatedPayload) String() string {
+func (x *RoleUpdatedPayload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChannelUpdatedPayload) ProtoMessage() {}
+func (*RoleUpdatedPayload) ProtoMessage() {}
 
-func (x *ChannelUpdatedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[7]
+func (x *RoleUpdatedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -763,48 +2012,48 @@ func (x *ChannelUpdatedPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChannelUpdatedPayload.ProtoReflect.Descriptor instead.
-func (*ChannelUpdatedPayload) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use RoleUpdatedPayload.ProtoReflect.Descriptor instead.
+func (*RoleUpdatedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *ChannelUpdatedPayload) GetChannel() *Channel {
+func (x *RoleUpdatedPayload) GetRole() *Role {
 	if x != nil {
-		return x.Channel
+		return x.Role
 	}
 	return nil
 }
 
-func (x *ChannelUpdatedPayload) GetChangedFields() []string {
+func (x *RoleUpdatedPayload) GetChangedFields() []string {
 	if x != nil {
 		return x.ChangedFields
 	}
 	return nil
 }
 
-type ChannelDeletedPayload struct {
+type RoleDeletedPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
 	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChannelDeletedPayload) Reset() {
-	*x = ChannelDeletedPayload{}
-	mi := &file_types_proto_msgTypes[8]
+func (x *RoleDeletedPayload) Reset() {
+	*x = RoleDeletedPayload{}
+	mi := &file_types_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChannelDeletedPayload) String() string {
+func (x *RoleDeletedPayload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChannelDeletedPayload) ProtoMessage() {}
+func (*RoleDeletedPayload) ProtoMessage() {}
 
-func (x *ChannelDeletedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[8]
+func (x *RoleDeletedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -815,48 +2064,50 @@ func (x *ChannelDeletedPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChannelDeletedPayload.ProtoReflect.Descriptor instead.
-func (*ChannelDeletedPayload) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RoleDeletedPayload.ProtoReflect.Descriptor instead.
+func (*RoleDeletedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *ChannelDeletedPayload) GetChannelId() string {
+func (x *RoleDeletedPayload) GetRoleId() string {
 	if x != nil {
-		return x.ChannelId
+		return x.RoleId
 	}
 	return ""
 }
 
-func (x *ChannelDeletedPayload) GetServerId() string {
+func (x *RoleDeletedPayload) GetServerId() string {
 	if x != nil {
 		return x.ServerId
 	}
 	return ""
 }
 
-// Message events
-type MessageSentPayload struct {
+// Sent with both role.member_added and role.member_removed
+type RoleMemberPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MessageSentPayload) Reset() {
-	*x = MessageSentPayload{}
-	mi := &file_types_proto_msgTypes[9]
+func (x *RoleMemberPayload) Reset() {
+	*x = RoleMemberPayload{}
+	mi := &file_types_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MessageSentPayload) String() string {
+func (x *RoleMemberPayload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MessageSentPayload) ProtoMessage() {}
+func (*RoleMemberPayload) ProtoMessage() {}
 
-func (x *MessageSentPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[9]
+func (x *RoleMemberPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -867,93 +2118,56 @@ func (x *MessageSentPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MessageSentPayload.ProtoReflect.Descriptor instead.
-func (*MessageSentPayload) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *MessageSentPayload) GetMessage() *Message {
-	if x != nil {
-		return x.Message
-	}
-	return nil
-}
-
-type MessageUpdatedPayload struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	ChangedFields []string               `protobuf:"bytes,2,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *MessageUpdatedPayload) Reset() {
-	*x = MessageUpdatedPayload{}
-	mi := &file_types_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *MessageUpdatedPayload) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use RoleMemberPayload.ProtoReflect.Descriptor instead.
+func (*RoleMemberPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{26}
 }
 
-func (*MessageUpdatedPayload) ProtoMessage() {}
-
-func (x *MessageUpdatedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[10]
+func (x *RoleMemberPayload) GetRoleId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.RoleId
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use MessageUpdatedPayload.ProtoReflect.Descriptor instead.
-func (*MessageUpdatedPayload) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{10}
+	return ""
 }
 
-func (x *MessageUpdatedPayload) GetMessage() *Message {
+func (x *RoleMemberPayload) GetServerId() string {
 	if x != nil {
-		return x.Message
+		return x.ServerId
 	}
-	return nil
+	return ""
 }
 
-func (x *MessageUpdatedPayload) GetChangedFields() []string {
+func (x *RoleMemberPayload) GetUserId() string {
 	if x != nil {
-		return x.ChangedFields
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type MessageDeletedPayload struct {
+type ChannelPermissionsUpdatedPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	ChannelId     string                 `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Overwrite     *PermissionOverwrite   `protobuf:"bytes,2,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	Deleted       bool                   `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"` // The overwrite was removed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MessageDeletedPayload) Reset() {
-	*x = MessageDeletedPayload{}
-	mi := &file_types_proto_msgTypes[11]
+func (x *ChannelPermissionsUpdatedPayload) Reset() {
+	*x = ChannelPermissionsUpdatedPayload{}
+	mi := &file_types_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MessageDeletedPayload) String() string {
+func (x *ChannelPermissionsUpdatedPayload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MessageDeletedPayload) ProtoMessage() {}
+func (*ChannelPermissionsUpdatedPayload) ProtoMessage() {}
 
-func (x *MessageDeletedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[11]
+func (x *ChannelPermissionsUpdatedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -964,23 +2178,30 @@ func (x *MessageDeletedPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MessageDeletedPayload.ProtoReflect.Descriptor instead.
-func (*MessageDeletedPayload) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ChannelPermissionsUpdatedPayload.ProtoReflect.Descriptor instead.
+func (*ChannelPermissionsUpdatedPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *MessageDeletedPayload) GetMessageId() string {
+func (x *ChannelPermissionsUpdatedPayload) GetChannelId() string {
 	if x != nil {
-		return x.MessageId
+		return x.ChannelId
 	}
 	return ""
 }
 
-func (x *MessageDeletedPayload) GetChannelId() string {
+func (x *ChannelPermissionsUpdatedPayload) GetOverwrite() *PermissionOverwrite {
 	if x != nil {
-		return x.ChannelId
+		return x.Overwrite
 	}
-	return ""
+	return nil
+}
+
+func (x *ChannelPermissionsUpdatedPayload) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
 }
 
 type ConfigUpdatedPayload struct {
@@ -998,7 +2219,7 @@ type ConfigUpdatedPayload struct {
 
 func (x *ConfigUpdatedPayload) Reset() {
 	*x = ConfigUpdatedPayload{}
-	mi := &file_types_proto_msgTypes[12]
+	mi := &file_types_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1010,7 +2231,7 @@ func (x *ConfigUpdatedPayload) String() string {
 func (*ConfigUpdatedPayload) ProtoMessage() {}
 
 func (x *ConfigUpdatedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[12]
+	mi := &file_types_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1023,7 +2244,7 @@ func (x *ConfigUpdatedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigUpdatedPayload.ProtoReflect.Descriptor instead.
 func (*ConfigUpdatedPayload) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{12}
+	return file_types_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ConfigUpdatedPayload) GetScope() string {
@@ -1089,7 +2310,7 @@ type ConfigDeletedPayload struct {
 
 func (x *ConfigDeletedPayload) Reset() {
 	*x = ConfigDeletedPayload{}
-	mi := &file_types_proto_msgTypes[13]
+	mi := &file_types_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1101,7 +2322,7 @@ func (x *ConfigDeletedPayload) String() string {
 func (*ConfigDeletedPayload) ProtoMessage() {}
 
 func (x *ConfigDeletedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[13]
+	mi := &file_types_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1114,7 +2335,7 @@ func (x *ConfigDeletedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigDeletedPayload.ProtoReflect.Descriptor instead.
 func (*ConfigDeletedPayload) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{13}
+	return file_types_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ConfigDeletedPayload) GetScope() string {
@@ -1159,6 +2380,67 @@ func (x *ConfigDeletedPayload) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+// Sent as consumer.dead_lettered when a consumer group gives up on an event
+type DeadLetterPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Group         string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	DeliveryCount int32                  `protobuf:"varint,3,opt,name=delivery_count,json=deliveryCount,proto3" json:"delivery_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetterPayload) Reset() {
+	*x = DeadLetterPayload{}
+	mi := &file_types_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetterPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterPayload) ProtoMessage() {}
+
+func (x *DeadLetterPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterPayload.ProtoReflect.Descriptor instead.
+func (*DeadLetterPayload) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeadLetterPayload) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *DeadLetterPayload) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *DeadLetterPayload) GetDeliveryCount() int32 {
+	if x != nil {
+		return x.DeliveryCount
+	}
+	return 0
+}
+
 // Config value types for the config service
 type ConfigValue struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1180,7 +2462,7 @@ type ConfigValue struct {
 
 func (x *ConfigValue) Reset() {
 	*x = ConfigValue{}
-	mi := &file_types_proto_msgTypes[14]
+	mi := &file_types_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1192,7 +2474,7 @@ func (x *ConfigValue) String() string {
 func (*ConfigValue) ProtoMessage() {}
 
 func (x *ConfigValue) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[14]
+	mi := &file_types_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1205,7 +2487,7 @@ func (x *ConfigValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigValue.ProtoReflect.Descriptor instead.
 func (*ConfigValue) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{14}
+	return file_types_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *ConfigValue) GetValue() isConfigValue_Value {
@@ -1339,7 +2621,7 @@ type ConfigObject struct {
 
 func (x *ConfigObject) Reset() {
 	*x = ConfigObject{}
-	mi := &file_types_proto_msgTypes[15]
+	mi := &file_types_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1351,7 +2633,7 @@ func (x *ConfigObject) String() string {
 func (*ConfigObject) ProtoMessage() {}
 
 func (x *ConfigObject) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[15]
+	mi := &file_types_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1364,7 +2646,7 @@ func (x *ConfigObject) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigObject.ProtoReflect.Descriptor instead.
 func (*ConfigObject) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{15}
+	return file_types_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ConfigObject) GetFields() map[string]*ConfigValue {
@@ -1383,7 +2665,7 @@ type ConfigArray struct {
 
 func (x *ConfigArray) Reset() {
 	*x = ConfigArray{}
-	mi := &file_types_proto_msgTypes[16]
+	mi := &file_types_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1395,7 +2677,7 @@ func (x *ConfigArray) String() string {
 func (*ConfigArray) ProtoMessage() {}
 
 func (x *ConfigArray) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[16]
+	mi := &file_types_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1408,7 +2690,7 @@ func (x *ConfigArray) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigArray.ProtoReflect.Descriptor instead.
 func (*ConfigArray) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{16}
+	return file_types_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ConfigArray) GetItems() []*ConfigValue {
@@ -1435,7 +2717,7 @@ type ConfigConstraints struct {
 
 func (x *ConfigConstraints) Reset() {
 	*x = ConfigConstraints{}
-	mi := &file_types_proto_msgTypes[17]
+	mi := &file_types_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1447,7 +2729,7 @@ func (x *ConfigConstraints) String() string {
 func (*ConfigConstraints) ProtoMessage() {}
 
 func (x *ConfigConstraints) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[17]
+	mi := &file_types_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1460,7 +2742,7 @@ func (x *ConfigConstraints) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigConstraints.ProtoReflect.Descriptor instead.
 func (*ConfigConstraints) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{17}
+	return file_types_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *ConfigConstraints) GetMinLength() int32 {
@@ -1543,7 +2825,13 @@ const file_types_proto_rawDesc = "" +
 	"\bsequence\x18\b \x01(\x03R\bsequence\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x89\x03\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x99\x01\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12!\n" +
+	"\fdisplay_name\x18\x03 \x01(\tR\vdisplayName\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb7\x03\n" +
 	"\aChannel\x12\x1d\n" +
 	"\n" +
 	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x12\n" +
@@ -1555,10 +2843,22 @@ const file_types_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a;\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12,\n" +
+	"\x06thread\x18\t \x01(\v2\x14.fuwa.ThreadMetadataR\x06thread\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xed\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbc\x02\n" +
+	"\x0eThreadMetadata\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12\x1a\n" +
+	"\barchived\x18\x03 \x01(\bR\barchived\x12\x16\n" +
+	"\x06locked\x18\x04 \x01(\bR\x06locked\x122\n" +
+	"\x15auto_archive_duration\x18\x05 \x01(\x05R\x13autoArchiveDuration\x12\x1d\n" +
+	"\n" +
+	"member_ids\x18\x06 \x03(\tR\tmemberIds\x12#\n" +
+	"\rmessage_count\x18\a \x01(\x05R\fmessageCount\x12D\n" +
+	"\x10last_activity_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\"\xac\x04\n" +
 	"\aMessage\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1d\n" +
@@ -1572,7 +2872,26 @@ const file_types_proto_rawDesc = "" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1e\n" +
-	"\vreply_to_id\x18\t \x01(\tR\treplyToId\"\x96\x01\n" +
+	"\vreply_to_id\x18\t \x01(\tR\treplyToId\x12,\n" +
+	"\treactions\x18\n" +
+	" \x03(\v2\x0e.fuwa.ReactionR\treactions\x12\x1b\n" +
+	"\tthread_id\x18\v \x01(\tR\bthreadId\x12\x18\n" +
+	"\adeleted\x18\f \x01(\bR\adeleted\x12\x1d\n" +
+	"\n" +
+	"deleted_by\x18\r \x01(\tR\tdeletedBy\x129\n" +
+	"\n" +
+	"deleted_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\xe1\x01\n" +
+	"\x0fMessageRevision\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12#\n" +
+	"\x06embeds\x18\x02 \x03(\v2\v.fuwa.EmbedR\x06embeds\x12\x1b\n" +
+	"\teditor_id\x18\x03 \x01(\tR\beditorId\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tedited_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\"F\n" +
+	"\bReaction\x12\x14\n" +
+	"\x05emoji\x18\x01 \x01(\tR\x05emoji\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x0e\n" +
+	"\x02me\x18\x03 \x01(\bR\x02me\"\x96\x01\n" +
 	"\n" +
 	"Attachment\x12#\n" +
 	"\rattachment_id\x18\x01 \x01(\tR\fattachmentId\x12\x1a\n" +
@@ -1592,16 +2911,47 @@ const file_types_proto_rawDesc = "" +
 	"EmbedField\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
-	"\x06inline\x18\x03 \x01(\bR\x06inline\"@\n" +
+	"\x06inline\x18\x03 \x01(\bR\x06inline\"\xa3\x02\n" +
+	"\x04Role\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vpermissions\x18\x04 \x01(\x04R\vpermissions\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x06 \x01(\bR\tisDefault\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb7\x01\n" +
+	"\x13PermissionOverwrite\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12:\n" +
+	"\vtarget_type\x18\x02 \x01(\x0e2\x19.fuwa.OverwriteTargetTypeR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x12\x14\n" +
+	"\x05allow\x18\x04 \x01(\x04R\x05allow\x12\x12\n" +
+	"\x04deny\x18\x05 \x01(\x04R\x04deny\"@\n" +
 	"\x15ChannelCreatedPayload\x12'\n" +
 	"\achannel\x18\x01 \x01(\v2\r.fuwa.ChannelR\achannel\"g\n" +
 	"\x15ChannelUpdatedPayload\x12'\n" +
 	"\achannel\x18\x01 \x01(\v2\r.fuwa.ChannelR\achannel\x12%\n" +
-	"\x0echanged_fields\x18\x02 \x03(\tR\rchangedFields\"S\n" +
+	"\x0echanged_fields\x18\x02 \x03(\tR\rchangedFields\"\xa7\x01\n" +
 	"\x15ChannelDeletedPayload\x12\x1d\n" +
 	"\n" +
 	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x1b\n" +
-	"\tserver_id\x18\x02 \x01(\tR\bserverId\"=\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12)\n" +
+	"\x10deleted_messages\x18\x03 \x01(\x03R\x0fdeletedMessages\x12'\n" +
+	"\x0fdeleted_threads\x18\x04 \x01(\x03R\x0edeletedThreads\"=\n" +
+	"\x14ThreadCreatedPayload\x12%\n" +
+	"\x06thread\x18\x01 \x01(\v2\r.fuwa.ChannelR\x06thread\"d\n" +
+	"\x14ThreadUpdatedPayload\x12%\n" +
+	"\x06thread\x18\x01 \x01(\v2\r.fuwa.ChannelR\x06thread\x12%\n" +
+	"\x0echanged_fields\x18\x02 \x03(\tR\rchangedFields\"h\n" +
+	"\x13ThreadMemberPayload\x12\x1b\n" +
+	"\tthread_id\x18\x01 \x01(\tR\bthreadId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"=\n" +
 	"\x12MessageSentPayload\x12'\n" +
 	"\amessage\x18\x01 \x01(\v2\r.fuwa.MessageR\amessage\"g\n" +
 	"\x15MessageUpdatedPayload\x12'\n" +
@@ -1611,7 +2961,39 @@ const file_types_proto_rawDesc = "" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1d\n" +
 	"\n" +
-	"channel_id\x18\x02 \x01(\tR\tchannelId\"\x99\x02\n" +
+	"channel_id\x18\x02 \x01(\tR\tchannelId\"T\n" +
+	"\x14MessagePurgedPayload\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x02 \x01(\tR\tchannelId\"\x9b\x01\n" +
+	"\x16MessageReactionPayload\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x02 \x01(\tR\tchannelId\x12\x14\n" +
+	"\x05emoji\x18\x03 \x01(\tR\x05emoji\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x05R\x05count\"4\n" +
+	"\x12RoleCreatedPayload\x12\x1e\n" +
+	"\x04role\x18\x01 \x01(\v2\n" +
+	".fuwa.RoleR\x04role\"[\n" +
+	"\x12RoleUpdatedPayload\x12\x1e\n" +
+	"\x04role\x18\x01 \x01(\v2\n" +
+	".fuwa.RoleR\x04role\x12%\n" +
+	"\x0echanged_fields\x18\x02 \x03(\tR\rchangedFields\"J\n" +
+	"\x12RoleDeletedPayload\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\"b\n" +
+	"\x11RoleMemberPayload\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\x94\x01\n" +
+	" ChannelPermissionsUpdatedPayload\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x127\n" +
+	"\toverwrite\x18\x02 \x01(\v2\x19.fuwa.PermissionOverwriteR\toverwrite\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\bR\adeleted\"\x99\x02\n" +
 	"\x14ConfigUpdatedPayload\x12\x14\n" +
 	"\x05scope\x18\x01 \x01(\tR\x05scope\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\x12.\n" +
@@ -1628,7 +3010,11 @@ const file_types_proto_rawDesc = "" +
 	"\n" +
 	"deleted_by\x18\x04 \x01(\tR\tdeletedBy\x12\x16\n" +
 	"\x06reason\x18\x05 \x01(\tR\x06reason\x128\n" +
-	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x96\x03\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"s\n" +
+	"\x11DeadLetterPayload\x12!\n" +
+	"\x05event\x18\x01 \x01(\v2\v.fuwa.EventR\x05event\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12%\n" +
+	"\x0edelivery_count\x18\x03 \x01(\x05R\rdeliveryCount\"\x96\x03\n" +
 	"\vConfigValue\x12#\n" +
 	"\fstring_value\x18\x01 \x01(\tH\x00R\vstringValue\x12\x1d\n" +
 	"\tint_value\x18\x02 \x01(\x03H\x00R\bintValue\x12!\n" +
@@ -1667,7 +3053,21 @@ const file_types_proto_rawDesc = "" +
 	"\x11CHANNEL_TYPE_TEXT\x10\x01\x12\x16\n" +
 	"\x12CHANNEL_TYPE_VOICE\x10\x02\x12\x1d\n" +
 	"\x19CHANNEL_TYPE_ANNOUNCEMENT\x10\x03\x12\x17\n" +
-	"\x13CHANNEL_TYPE_THREAD\x10\x04*\xe1\x01\n" +
+	"\x13CHANNEL_TYPE_THREAD\x10\x04*\xfc\x01\n" +
+	"\n" +
+	"Permission\x12\x1a\n" +
+	"\x16PERMISSION_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17PERMISSION_VIEW_CHANNEL\x10\x01\x12\x1c\n" +
+	"\x18PERMISSION_SEND_MESSAGES\x10\x02\x12\x1e\n" +
+	"\x1aPERMISSION_MANAGE_MESSAGES\x10\x04\x12\x1e\n" +
+	"\x1aPERMISSION_MANAGE_CHANNELS\x10\b\x12\x1c\n" +
+	"\x18PERMISSION_MANAGE_CONFIG\x10\x10\x12\x1b\n" +
+	"\x17PERMISSION_MANAGE_ROLES\x10 \x12\x1c\n" +
+	"\x18PERMISSION_ADMINISTRATOR\x10@*|\n" +
+	"\x13OverwriteTargetType\x12%\n" +
+	"!OVERWRITE_TARGET_TYPE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aOVERWRITE_TARGET_TYPE_ROLE\x10\x01\x12\x1e\n" +
+	"\x1aOVERWRITE_TARGET_TYPE_USER\x10\x02*\xe1\x01\n" +
 	"\x0fConfigValueType\x12!\n" +
 	"\x1dCONFIG_VALUE_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18CONFIG_VALUE_TYPE_STRING\x10\x01\x12\x19\n" +
@@ -1689,69 +3089,105 @@ func file_types_proto_rawDescGZIP() []byte {
 	return file_types_proto_rawDescData
 }
 
-var file_types_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_types_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_types_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_types_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_types_proto_goTypes = []any{
-	(ChannelType)(0),              // 0: fuwa.ChannelType
-	(ConfigValueType)(0),          // 1: fuwa.ConfigValueType
-	(*Event)(nil),                 // 2: fuwa.Event
-	(*Channel)(nil),               // 3: fuwa.Channel
-	(*Message)(nil),               // 4: fuwa.Message
-	(*Attachment)(nil),            // 5: fuwa.Attachment
-	(*Embed)(nil),                 // 6: fuwa.Embed
-	(*EmbedField)(nil),            // 7: fuwa.EmbedField
-	(*ChannelCreatedPayload)(nil), // 8: fuwa.ChannelCreatedPayload
-	(*ChannelUpdatedPayload)(nil), // 9: fuwa.ChannelUpdatedPayload
-	(*ChannelDeletedPayload)(nil), // 10: fuwa.ChannelDeletedPayload
-	(*MessageSentPayload)(nil),    // 11: fuwa.MessageSentPayload
-	(*MessageUpdatedPayload)(nil), // 12: fuwa.MessageUpdatedPayload
-	(*MessageDeletedPayload)(nil), // 13: fuwa.MessageDeletedPayload
-	(*ConfigUpdatedPayload)(nil),  // 14: fuwa.ConfigUpdatedPayload
-	(*ConfigDeletedPayload)(nil),  // 15: fuwa.ConfigDeletedPayload
-	(*ConfigValue)(nil),           // 16: fuwa.ConfigValue
-	(*ConfigObject)(nil),          // 17: fuwa.ConfigObject
-	(*ConfigArray)(nil),           // 18: fuwa.ConfigArray
-	(*ConfigConstraints)(nil),     // 19: fuwa.ConfigConstraints
-	nil,                           // 20: fuwa.Event.MetadataEntry
-	nil,                           // 21: fuwa.Channel.MetadataEntry
-	nil,                           // 22: fuwa.ConfigObject.FieldsEntry
-	(*timestamppb.Timestamp)(nil), // 23: google.protobuf.Timestamp
-	(*anypb.Any)(nil),             // 24: google.protobuf.Any
+	(ChannelType)(0),                         // 0: fuwa.ChannelType
+	(Permission)(0),                          // 1: fuwa.Permission
+	(OverwriteTargetType)(0),                 // 2: fuwa.OverwriteTargetType
+	(ConfigValueType)(0),                     // 3: fuwa.ConfigValueType
+	(*Event)(nil),                            // 4: fuwa.Event
+	(*User)(nil),                             // 5: fuwa.User
+	(*Channel)(nil),                          // 6: fuwa.Channel
+	(*ThreadMetadata)(nil),                   // 7: fuwa.ThreadMetadata
+	(*Message)(nil),                          // 8: fuwa.Message
+	(*MessageRevision)(nil),                  // 9: fuwa.MessageRevision
+	(*Reaction)(nil),                         // 10: fuwa.Reaction
+	(*Attachment)(nil),                       // 11: fuwa.Attachment
+	(*Embed)(nil),                            // 12: fuwa.Embed
+	(*EmbedField)(nil),                       // 13: fuwa.EmbedField
+	(*Role)(nil),                             // 14: fuwa.Role
+	(*PermissionOverwrite)(nil),              // 15: fuwa.PermissionOverwrite
+	(*ChannelCreatedPayload)(nil),            // 16: fuwa.ChannelCreatedPayload
+	(*ChannelUpdatedPayload)(nil),            // 17: fuwa.ChannelUpdatedPayload
+	(*ChannelDeletedPayload)(nil),            // 18: fuwa.ChannelDeletedPayload
+	(*ThreadCreatedPayload)(nil),             // 19: fuwa.ThreadCreatedPayload
+	(*ThreadUpdatedPayload)(nil),             // 20: fuwa.ThreadUpdatedPayload
+	(*ThreadMemberPayload)(nil),              // 21: fuwa.ThreadMemberPayload
+	(*MessageSentPayload)(nil),               // 22: fuwa.MessageSentPayload
+	(*MessageUpdatedPayload)(nil),            // 23: fuwa.MessageUpdatedPayload
+	(*MessageDeletedPayload)(nil),            // 24: fuwa.MessageDeletedPayload
+	(*MessagePurgedPayload)(nil),             // 25: fuwa.MessagePurgedPayload
+	(*MessageReactionPayload)(nil),           // 26: fuwa.MessageReactionPayload
+	(*RoleCreatedPayload)(nil),               // 27: fuwa.RoleCreatedPayload
+	(*RoleUpdatedPayload)(nil),               // 28: fuwa.RoleUpdatedPayload
+	(*RoleDeletedPayload)(nil),               // 29: fuwa.RoleDeletedPayload
+	(*RoleMemberPayload)(nil),                // 30: fuwa.RoleMemberPayload
+	(*ChannelPermissionsUpdatedPayload)(nil), // 31: fuwa.ChannelPermissionsUpdatedPayload
+	(*ConfigUpdatedPayload)(nil),             // 32: fuwa.ConfigUpdatedPayload
+	(*ConfigDeletedPayload)(nil),             // 33: fuwa.ConfigDeletedPayload
+	(*DeadLetterPayload)(nil),                // 34: fuwa.DeadLetterPayload
+	(*ConfigValue)(nil),                      // 35: fuwa.ConfigValue
+	(*ConfigObject)(nil),                     // 36: fuwa.ConfigObject
+	(*ConfigArray)(nil),                      // 37: fuwa.ConfigArray
+	(*ConfigConstraints)(nil),                // 38: fuwa.ConfigConstraints
+	nil,                                      // 39: fuwa.Event.MetadataEntry
+	nil,                                      // 40: fuwa.Channel.MetadataEntry
+	nil,                                      // 41: fuwa.ConfigObject.FieldsEntry
+	(*timestamppb.Timestamp)(nil),            // 42: google.protobuf.Timestamp
+	(*anypb.Any)(nil),                        // 43: google.protobuf.Any
 }
 var file_types_proto_depIdxs = []int32{
-	23, // 0: fuwa.Event.timestamp:type_name -> google.protobuf.Timestamp
-	24, // 1: fuwa.Event.payload:type_name -> google.protobuf.Any
-	20, // 2: fuwa.Event.metadata:type_name -> fuwa.Event.MetadataEntry
-	0,  // 3: fuwa.Channel.type:type_name -> fuwa.ChannelType
-	21, // 4: fuwa.Channel.metadata:type_name -> fuwa.Channel.MetadataEntry
-	23, // 5: fuwa.Channel.created_at:type_name -> google.protobuf.Timestamp
-	23, // 6: fuwa.Channel.updated_at:type_name -> google.protobuf.Timestamp
-	5,  // 7: fuwa.Message.attachments:type_name -> fuwa.Attachment
-	6,  // 8: fuwa.Message.embeds:type_name -> fuwa.Embed
-	23, // 9: fuwa.Message.created_at:type_name -> google.protobuf.Timestamp
-	23, // 10: fuwa.Message.updated_at:type_name -> google.protobuf.Timestamp
-	7,  // 11: fuwa.Embed.fields:type_name -> fuwa.EmbedField
-	3,  // 12: fuwa.ChannelCreatedPayload.channel:type_name -> fuwa.Channel
-	3,  // 13: fuwa.ChannelUpdatedPayload.channel:type_name -> fuwa.Channel
-	4,  // 14: fuwa.MessageSentPayload.message:type_name -> fuwa.Message
-	4,  // 15: fuwa.MessageUpdatedPayload.message:type_name -> fuwa.Message
-	16, // 16: fuwa.ConfigUpdatedPayload.old_value:type_name -> fuwa.ConfigValue
-	16, // 17: fuwa.ConfigUpdatedPayload.new_value:type_name -> fuwa.ConfigValue
-	23, // 18: fuwa.ConfigUpdatedPayload.timestamp:type_name -> google.protobuf.Timestamp
-	16, // 19: fuwa.ConfigDeletedPayload.deleted_value:type_name -> fuwa.ConfigValue
-	23, // 20: fuwa.ConfigDeletedPayload.timestamp:type_name -> google.protobuf.Timestamp
-	17, // 21: fuwa.ConfigValue.object_value:type_name -> fuwa.ConfigObject
-	18, // 22: fuwa.ConfigValue.array_value:type_name -> fuwa.ConfigArray
-	1,  // 23: fuwa.ConfigValue.type:type_name -> fuwa.ConfigValueType
-	19, // 24: fuwa.ConfigValue.constraints:type_name -> fuwa.ConfigConstraints
-	22, // 25: fuwa.ConfigObject.fields:type_name -> fuwa.ConfigObject.FieldsEntry
-	16, // 26: fuwa.ConfigArray.items:type_name -> fuwa.ConfigValue
-	16, // 27: fuwa.ConfigObject.FieldsEntry.value:type_name -> fuwa.ConfigValue
-	28, // [28:28] is the sub-list for method output_type
-	28, // [28:28] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	42, // 0: fuwa.Event.timestamp:type_name -> google.protobuf.Timestamp
+	43, // 1: fuwa.Event.payload:type_name -> google.protobuf.Any
+	39, // 2: fuwa.Event.metadata:type_name -> fuwa.Event.MetadataEntry
+	42, // 3: fuwa.User.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: fuwa.Channel.type:type_name -> fuwa.ChannelType
+	40, // 5: fuwa.Channel.metadata:type_name -> fuwa.Channel.MetadataEntry
+	42, // 6: fuwa.Channel.created_at:type_name -> google.protobuf.Timestamp
+	42, // 7: fuwa.Channel.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 8: fuwa.Channel.thread:type_name -> fuwa.ThreadMetadata
+	42, // 9: fuwa.ThreadMetadata.last_activity_at:type_name -> google.protobuf.Timestamp
+	11, // 10: fuwa.Message.attachments:type_name -> fuwa.Attachment
+	12, // 11: fuwa.Message.embeds:type_name -> fuwa.Embed
+	42, // 12: fuwa.Message.created_at:type_name -> google.protobuf.Timestamp
+	42, // 13: fuwa.Message.updated_at:type_name -> google.protobuf.Timestamp
+	10, // 14: fuwa.Message.reactions:type_name -> fuwa.Reaction
+	42, // 15: fuwa.Message.deleted_at:type_name -> google.protobuf.Timestamp
+	12, // 16: fuwa.MessageRevision.embeds:type_name -> fuwa.Embed
+	42, // 17: fuwa.MessageRevision.created_at:type_name -> google.protobuf.Timestamp
+	42, // 18: fuwa.MessageRevision.edited_at:type_name -> google.protobuf.Timestamp
+	13, // 19: fuwa.Embed.fields:type_name -> fuwa.EmbedField
+	42, // 20: fuwa.Role.created_at:type_name -> google.protobuf.Timestamp
+	42, // 21: fuwa.Role.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 22: fuwa.PermissionOverwrite.target_type:type_name -> fuwa.OverwriteTargetType
+	6,  // 23: fuwa.ChannelCreatedPayload.channel:type_name -> fuwa.Channel
+	6,  // 24: fuwa.ChannelUpdatedPayload.channel:type_name -> fuwa.Channel
+	6,  // 25: fuwa.ThreadCreatedPayload.thread:type_name -> fuwa.Channel
+	6,  // 26: fuwa.ThreadUpdatedPayload.thread:type_name -> fuwa.Channel
+	8,  // 27: fuwa.MessageSentPayload.message:type_name -> fuwa.Message
+	8,  // 28: fuwa.MessageUpdatedPayload.message:type_name -> fuwa.Message
+	14, // 29: fuwa.RoleCreatedPayload.role:type_name -> fuwa.Role
+	14, // 30: fuwa.RoleUpdatedPayload.role:type_name -> fuwa.Role
+	15, // 31: fuwa.ChannelPermissionsUpdatedPayload.overwrite:type_name -> fuwa.PermissionOverwrite
+	35, // 32: fuwa.ConfigUpdatedPayload.old_value:type_name -> fuwa.ConfigValue
+	35, // 33: fuwa.ConfigUpdatedPayload.new_value:type_name -> fuwa.ConfigValue
+	42, // 34: fuwa.ConfigUpdatedPayload.timestamp:type_name -> google.protobuf.Timestamp
+	35, // 35: fuwa.ConfigDeletedPayload.deleted_value:type_name -> fuwa.ConfigValue
+	42, // 36: fuwa.ConfigDeletedPayload.timestamp:type_name -> google.protobuf.Timestamp
+	4,  // 37: fuwa.DeadLetterPayload.event:type_name -> fuwa.Event
+	36, // 38: fuwa.ConfigValue.object_value:type_name -> fuwa.ConfigObject
+	37, // 39: fuwa.ConfigValue.array_value:type_name -> fuwa.ConfigArray
+	3,  // 40: fuwa.ConfigValue.type:type_name -> fuwa.ConfigValueType
+	38, // 41: fuwa.ConfigValue.constraints:type_name -> fuwa.ConfigConstraints
+	41, // 42: fuwa.ConfigObject.fields:type_name -> fuwa.ConfigObject.FieldsEntry
+	35, // 43: fuwa.ConfigArray.items:type_name -> fuwa.ConfigValue
+	35, // 44: fuwa.ConfigObject.FieldsEntry.value:type_name -> fuwa.ConfigValue
+	45, // [45:45] is the sub-list for method output_type
+	45, // [45:45] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_types_proto_init() }
@@ -1759,7 +3195,7 @@ func file_types_proto_init() {
 	if File_types_proto != nil {
 		return
 	}
-	file_types_proto_msgTypes[14].OneofWrappers = []any{
+	file_types_proto_msgTypes[31].OneofWrappers = []any{
 		(*ConfigValue_StringValue)(nil),
 		(*ConfigValue_IntValue)(nil),
 		(*ConfigValue_FloatValue)(nil),
@@ -1772,8 +3208,8 @@ func file_types_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_types_proto_rawDesc), len(file_types_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   21,
+			NumEnums:      4,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,274 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// replayBatchSize is how many events a replay loads at once.
+const replayBatchSize = 500
+
+// ReplayOptions configures ReplayDatabase.
+type ReplayOptions struct {
+	// Projections to run, by name; empty runs all of them
+	Projections []string
+	// Verify replays into a transaction that is rolled back and reports
+	// how the rebuilt tables differ from the stored ones
+	Verify bool
+	// Progress is called after each batch of events
+	Progress func(applied, total int64)
+}
+
+// ReplayReport describes the outcome of replaying one database.
+type ReplayReport struct {
+	Database string
+	Events   int64
+	// Skipped lists events that could not be fully applied
+	Skipped []string
+	// Differences lists rows that differ after a verifying replay
+	Differences []string
+	// Backup is the copy taken before the tables were rebuilt
+	Backup string
+}
+
+// ReplayDatabase rebuilds the read-model tables of a database file from its
+// event log. Events are applied in the order they were stored, all in one
+// transaction, so a failed replay leaves the database untouched. The file
+// must not be in use by a running server.
+func (mdm *MultiDatabaseManager) ReplayDatabase(ctx context.Context, name string, opts ReplayOptions) (ReplayReport, error) {
+	report := ReplayReport{Database: name}
+
+	selected, err := projectionsByName(opts.Projections)
+	if err != nil {
+		return report, err
+	}
+
+	err = mdm.withClosedDatabase(name, func(path string, db *sql.DB) error {
+		current, err := mdm.requireCurrentSchema(name, db)
+		if err != nil {
+			return err
+		}
+
+		if !opts.Verify {
+			report.Backup, err = mdm.backupDatabase(name, path, current)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Projections reset and rebuild their tables one after another, so
+		// foreign keys would delete the rows of the projections not being
+		// replayed. Each projection removes the rows a deletion takes along
+		// itself instead
+		if _, err := db.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+			return fmt.Errorf("failed to disable foreign keys: %w", err)
+		}
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+		queries := database.New(tx)
+
+		var before map[string]string
+		if opts.Verify {
+			if before, err = readModelState(ctx, queries); err != nil {
+				return err
+			}
+		}
+
+		if err := replayEvents(ctx, queries, selected, opts.Progress, &report); err != nil {
+			return err
+		}
+
+		if opts.Verify {
+			after, err := readModelState(ctx, queries)
+			if err != nil {
+				return err
+			}
+			report.Differences = diffReadModelState(before, after)
+			return nil
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit replay: %w", err)
+		}
+		log.Printf("Replayed %d events into database %s (backup: %s)", report.Events, name, report.Backup)
+		return nil
+	})
+	return report, err
+}
+
+// requireCurrentSchema refuses to replay into a database with pending
+// migrations, whose tables may not match the projections.
+func (mdm *MultiDatabaseManager) requireCurrentSchema(name string, db *sql.DB) (int64, error) {
+	migrationMu.Lock()
+	defer migrationMu.Unlock()
+
+	if err := setupGoose(); err != nil {
+		return 0, err
+	}
+	current, latest, err := migrationVersions(db)
+	if err != nil {
+		return 0, err
+	}
+	if current != latest {
+		return 0, fmt.Errorf("database %s is at version %d, expected %d; run migrate up first", name, current, latest)
+	}
+	return current, nil
+}
+
+func replayEvents(ctx context.Context, queries *database.Queries, selected []Projection, progress func(applied, total int64), report *ReplayReport) error {
+	for _, projection := range selected {
+		if err := projection.Reset(ctx, queries); err != nil {
+			return fmt.Errorf("failed to reset %s: %w", projection.Name(), err)
+		}
+	}
+
+	total, err := queries.CountEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count events: %w", err)
+	}
+
+	var lastRowid int64
+	for {
+		rows, err := queries.ListEventsForReplay(ctx, database.ListEventsForReplayParams{
+			Rowid: lastRowid,
+			Limit: replayBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load events: %w", err)
+		}
+
+		for _, row := range rows {
+			event := dbEventToProto(&row.Event)
+			for _, projection := range selected {
+				err := projection.Apply(ctx, queries, event)
+				if errors.Is(err, errEventSkipped) {
+					report.Skipped = append(report.Skipped, fmt.Sprintf("%s %s: %v", event.EventId, event.EventType, err))
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("%s failed on event %s (%s, %s sequence %d): %w", projection.Name(), event.EventId, event.EventType, event.Scope, event.Sequence, err)
+				}
+			}
+			lastRowid = row.Rowid
+			report.Events++
+		}
+
+		if progress != nil {
+			progress(report.Events, total)
+		}
+		if len(rows) < replayBatchSize {
+			return nil
+		}
+	}
+}
+
+// readModelState renders every row of the read models the way clients see
+// it, keyed by kind and ID. Comparing renders rather than raw rows ignores
+// IDs a replay assigns anew, such as those of embeds.
+func readModelState(ctx context.Context, queries *database.Queries) (map[string]string, error) {
+	state := make(map[string]string)
+
+	channels, err := queries.ListAllChannels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channels: %w", err)
+	}
+	for i := range channels {
+		channel := dbChannelToProto(&channels[i])
+		if err := loadThreadMetadata(ctx, queries, []*pb.Channel{channel}); err != nil {
+			return nil, fmt.Errorf("failed to list threads: %w", err)
+		}
+		encoded, err := protojson.Marshal(channel)
+		if err != nil {
+			return nil, err
+		}
+		state["channel "+channels[i].ChannelID] = string(encoded)
+	}
+
+	messages, err := queries.ListAllMessages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+	for i := range messages {
+		message := dbMessageToProto(&messages[i])
+		if message.Attachments, err = getMessageAttachments(ctx, queries, message.MessageId); err != nil {
+			return nil, fmt.Errorf("failed to list attachments: %w", err)
+		}
+		if message.Embeds, err = getMessageEmbeds(ctx, queries, message.MessageId); err != nil {
+			return nil, fmt.Errorf("failed to list embeds: %w", err)
+		}
+		if err := loadMessageReactions(ctx, queries, []*pb.Message{message}, ""); err != nil {
+			return nil, fmt.Errorf("failed to list reactions: %w", err)
+		}
+		if err := loadMessageThreads(ctx, queries, []*pb.Message{message}); err != nil {
+			return nil, fmt.Errorf("failed to list threads: %w", err)
+		}
+		encoded, err := protojson.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		state["message "+message.MessageId] = string(encoded)
+
+		revisions, err := getMessageRevisions(ctx, queries, message.MessageId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list revisions: %w", err)
+		}
+		for j, revision := range revisions {
+			encoded, err := protojson.Marshal(revision)
+			if err != nil {
+				return nil, err
+			}
+			state[fmt.Sprintf("revision %s %d", message.MessageId, j)] = string(encoded)
+		}
+	}
+
+	configs, err := queries.ListAllConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configs: %w", err)
+	}
+	for i := range configs {
+		value, err := dbConfigToProto(&configs[i])
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := protojson.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		state["config "+configs[i].Scope+" "+configs[i].Key] = string(encoded)
+	}
+
+	return state, nil
+}
+
+func diffReadModelState(before, after map[string]string) []string {
+	var differences []string
+	for key, stored := range before {
+		replayed, ok := after[key]
+		switch {
+		case !ok:
+			differences = append(differences, key+": missing after replay")
+		case replayed != stored:
+			differences = append(differences, fmt.Sprintf("%s: stored %s, replayed %s", key, stored, replayed))
+		}
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			differences = append(differences, key+": only present after replay")
+		}
+	}
+	slices.Sort(differences)
+	return differences
+}
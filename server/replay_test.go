@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+// buildHistory records channels, subchannels, threads, messages, edits,
+// reactions and deletions in srv1, then closes it so it can be replayed.
+func buildHistory(t *testing.T, s *testServer) (channelID string) {
+	t.Helper()
+
+	ctx, release := WithDatabaseLeases(ContextWithPrincipal(context.Background(), &Principal{UserID: "alice", Username: "alice"}))
+	defer func() {
+		release()
+		if err := s.manager.CloseDatabase("srv1"); err != nil {
+			t.Fatalf("CloseDatabase: %v", err)
+		}
+	}()
+
+	createChannel := func(name, parentID string) string {
+		t.Helper()
+		resp, err := s.channels.CreateChannel(ctx, &pb.CreateChannelRequest{Name: name, Type: pb.ChannelType_CHANNEL_TYPE_TEXT, ServerId: "srv1", ParentId: parentID})
+		if err != nil {
+			t.Fatalf("CreateChannel: %v", err)
+		}
+		return resp.Channel.ChannelId
+	}
+	sendMessage := func(channelID, content string) string {
+		t.Helper()
+		resp, err := s.messages.SendMessage(ctx, &pb.SendMessageRequest{ChannelId: channelID, Content: content})
+		if err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+		return resp.Message.MessageId
+	}
+
+	channelID = createChannel("general", "")
+	createChannel("general-sub", channelID)
+	doomed := createChannel("doomed", "")
+
+	first := sendMessage(channelID, "hello")
+	second := sendMessage(channelID, "typo")
+	sendMessage(channelID, "gone soon")
+	if _, err := s.messages.UpdateMessage(ctx, &pb.UpdateMessageRequest{MessageId: second, Content: "fixed", UpdateMask: []string{"content"}}); err != nil {
+		t.Fatalf("UpdateMessage: %v", err)
+	}
+	if _, err := s.messages.AddReaction(ctx, &pb.AddReactionRequest{MessageId: first, Emoji: "👍"}); err != nil {
+		t.Fatalf("AddReaction: %v", err)
+	}
+	if _, err := s.messages.DeleteMessage(ctx, &pb.DeleteMessageRequest{MessageId: sendMessage(channelID, "oops")}); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+
+	thread, err := s.channels.StartThread(ctx, &pb.StartThreadRequest{MessageId: first, Name: "about hello"})
+	if err != nil {
+		t.Fatalf("StartThread: %v", err)
+	}
+	sendMessage(thread.Thread.ChannelId, "in the thread")
+
+	// Deleting a channel takes its threads and messages along
+	doomedThread, err := s.channels.StartThread(ctx, &pb.StartThreadRequest{MessageId: sendMessage(doomed, "bye")})
+	if err != nil {
+		t.Fatalf("StartThread: %v", err)
+	}
+	sendMessage(doomedThread.Thread.ChannelId, "bye too")
+	if _, err := s.channels.DeleteChannel(ctx, &pb.DeleteChannelRequest{ChannelId: doomed}); err != nil {
+		t.Fatalf("DeleteChannel: %v", err)
+	}
+
+	return channelID
+}
+
+func TestReplayVerifyMatchesStoredState(t *testing.T) {
+	s := newTestServer(t, nil)
+	buildHistory(t, s)
+
+	report, err := s.manager.ReplayDatabase(context.Background(), "srv1", ReplayOptions{Verify: true})
+	if err != nil {
+		t.Fatalf("ReplayDatabase: %v", err)
+	}
+	if report.Events == 0 {
+		t.Fatal("replay applied no events")
+	}
+	if report.Backup != "" {
+		t.Errorf("verifying replay took a backup: %s", report.Backup)
+	}
+	for _, skipped := range report.Skipped {
+		t.Errorf("skipped %s", skipped)
+	}
+	for _, difference := range report.Differences {
+		t.Errorf("difference: %s", difference)
+	}
+}
+
+func TestReplayVerifyAfterRetention(t *testing.T) {
+	// Every event type but the projected ones is cut down to one per scope
+	s := newTestServer(t, map[string]string{"FUWA_EVENT_RETENTION": "max_count=1"})
+	buildHistory(t, s)
+
+	// Retention skips events still waiting in the outbox
+	s.events.dispatchOutbox(true)
+	maintenance, err := NewEventMaintenance(s.config, s.router, s.events)
+	if err != nil {
+		t.Fatalf("NewEventMaintenance: %v", err)
+	}
+	maintenance.Run(context.Background())
+	if err := s.manager.CloseDatabase("srv1"); err != nil {
+		t.Fatalf("CloseDatabase: %v", err)
+	}
+
+	report, err := s.manager.ReplayDatabase(context.Background(), "srv1", ReplayOptions{Verify: true})
+	if err != nil {
+		t.Fatalf("ReplayDatabase: %v", err)
+	}
+	for _, difference := range report.Differences {
+		t.Errorf("difference after retention: %s", difference)
+	}
+}
+
+func TestRetentionRejectsProjectedEventTypes(t *testing.T) {
+	for _, raw := range []string{"type=message.sent max_age=1h", "scope=server:* type=channel.deleted max_count=10"} {
+		if _, err := parseRetentionRules(raw); err == nil {
+			t.Errorf("parseRetentionRules(%q) accepted a projected event type", raw)
+		}
+	}
+	if _, err := parseRetentionRules("type=presence.* max_age=1h; type=app.ping max_count=10"); err != nil {
+		t.Errorf("parseRetentionRules: %v", err)
+	}
+}
+
+func TestReplayRepairsDrift(t *testing.T) {
+	s := newTestServer(t, nil)
+	channelID := buildHistory(t, s)
+
+	tamper := func() {
+		t.Helper()
+		lease, err := s.manager.Acquire("srv1")
+		if err != nil {
+			t.Fatalf("Acquire: %v", err)
+		}
+		defer lease.Release()
+		if _, err := lease.DB.Exec("UPDATE channels SET name = 'tampered' WHERE channel_id = ?", channelID); err != nil {
+			t.Fatalf("tamper: %v", err)
+		}
+	}
+	tamper()
+	if err := s.manager.CloseDatabase("srv1"); err != nil {
+		t.Fatalf("CloseDatabase: %v", err)
+	}
+
+	report, err := s.manager.ReplayDatabase(context.Background(), "srv1", ReplayOptions{Verify: true})
+	if err != nil {
+		t.Fatalf("ReplayDatabase: %v", err)
+	}
+	if len(report.Differences) != 1 || !strings.HasPrefix(report.Differences[0], "channel "+channelID+":") {
+		t.Fatalf("differences %q, want only channel %s", report.Differences, channelID)
+	}
+
+	report, err = s.manager.ReplayDatabase(context.Background(), "srv1", ReplayOptions{})
+	if err != nil {
+		t.Fatalf("ReplayDatabase: %v", err)
+	}
+	if report.Backup == "" {
+		t.Error("rebuilding replay took no backup")
+	}
+
+	report, err = s.manager.ReplayDatabase(context.Background(), "srv1", ReplayOptions{Verify: true})
+	if err != nil {
+		t.Fatalf("ReplayDatabase: %v", err)
+	}
+	for _, difference := range report.Differences {
+		t.Errorf("difference after rebuild: %s", difference)
+	}
+}
+
+func TestReplayRefusesOpenDatabase(t *testing.T) {
+	s := newTestServer(t, nil)
+	buildHistory(t, s)
+
+	lease, err := s.manager.Acquire("srv1")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	defer lease.Release()
+
+	if _, err := s.manager.ReplayDatabase(context.Background(), "srv1", ReplayOptions{Verify: true}); err == nil {
+		t.Fatal("replayed a database in use")
+	}
+}
@@ -0,0 +1,595 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/waifu-devs/fuwa/server/database"
+	"github.com/waifu-devs/fuwa/server/id"
+	pb "github.com/waifu-devs/fuwa/server/proto"
+)
+
+type roleServiceServer struct {
+	pb.UnimplementedRoleServiceServer
+	router       *DatabaseRouter
+	eventService *eventServiceServer
+	permissions  *PermissionResolver
+}
+
+func NewRoleServiceServer(router *DatabaseRouter, eventService *eventServiceServer, permissions *PermissionResolver) *roleServiceServer {
+	return &roleServiceServer{
+		router:       router,
+		eventService: eventService,
+		permissions:  permissions,
+	}
+}
+
+func (s *roleServiceServer) CreateRole(ctx context.Context, req *pb.CreateRoleRequest) (*pb.CreateRoleResponse, error) {
+	if req.ServerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "server_id is required")
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "role name is required")
+	}
+
+	if _, err := s.router.ForServer(ctx, req.ServerId); err != nil {
+		return nil, routeError(err, "server not found")
+	}
+
+	if err := s.permissions.BootstrapServer(ctx, req.ServerId, s.permissions.callerID(ctx)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to bootstrap server roles: %v", err)
+	}
+	if err := s.requireGrantable(ctx, req.ServerId, Permissions(req.Permissions)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	roleID := id.New(id.Role)
+
+	// The route lives in the primary database, so it is added before and
+	// removed again if the role cannot be created
+	if err := s.router.AddRoute(ctx, roleID, req.ServerId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to register role route: %v", err)
+	}
+
+	var dbRole database.Role
+	err := s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", req.ServerId), func(ctx context.Context, tx *database.Queries) error {
+		var err error
+		dbRole, err = tx.CreateRole(ctx, database.CreateRoleParams{
+			RoleID:      roleID,
+			ServerID:    req.ServerId,
+			Name:        req.Name,
+			Permissions: int64(req.Permissions & uint64(PermAll)),
+			Position:    int64(req.Position),
+			IsDefault:   0,
+			CreatedAt:   now.Unix(),
+			UpdatedAt:   now.Unix(),
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to create role: %v", err)
+		}
+
+		return s.appendRoleEvent(ctx, tx, "role.created", dbRole.ServerID, &pb.RoleCreatedPayload{Role: dbRoleToProto(&dbRole)}, map[string]string{
+			"role_id":   dbRole.RoleID,
+			"role_name": dbRole.Name,
+		})
+	})
+	if err != nil {
+		if err := s.router.RemoveRoute(ctx, roleID); err != nil {
+			log.Printf("Failed to remove route for role %s: %v", roleID, err)
+		}
+		return nil, txError(err, "create role")
+	}
+	s.eventService.notify()
+
+	return &pb.CreateRoleResponse{
+		Role: dbRoleToProto(&dbRole),
+	}, nil
+}
+
+func (s *roleServiceServer) ListRoles(ctx context.Context, req *pb.ListRolesRequest) (*pb.ListRolesResponse, error) {
+	if req.ServerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "server_id is required")
+	}
+
+	db, err := s.router.ForServer(ctx, req.ServerId)
+	if err != nil {
+		return nil, routeError(err, "server not found")
+	}
+
+	dbRoles, err := db.ListRolesByServerId(ctx, req.ServerId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list roles: %v", err)
+	}
+
+	roles := make([]*pb.Role, len(dbRoles))
+	for i, dbRole := range dbRoles {
+		roles[i] = dbRoleToProto(&dbRole)
+	}
+
+	return &pb.ListRolesResponse{
+		Roles: roles,
+	}, nil
+}
+
+func (s *roleServiceServer) UpdateRole(ctx context.Context, req *pb.UpdateRoleRequest) (*pb.UpdateRoleResponse, error) {
+	if req.RoleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "role_id is required")
+	}
+
+	_, existingRole, err := s.getRole(ctx, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+
+	name := existingRole.Name
+	perms := existingRole.Permissions
+	position := existingRole.Position
+
+	// Apply updates based on update_mask
+	for _, field := range req.UpdateMask {
+		switch field {
+		case "name":
+			if req.Name == "" {
+				return nil, status.Error(codes.InvalidArgument, "role name cannot be empty")
+			}
+			name = req.Name
+		case "permissions":
+			perms = int64(req.Permissions & uint64(PermAll))
+		case "position":
+			position = int64(req.Position)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown update_mask field %q", field)
+		}
+	}
+
+	if existingRole.IsDefault != 0 && name != existingRole.Name {
+		return nil, status.Error(codes.InvalidArgument, "the default role cannot be renamed")
+	}
+	// Both the old and the new permission set must be within the caller's reach
+	if err := s.requireGrantable(ctx, existingRole.ServerID, Permissions(existingRole.Permissions)|Permissions(perms)); err != nil {
+		return nil, err
+	}
+
+	var dbRole database.Role
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", existingRole.ServerID), func(ctx context.Context, tx *database.Queries) error {
+		dbRole, err = tx.UpdateRole(ctx, database.UpdateRoleParams{
+			Name:        name,
+			Permissions: perms,
+			Position:    position,
+			UpdatedAt:   time.Now().Unix(),
+			RoleID:      req.RoleId,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to update role: %v", err)
+		}
+
+		return s.appendRoleEvent(ctx, tx, "role.updated", dbRole.ServerID, &pb.RoleUpdatedPayload{
+			Role:          dbRoleToProto(&dbRole),
+			ChangedFields: req.UpdateMask,
+		}, map[string]string{
+			"role_id":        dbRole.RoleID,
+			"changed_fields": fmt.Sprintf("%v", req.UpdateMask),
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "update role")
+	}
+	s.eventService.notify()
+
+	return &pb.UpdateRoleResponse{
+		Role: dbRoleToProto(&dbRole),
+	}, nil
+}
+
+func (s *roleServiceServer) DeleteRole(ctx context.Context, req *pb.DeleteRoleRequest) (*pb.DeleteRoleResponse, error) {
+	if req.RoleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "role_id is required")
+	}
+
+	_, existingRole, err := s.getRole(ctx, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	if existingRole.IsDefault != 0 {
+		return nil, status.Error(codes.FailedPrecondition, "the default role cannot be deleted")
+	}
+	if err := s.requireGrantable(ctx, existingRole.ServerID, Permissions(existingRole.Permissions)); err != nil {
+		return nil, err
+	}
+
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", existingRole.ServerID), func(ctx context.Context, tx *database.Queries) error {
+		if err := tx.DeleteRoleMembersByRoleId(ctx, req.RoleId); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete role members: %v", err)
+		}
+		if err := tx.DeletePermissionOverwritesByTargetId(ctx, database.DeletePermissionOverwritesByTargetIdParams{
+			TargetType: int64(pb.OverwriteTargetType_OVERWRITE_TARGET_TYPE_ROLE),
+			TargetID:   req.RoleId,
+		}); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete role overwrites: %v", err)
+		}
+		if err := tx.DeleteRole(ctx, req.RoleId); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete role: %v", err)
+		}
+
+		return s.appendRoleEvent(ctx, tx, "role.deleted", existingRole.ServerID, &pb.RoleDeletedPayload{
+			RoleId:   existingRole.RoleID,
+			ServerId: existingRole.ServerID,
+		}, map[string]string{
+			"role_id":   existingRole.RoleID,
+			"role_name": existingRole.Name,
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "delete role")
+	}
+	s.eventService.notify()
+
+	if err := s.router.RemoveRoute(ctx, req.RoleId); err != nil {
+		log.Printf("Failed to remove route for role %s: %v", req.RoleId, err)
+	}
+
+	return &pb.DeleteRoleResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *roleServiceServer) AddRoleMember(ctx context.Context, req *pb.AddRoleMemberRequest) (*pb.AddRoleMemberResponse, error) {
+	if req.RoleId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "role_id and user_id are required")
+	}
+
+	_, existingRole, err := s.getRole(ctx, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	if existingRole.IsDefault != 0 {
+		return nil, status.Error(codes.FailedPrecondition, "every user already has the default role")
+	}
+	if err := s.requireGrantable(ctx, existingRole.ServerID, Permissions(existingRole.Permissions)); err != nil {
+		return nil, err
+	}
+
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", existingRole.ServerID), func(ctx context.Context, tx *database.Queries) error {
+		if err := tx.AddRoleMember(ctx, database.AddRoleMemberParams{
+			RoleID:    req.RoleId,
+			UserID:    req.UserId,
+			ServerID:  existingRole.ServerID,
+			CreatedAt: time.Now().Unix(),
+		}); err != nil {
+			return status.Errorf(codes.Internal, "failed to add role member: %v", err)
+		}
+
+		return s.appendRoleEvent(ctx, tx, "role.member_added", existingRole.ServerID, &pb.RoleMemberPayload{
+			RoleId:   req.RoleId,
+			ServerId: existingRole.ServerID,
+			UserId:   req.UserId,
+		}, map[string]string{
+			"role_id": req.RoleId,
+			"user_id": req.UserId,
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "add role member")
+	}
+	s.eventService.notify()
+
+	return &pb.AddRoleMemberResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *roleServiceServer) RemoveRoleMember(ctx context.Context, req *pb.RemoveRoleMemberRequest) (*pb.RemoveRoleMemberResponse, error) {
+	if req.RoleId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "role_id and user_id are required")
+	}
+
+	_, existingRole, err := s.getRole(ctx, req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireGrantable(ctx, existingRole.ServerID, Permissions(existingRole.Permissions)); err != nil {
+		return nil, err
+	}
+
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", existingRole.ServerID), func(ctx context.Context, tx *database.Queries) error {
+		removed, err := tx.RemoveRoleMember(ctx, database.RemoveRoleMemberParams{
+			RoleID: req.RoleId,
+			UserID: req.UserId,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to remove role member: %v", err)
+		}
+		if removed == 0 {
+			return status.Error(codes.NotFound, "user does not have this role")
+		}
+
+		return s.appendRoleEvent(ctx, tx, "role.member_removed", existingRole.ServerID, &pb.RoleMemberPayload{
+			RoleId:   req.RoleId,
+			ServerId: existingRole.ServerID,
+			UserId:   req.UserId,
+		}, map[string]string{
+			"role_id": req.RoleId,
+			"user_id": req.UserId,
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "remove role member")
+	}
+	s.eventService.notify()
+
+	return &pb.RemoveRoleMemberResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *roleServiceServer) SetPermissionOverwrite(ctx context.Context, req *pb.SetPermissionOverwriteRequest) (*pb.SetPermissionOverwriteResponse, error) {
+	overwrite := req.Overwrite
+	if overwrite == nil || overwrite.ChannelId == "" || overwrite.TargetId == "" {
+		return nil, status.Error(codes.InvalidArgument, "overwrite with channel_id and target_id is required")
+	}
+	if overwrite.TargetType == pb.OverwriteTargetType_OVERWRITE_TARGET_TYPE_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "overwrite target_type is required")
+	}
+	if overwrite.Allow&overwrite.Deny != 0 {
+		return nil, status.Error(codes.InvalidArgument, "a permission cannot be both allowed and denied")
+	}
+
+	_, channel, err := s.requireChannelRoles(ctx, overwrite.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireGrantable(ctx, channel.ServerID.String, Permissions(overwrite.Allow|overwrite.Deny)); err != nil {
+		return nil, err
+	}
+
+	var protoOverwrite *pb.PermissionOverwrite
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", channel.ServerID.String), func(ctx context.Context, tx *database.Queries) error {
+		dbOverwrite, err := tx.SetPermissionOverwrite(ctx, database.SetPermissionOverwriteParams{
+			ChannelID:  overwrite.ChannelId,
+			TargetType: int64(overwrite.TargetType),
+			TargetID:   overwrite.TargetId,
+			Allow:      int64(overwrite.Allow & uint64(PermAll)),
+			Deny:       int64(overwrite.Deny & uint64(PermAll)),
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to set permission overwrite: %v", err)
+		}
+
+		protoOverwrite = dbOverwriteToProto(&dbOverwrite)
+		return s.appendRoleEvent(ctx, tx, "channel.permissions_updated", channel.ServerID.String, &pb.ChannelPermissionsUpdatedPayload{
+			ChannelId: overwrite.ChannelId,
+			Overwrite: protoOverwrite,
+		}, map[string]string{
+			"channel_id": overwrite.ChannelId,
+			"target_id":  overwrite.TargetId,
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "set permission overwrite")
+	}
+	s.eventService.notify()
+
+	return &pb.SetPermissionOverwriteResponse{
+		Overwrite: protoOverwrite,
+	}, nil
+}
+
+func (s *roleServiceServer) DeletePermissionOverwrite(ctx context.Context, req *pb.DeletePermissionOverwriteRequest) (*pb.DeletePermissionOverwriteResponse, error) {
+	if req.ChannelId == "" || req.TargetId == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel_id and target_id are required")
+	}
+
+	_, channel, err := s.requireChannelRoles(ctx, req.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.router.InScopeTx(ctx, fmt.Sprintf("server:%s", channel.ServerID.String), func(ctx context.Context, tx *database.Queries) error {
+		deleted, err := tx.DeletePermissionOverwrite(ctx, database.DeletePermissionOverwriteParams{
+			ChannelID:  req.ChannelId,
+			TargetType: int64(req.TargetType),
+			TargetID:   req.TargetId,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to delete permission overwrite: %v", err)
+		}
+		if deleted == 0 {
+			return status.Error(codes.NotFound, "permission overwrite not found")
+		}
+
+		return s.appendRoleEvent(ctx, tx, "channel.permissions_updated", channel.ServerID.String, &pb.ChannelPermissionsUpdatedPayload{
+			ChannelId: req.ChannelId,
+			Overwrite: &pb.PermissionOverwrite{
+				ChannelId:  req.ChannelId,
+				TargetType: req.TargetType,
+				TargetId:   req.TargetId,
+			},
+			Deleted: true,
+		}, map[string]string{
+			"channel_id": req.ChannelId,
+			"target_id":  req.TargetId,
+		})
+	})
+	if err != nil {
+		return nil, txError(err, "delete permission overwrite")
+	}
+	s.eventService.notify()
+
+	return &pb.DeletePermissionOverwriteResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *roleServiceServer) ListPermissionOverwrites(ctx context.Context, req *pb.ListPermissionOverwritesRequest) (*pb.ListPermissionOverwritesResponse, error) {
+	if req.ChannelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel_id is required")
+	}
+
+	db, channel, err := s.getChannel(ctx, req.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.permissions.RequireChannelPermission(ctx, channel, PermViewChannel); err != nil {
+		return nil, err
+	}
+
+	dbOverwrites, err := db.GetPermissionOverwritesByChannelId(ctx, req.ChannelId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list permission overwrites: %v", err)
+	}
+
+	overwrites := make([]*pb.PermissionOverwrite, len(dbOverwrites))
+	for i, dbOverwrite := range dbOverwrites {
+		overwrites[i] = dbOverwriteToProto(&dbOverwrite)
+	}
+
+	return &pb.ListPermissionOverwritesResponse{
+		Overwrites: overwrites,
+	}, nil
+}
+
+func (s *roleServiceServer) GetPermissions(ctx context.Context, req *pb.GetPermissionsRequest) (*pb.GetPermissionsResponse, error) {
+	if req.ServerId == "" && req.ChannelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "server_id or channel_id is required")
+	}
+
+	userID := req.UserId
+	if userID == "" {
+		userID = s.permissions.callerID(ctx)
+	}
+
+	var perms Permissions
+	if req.ChannelId != "" {
+		_, channel, err := s.getChannel(ctx, req.ChannelId)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.permissions.RequireChannelPermission(ctx, channel, PermViewChannel); err != nil {
+			return nil, err
+		}
+		perms, err = s.permissions.ChannelPermissions(ctx, userID, channel)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to resolve permissions: %v", err)
+		}
+	} else {
+		var err error
+		perms, err = s.permissions.ServerPermissions(ctx, userID, req.ServerId)
+		if err != nil {
+			return nil, permissionError(err)
+		}
+	}
+
+	return &pb.GetPermissionsResponse{
+		Permissions: uint64(perms),
+	}, nil
+}
+
+// getRole loads a role together with the database of the server it belongs to.
+func (s *roleServiceServer) getRole(ctx context.Context, roleID string) (*database.Queries, *database.Role, error) {
+	db, _, err := s.router.ForResource(ctx, roleID)
+	if err != nil {
+		return nil, nil, routeError(err, "role not found")
+	}
+
+	dbRole, err := db.GetRole(ctx, roleID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, status.Error(codes.NotFound, "role not found")
+		}
+		return nil, nil, status.Errorf(codes.Internal, "failed to get role: %v", err)
+	}
+	return db, &dbRole, nil
+}
+
+// getChannel loads a channel together with the database of the server it belongs to.
+func (s *roleServiceServer) getChannel(ctx context.Context, channelID string) (*database.Queries, *database.Channel, error) {
+	db, _, err := s.router.ForResource(ctx, channelID)
+	if err != nil {
+		return nil, nil, routeError(err, "channel not found")
+	}
+
+	dbChannel, err := db.GetChannel(ctx, channelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, status.Error(codes.NotFound, "channel not found")
+		}
+		return nil, nil, status.Errorf(codes.Internal, "failed to get channel: %v", err)
+	}
+	return db, &dbChannel, nil
+}
+
+// requireChannelRoles loads a channel whose overwrites the caller may manage.
+func (s *roleServiceServer) requireChannelRoles(ctx context.Context, channelID string) (*database.Queries, *database.Channel, error) {
+	db, channel, err := s.getChannel(ctx, channelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.permissions.RequireChannelPermission(ctx, channel, PermManageRoles); err != nil {
+		return nil, nil, err
+	}
+	return db, channel, nil
+}
+
+// requireGrantable checks the caller can manage roles in the server and holds
+// every permission in perms, so nobody can hand out more than they have.
+func (s *roleServiceServer) requireGrantable(ctx context.Context, serverID string, perms Permissions) error {
+	callerPerms, err := s.permissions.ServerPermissions(ctx, s.permissions.callerID(ctx), serverID)
+	if err != nil {
+		return permissionError(err)
+	}
+	if !callerPerms.Has(PermManageRoles) {
+		return status.Error(codes.PermissionDenied, "missing permission to manage roles")
+	}
+	if !callerPerms.Has(perms & PermAll) {
+		return status.Error(codes.PermissionDenied, "cannot grant permissions you do not have")
+	}
+	return nil
+}
+
+// appendRoleEvent records a role or permission event in the transaction of
+// the change it describes.
+func (s *roleServiceServer) appendRoleEvent(ctx context.Context, tx *database.Queries, eventType, serverID string, payload proto.Message, metadata map[string]string) error {
+	return s.eventService.appendEvent(ctx, tx, &pb.Event{
+		EventId:   id.New(id.Event),
+		EventType: eventType,
+		Scope:     fmt.Sprintf("server:%s", serverID),
+		ActorId:   getActorFromContext(ctx),
+		Timestamp: timestamppb.Now(),
+		Payload:   eventPayload(payload),
+		Metadata:  metadata,
+	})
+}
+
+// Helper function to convert database role to proto role
+func dbRoleToProto(dbRole *database.Role) *pb.Role {
+	return &pb.Role{
+		RoleId:      dbRole.RoleID,
+		ServerId:    dbRole.ServerID,
+		Name:        dbRole.Name,
+		Permissions: uint64(dbRole.Permissions),
+		Position:    int32(dbRole.Position),
+		IsDefault:   int64ToBool(dbRole.IsDefault),
+		CreatedAt:   timestamppb.New(time.Unix(dbRole.CreatedAt, 0)),
+		UpdatedAt:   timestamppb.New(time.Unix(dbRole.UpdatedAt, 0)),
+	}
+}
+
+// Helper function to convert database permission overwrite to proto
+func dbOverwriteToProto(dbOverwrite *database.PermissionOverwrite) *pb.PermissionOverwrite {
+	return &pb.PermissionOverwrite{
+		ChannelId:  dbOverwrite.ChannelID,
+		TargetType: pb.OverwriteTargetType(dbOverwrite.TargetType),
+		TargetId:   dbOverwrite.TargetID,
+		Allow:      uint64(dbOverwrite.Allow),
+		Deny:       uint64(dbOverwrite.Deny),
+	}
+}
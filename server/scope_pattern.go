@@ -0,0 +1,258 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Scopes are hierarchical paths of "<kind>:<id>" segments separated by "/",
+// outermost first:
+//
+//	scope   = segment *( "/" segment )
+//	segment = kind [ ":" id ]
+//	kind    = 1*( "a"-"z" / "_" )
+//	id      = 1*( any character except "/" and "*" )
+//
+// Events are stored with their innermost scope ("server:abc",
+// "channel:xyz"); for matching, a channel scope is expanded to the path
+// "server:<id>/channel:<id>" of the server the channel belongs to.
+//
+// Subscription patterns use the same grammar with two wildcards:
+//
+//	"kind:*"  matches one segment of that kind, e.g. "server:abc/channel:*"
+//	"*"       matches any one segment; as the last segment it matches any
+//	          number, so "server:abc/*" covers server abc and everything in it
+//
+// A pattern starting with "channel:" is relative to any server, so the flat
+// "channel:xyz" keeps working. Event types are dot separated segments of
+// letters, digits, '_' and '-'; their patterns support the same "*"
+// segment, e.g. "message.*" or "*.deleted".
+
+// eventTypeSegment is the grammar of one segment of an event type, shared by
+// subscription patterns and the event types clients publish.
+const eventTypeSegment = `[A-Za-z0-9_-]+`
+
+var (
+	scopeSegmentPattern     = regexp.MustCompile(`^[a-z_]+(:([^/*]+|\*))?$`)
+	eventTypeSegmentPattern = regexp.MustCompile(`^` + eventTypeSegment + `$`)
+)
+
+// pattern is a parsed scope or event type pattern, one entry per segment.
+type pattern []string
+
+// parseScopePattern validates a scope pattern and splits it into segments.
+func parseScopePattern(raw string) (pattern, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("scope pattern is empty")
+	}
+
+	segments := strings.Split(raw, "/")
+	for _, segment := range segments {
+		if segment != "*" && !scopeSegmentPattern.MatchString(segment) {
+			return nil, fmt.Errorf("invalid segment %q in scope pattern %q", segment, raw)
+		}
+	}
+
+	if strings.HasPrefix(segments[0], "channel:") {
+		segments = append([]string{"server:*"}, segments...)
+	}
+	return segments, nil
+}
+
+// parseEventTypePattern validates an event type pattern such as "message.*".
+func parseEventTypePattern(raw string) (pattern, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("event type pattern is empty")
+	}
+
+	segments := strings.Split(raw, ".")
+	for _, segment := range segments {
+		if segment != "*" && !eventTypeSegmentPattern.MatchString(segment) {
+			return nil, fmt.Errorf("invalid segment %q in event type pattern %q", segment, raw)
+		}
+	}
+	return segments, nil
+}
+
+// parseSubscriptionPatterns parses the event type and scope patterns of a
+// subscription. No scopes means every scope the caller can see.
+func parseSubscriptionPatterns(rawEventTypes, rawScopes []string) ([]pattern, []pattern, error) {
+	eventTypes := make([]pattern, 0, len(rawEventTypes))
+	for _, raw := range rawEventTypes {
+		p, err := parseEventTypePattern(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		eventTypes = append(eventTypes, p)
+	}
+
+	if len(rawScopes) == 0 {
+		return eventTypes, []pattern{{"*"}}, nil
+	}
+	scopes := make([]pattern, 0, len(rawScopes))
+	for _, raw := range rawScopes {
+		p, err := parseScopePattern(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		scopes = append(scopes, p)
+	}
+	return eventTypes, scopes, nil
+}
+
+// hasWildcard reports whether the pattern matches more than one value.
+func (p pattern) hasWildcard() bool {
+	for _, segment := range p {
+		if segment == "*" || strings.HasSuffix(segment, ":*") {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether a path, split into segments, matches the pattern.
+func (p pattern) matches(segments []string) bool {
+	for i, want := range p {
+		if want == "*" && i == len(p)-1 {
+			return len(segments) >= i
+		}
+		if i >= len(segments) || !segmentMatches(want, segments[i]) {
+			return false
+		}
+	}
+	return len(segments) == len(p)
+}
+
+// eventTypeMatches reports whether an event type matches any of the
+// patterns; no patterns match every type.
+func eventTypeMatches(patterns []pattern, eventType string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	segments := strings.Split(eventType, ".")
+	for _, p := range patterns {
+		if p.matches(segments) {
+			return true
+		}
+	}
+	return false
+}
+
+func segmentMatches(want, segment string) bool {
+	if want == "*" || want == segment {
+		return true
+	}
+	if kind, ok := strings.CutSuffix(want, "*"); ok && strings.HasSuffix(kind, ":") {
+		return strings.HasPrefix(segment, kind)
+	}
+	return false
+}
+
+// kindWildcard returns the "kind:*" pattern segment matching a segment, or
+// "" for segments without an id.
+func kindWildcard(segment string) string {
+	kind, _, ok := strings.Cut(segment, ":")
+	if !ok {
+		return ""
+	}
+	return kind + ":*"
+}
+
+// scopeIndex finds the subscribers whose scope patterns match an event
+// without testing every subscriber: patterns are stored in a trie keyed by
+// segment, and a lookup only follows the exact, "kind:*" and "*" branches of
+// each segment of the event's path.
+type scopeIndex struct {
+	root *scopeNode
+}
+
+type scopeNode struct {
+	children map[string]*scopeNode
+	// exact holds subscribers whose pattern ends at this node
+	exact map[string]struct{}
+	// subtree holds subscribers whose pattern ends in "*" here, matching any
+	// number of further segments
+	subtree map[string]struct{}
+}
+
+func newScopeNode() *scopeNode {
+	return &scopeNode{
+		children: make(map[string]*scopeNode),
+		exact:    make(map[string]struct{}),
+		subtree:  make(map[string]struct{}),
+	}
+}
+
+func newScopeIndex() *scopeIndex {
+	return &scopeIndex{root: newScopeNode()}
+}
+
+// add registers a subscriber for a pattern.
+func (idx *scopeIndex) add(id string, p pattern) {
+	node := idx.root
+	for i, segment := range p {
+		if segment == "*" && i == len(p)-1 {
+			node.subtree[id] = struct{}{}
+			return
+		}
+		child, ok := node.children[segment]
+		if !ok {
+			child = newScopeNode()
+			node.children[segment] = child
+		}
+		node = child
+	}
+	node.exact[id] = struct{}{}
+}
+
+// remove unregisters a subscriber's pattern and prunes emptied branches.
+func (idx *scopeIndex) remove(id string, p pattern) {
+	idx.root.remove(id, p)
+}
+
+func (n *scopeNode) remove(id string, p pattern) {
+	if len(p) == 1 && p[0] == "*" {
+		delete(n.subtree, id)
+		return
+	}
+	if len(p) == 0 {
+		delete(n.exact, id)
+		return
+	}
+
+	child, ok := n.children[p[0]]
+	if !ok {
+		return
+	}
+	child.remove(id, p[1:])
+	if len(child.children) == 0 && len(child.exact) == 0 && len(child.subtree) == 0 {
+		delete(n.children, p[0])
+	}
+}
+
+// match returns the IDs of subscribers with a pattern matching the path.
+func (idx *scopeIndex) match(segments []string) map[string]struct{} {
+	matched := make(map[string]struct{})
+	idx.root.collect(segments, matched)
+	return matched
+}
+
+func (n *scopeNode) collect(segments []string, matched map[string]struct{}) {
+	for id := range n.subtree {
+		matched[id] = struct{}{}
+	}
+	if len(segments) == 0 {
+		for id := range n.exact {
+			matched[id] = struct{}{}
+		}
+		return
+	}
+
+	segment := segments[0]
+	for _, key := range []string{segment, kindWildcard(segment), "*"} {
+		if child, ok := n.children[key]; ok && key != "" {
+			child.collect(segments[1:], matched)
+		}
+	}
+}
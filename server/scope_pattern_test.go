@@ -0,0 +1,158 @@
+package server
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseScopePattern(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{raw: "server:abc", want: []string{"server:abc"}},
+		{raw: "server:abc/*", want: []string{"server:abc", "*"}},
+		{raw: "server:abc/channel:*", want: []string{"server:abc", "channel:*"}},
+		{raw: "channel:xyz", want: []string{"server:*", "channel:xyz"}},
+		{raw: "*", want: []string{"*"}},
+		{raw: "user:u_1", want: []string{"user:u_1"}},
+		{raw: ""},
+		{raw: "server:abc/"},
+		{raw: "Server:abc"},
+		{raw: "server:a*c"},
+		{raw: "server:abc//channel:xyz"},
+	}
+
+	for _, tt := range tests {
+		got, err := parseScopePattern(tt.raw)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("parseScopePattern(%q) = %v, want error", tt.raw, got)
+			}
+			continue
+		}
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("parseScopePattern(%q) = %v, %v, want %v", tt.raw, got, err, tt.want)
+		}
+	}
+}
+
+func TestEventTypeGrammarMatchesPublish(t *testing.T) {
+	// Every type clients may publish can be subscribed to exactly
+	for _, eventType := range []string{"app.ping", "App.Ping", "my-app.user_joined", "app.v2.ping", "x.1"} {
+		if err := validatePublishedEventType(eventType); err != nil {
+			t.Errorf("validatePublishedEventType(%q): %v", eventType, err)
+			continue
+		}
+		p, err := parseEventTypePattern(eventType)
+		if err != nil {
+			t.Errorf("parseEventTypePattern(%q): %v", eventType, err)
+			continue
+		}
+		if !eventTypeMatches([]pattern{p}, eventType) {
+			t.Errorf("pattern %q doesn't match itself", eventType)
+		}
+	}
+
+	for _, raw := range []string{"", "app..ping", "app.pi ng", "app.ping!", "app.*x"} {
+		if _, err := parseEventTypePattern(raw); err == nil {
+			t.Errorf("parseEventTypePattern(%q) accepted an invalid pattern", raw)
+		}
+	}
+}
+
+func TestEventTypeMatches(t *testing.T) {
+	tests := []struct {
+		patterns  []string
+		eventType string
+		want      bool
+	}{
+		{eventType: "message.sent", want: true},
+		{patterns: []string{"message.sent"}, eventType: "message.sent", want: true},
+		{patterns: []string{"message.sent"}, eventType: "message.updated"},
+		{patterns: []string{"message.*"}, eventType: "message.sent", want: true},
+		{patterns: []string{"message.*"}, eventType: "message.reaction.added", want: true},
+		// A trailing "*" matches zero segments too, as in "server:abc/*"
+		{patterns: []string{"message.*"}, eventType: "message", want: true},
+		{patterns: []string{"*.deleted"}, eventType: "channel.deleted", want: true},
+		{patterns: []string{"*.deleted"}, eventType: "channel.updated"},
+		{patterns: []string{"App.Ping"}, eventType: "app.ping"},
+		{patterns: []string{"channel.*", "*.sent"}, eventType: "message.sent", want: true},
+	}
+
+	for _, tt := range tests {
+		var patterns []pattern
+		for _, raw := range tt.patterns {
+			p, err := parseEventTypePattern(raw)
+			if err != nil {
+				t.Fatalf("parseEventTypePattern(%q): %v", raw, err)
+			}
+			patterns = append(patterns, p)
+		}
+		if got := eventTypeMatches(patterns, tt.eventType); got != tt.want {
+			t.Errorf("eventTypeMatches(%v, %q) = %v, want %v", tt.patterns, tt.eventType, got, tt.want)
+		}
+	}
+}
+
+func TestScopeIndexAgreesWithMatches(t *testing.T) {
+	rawPatterns := []string{
+		"server:a",
+		"server:a/*",
+		"server:a/channel:*",
+		"server:*/channel:x",
+		"channel:y",
+		"*",
+		"*/channel:*",
+		"user:u1",
+		"server:b/channel:x",
+	}
+	paths := [][]string{
+		{"server:a"},
+		{"server:a", "channel:x"},
+		{"server:a", "channel:y"},
+		{"server:b", "channel:x"},
+		{"server:b"},
+		{"user:u1"},
+		{"user:u2"},
+		{"config:global"},
+	}
+
+	idx := newScopeIndex()
+	patterns := make(map[string]pattern)
+	for _, raw := range rawPatterns {
+		p, err := parseScopePattern(raw)
+		if err != nil {
+			t.Fatalf("parseScopePattern(%q): %v", raw, err)
+		}
+		patterns[raw] = p
+		idx.add(raw, p)
+	}
+
+	check := func() {
+		t.Helper()
+		for _, path := range paths {
+			var want []string
+			for raw, p := range patterns {
+				if p.matches(path) {
+					want = append(want, raw)
+				}
+			}
+			slices.Sort(want)
+			got := slices.Sorted(maps.Keys(idx.match(path)))
+			if !slices.Equal(got, want) {
+				t.Errorf("%s: index matched %v, want %v", strings.Join(path, "/"), got, want)
+			}
+		}
+	}
+	check()
+
+	// Removing patterns prunes them from the index without touching others
+	for _, raw := range []string{"server:a/*", "*", "channel:y"} {
+		idx.remove(raw, patterns[raw])
+		delete(patterns, raw)
+	}
+	check()
+}